@@ -0,0 +1,123 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Access points are managed through the S3 Control API rather than the S3
+// API the rest of this package is generated from, so the AccessPoint types
+// are maintained by hand.
+
+// AccessPointSpec defines the desired state of AccessPoint.
+type AccessPointSpec struct {
+
+	// The name of the bucket the access point is associated with.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
+	Bucket    *string                                  `json:"bucket,omitempty"`
+	BucketRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"bucketRef,omitempty"`
+	// The ID of the AWS account that owns the bucket, when it is not the account
+	// of the access point.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
+	BucketAccountID *string `json:"bucketAccountID,omitempty"`
+	// The name of the access point.
+	//
+	// For directory buckets, the name must consist of a base name followed by
+	// the zone ID of the bucket and the --xa-s3 suffix.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
+	// +kubebuilder:validation:Required
+	Name *string `json:"name"`
+	// The access point policy, as a JSON string. It is compared with the policy
+	// returned by S3 by the permissions it grants rather than by its
+	// serialization.
+	Policy *string `json:"policy,omitempty"`
+	// The public access block configuration of the access point. S3 blocks all
+	// public access when it is not set.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
+	PublicAccessBlockConfiguration *PublicAccessBlockConfiguration `json:"publicAccessBlockConfiguration,omitempty"`
+	// The prefixes and API operations the access point is restricted to.
+	//
+	// Only supported for access points attached to directory buckets.
+	Scope *AccessPointScope `json:"scope,omitempty"`
+	// Restricts access to the access point to requests from a VPC.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
+	VPCConfiguration *VPCConfiguration `json:"vpcConfiguration,omitempty"`
+}
+
+// AccessPointScope restricts an access point of a directory bucket to a set
+// of prefixes, API operations, or both.
+type AccessPointScope struct {
+	// The API operations allowed through the access point, e.g. GetObject or
+	// PutObject.
+	Permissions []*string `json:"permissions,omitempty"`
+	// The object key prefixes allowed through the access point.
+	Prefixes []*string `json:"prefixes,omitempty"`
+}
+
+// VPCConfiguration restricts access to an access point to requests from a
+// VPC.
+type VPCConfiguration struct {
+	// The ID of the VPC.
+	VPCID *string `json:"vpcID,omitempty"`
+}
+
+// AccessPointStatus defines the observed state of AccessPoint
+type AccessPointStatus struct {
+	// All CRs managed by ACK have a common `Status.ACKResourceMetadata` member
+	// that is used to contain resource sync state, account ownership,
+	// constructed ARN for the resource
+	// +kubebuilder:validation:Optional
+	ACKResourceMetadata *ackv1alpha1.ResourceMetadata `json:"ackResourceMetadata"`
+	// All CRs managed by ACK have a common `Status.Conditions` member that
+	// contains a collection of `ackv1alpha1.Condition` objects that describe
+	// the various terminal states of the CR and its backend AWS service API
+	// resource
+	// +kubebuilder:validation:Optional
+	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// The alias of the access point, which can be used wherever a bucket name
+	// is expected.
+	// +kubebuilder:validation:Optional
+	Alias *string `json:"alias,omitempty"`
+	// The endpoints of the access point, by endpoint type.
+	// +kubebuilder:validation:Optional
+	Endpoints map[string]*string `json:"endpoints,omitempty"`
+	// Whether the access point accepts requests from the Internet or only from
+	// its VPC. One of Internet or VPC.
+	// +kubebuilder:validation:Optional
+	NetworkOrigin *string `json:"networkOrigin,omitempty"`
+}
+
+// AccessPoint is the Schema for the AccessPoints API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+type AccessPoint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              AccessPointSpec   `json:"spec,omitempty"`
+	Status            AccessPointStatus `json:"status,omitempty"`
+}
+
+// AccessPointList contains a list of AccessPoint
+// +kubebuilder:object:root=true
+type AccessPointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccessPoint `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AccessPoint{}, &AccessPointList{})
+}
@@ -0,0 +1,50 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+const (
+	// AnnotationPrefix is the prefix for all S3 controller specific
+	// annotations.
+	AnnotationPrefix = "s3.services.k8s.aws/"
+
+	// AnnotationForceDelete is an annotation whose value, when set to "true",
+	// instructs the controller to empty the bucket before calling
+	// DeleteBucket. All object versions, delete markers and incomplete
+	// multipart uploads are removed, so this must only be set on buckets
+	// whose contents are disposable.
+	AnnotationForceDelete = AnnotationPrefix + "force-delete"
+
+	// AnnotationManageDestinationPolicies is an annotation whose value, when
+	// set to "true", instructs the controller to add the statements granting
+	// log delivery, inventory and analytics reports and replication to the
+	// policy of the destination buckets set through references to other
+	// Buckets, and to remove them once the bucket stops delivering to a
+	// destination or is deleted. Removing the annotation removes them too.
+	AnnotationManageDestinationPolicies = AnnotationPrefix + "manage-destination-policies"
+
+	// AnnotationDeletionProtection is an annotation whose value, when set to
+	// "true", prevents the bucket from being deleted: the deletion of the
+	// Bucket is rejected by the validating webhook and, should it go through,
+	// the controller retains the S3 bucket. When set to "false", the bucket
+	// is not protected regardless of the controller-wide default set with the
+	// --bucket-deletion-protection flag.
+	AnnotationDeletionProtection = AnnotationPrefix + "deletion-protection"
+
+	// AnnotationDefaultBucketClass is an annotation of BucketClass whose
+	// value, when set to "true", makes the class apply to the Buckets created
+	// without selecting a class with spec.bucketClassName. The class is
+	// recorded in the status of those Buckets, so that changing the default
+	// class leaves the existing ones alone.
+	AnnotationDefaultBucketClass = AnnotationPrefix + "is-default-class"
+)
@@ -39,6 +39,10 @@ type BucketSpec struct {
 	// Container for setting the transfer acceleration state.
 	Accelerate *AccelerateConfiguration  `json:"accelerate,omitempty"`
 	Analytics  []*AnalyticsConfiguration `json:"analytics,omitempty"`
+	// The name of the BucketClass whose defaults are merged under this spec.
+	// When unset, the default BucketClass at the time the bucket is created
+	// applies, if any.
+	BucketClassName *string `json:"bucketClassName,omitempty"`
 	// Describes the cross-origin access configuration for objects in an Amazon
 	// S3 bucket. For more information, see Enabling Cross-Origin Resource Sharing
 	// (https://docs.aws.amazon.com/AmazonS3/latest/dev/cors.html) in the Amazon
@@ -76,8 +80,19 @@ type BucketSpec struct {
 	// Container for lifecycle rules. You can add as many as 1,000 rules.
 	Lifecycle *BucketLifecycleConfiguration `json:"lifecycle,omitempty"`
 	// Container for logging status information.
-	Logging *BucketLoggingStatus    `json:"logging,omitempty"`
-	Metrics []*MetricsConfiguration `json:"metrics,omitempty"`
+	Logging *BucketLoggingStatus `json:"logging,omitempty"`
+	// Which bucket configurations the controller manages. With all, the
+	// default, a configuration left out of the spec is removed from the
+	// bucket. With explicit, only the configurations set in the spec are
+	// managed, and the others are left to other tools. Configurations the
+	// controller late-initializes, such as encryption, are recorded in the
+	// spec and managed from then on.
+	// +kubebuilder:validation:Enum=all;explicit
+	ManagedFields *string `json:"managedFields,omitempty"`
+	// The S3 Metadata configuration of the bucket, which records its objects
+	// in journal and inventory tables managed by S3.
+	MetadataConfiguration *MetadataConfiguration  `json:"metadataConfiguration,omitempty"`
+	Metrics               []*MetricsConfiguration `json:"metrics,omitempty"`
 	// The name of the bucket to create.
 	//
 	// General purpose buckets - For information about bucket naming restrictions,
@@ -132,6 +147,10 @@ type BucketSpec struct {
 	// For directory buckets, the only IAM action supported in the bucket policy
 	// is s3express:CreateSession.
 	Policy *string `json:"policy,omitempty"`
+	// The bucket policy as structured fields, as an alternative to Policy.
+	// Placeholders such as ${bucket.arn} are resolved by the controller. Only
+	// one of Policy and PolicyDocument may be set.
+	PolicyDocument *BucketPolicyDocument `json:"policyDocument,omitempty"`
 	// The PublicAccessBlock configuration that you want to apply to this Amazon
 	// S3 bucket. You can enable the configuration options in any combination. For
 	// more information about when Amazon S3 considers a bucket or object public,
@@ -147,6 +166,11 @@ type BucketSpec struct {
 	Versioning *VersioningConfiguration `json:"versioning,omitempty"`
 	// Container for the request.
 	Website *WebsiteConfiguration `json:"website,omitempty"`
+	// The Secret or ConfigMap, in the namespace of the Bucket, the connection
+	// details of the bucket are written to, such as its name, region, ARN and
+	// endpoint URLs. The Secret or ConfigMap is owned by the Bucket. Only
+	// written by controllers run with --enable-bucket-connection-details.
+	WriteConnectionDetailsTo *ConnectionDetailsTarget `json:"writeConnectionDetailsTo,omitempty"`
 }
 
 // BucketStatus defines the observed state of Bucket
@@ -156,15 +180,43 @@ type BucketStatus struct {
 	// constructed ARN for the resource
 	// +kubebuilder:validation:Optional
 	ACKResourceMetadata *ackv1alpha1.ResourceMetadata `json:"ackResourceMetadata"`
+	// The BucketClass whose defaults were merged into the spec, and the
+	// fields they set.
+	// +kubebuilder:validation:Optional
+	BucketClass *AppliedBucketClass `json:"bucketClass,omitempty"`
+	// The region the bucket was last found in, which may differ from the
+	// region the resource is reconciled from.
+	// +kubebuilder:validation:Optional
+	BucketRegion *string `json:"bucketRegion,omitempty"`
 	// All CRs managed by ACK have a common `Status.Conditions` member that
 	// contains a collection of `ackv1alpha1.Condition` objects that describe
 	// the various terminal states of the CR and its backend AWS service API
 	// resource
 	// +kubebuilder:validation:Optional
 	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// The buckets whose policy holds the destination grants made for this
+	// bucket, which are revoked once they are no longer needed.
+	// +kubebuilder:validation:Optional
+	DestinationGrants []*string `json:"destinationGrants,omitempty"`
+	// The number of object versions and delete markers removed so far while
+	// emptying the bucket ahead of a force delete.
+	// +kubebuilder:validation:Optional
+	ForceDeleteObjectsDeleted *int64 `json:"forceDeleteObjectsDeleted,omitempty"`
+	// The number of incomplete multipart uploads aborted so far while emptying
+	// the bucket ahead of a force delete.
+	// +kubebuilder:validation:Optional
+	ForceDeleteUploadsAborted *int64 `json:"forceDeleteUploadsAborted,omitempty"`
 	// A forward slash followed by the name of the bucket.
 	// +kubebuilder:validation:Optional
 	Location *string `json:"location,omitempty"`
+	// The destination and tables of the S3 Metadata configuration of the
+	// bucket, along with the status and errors of the tables.
+	// +kubebuilder:validation:Optional
+	MetadataConfigurationResult *MetadataConfigurationResult `json:"metadataConfigurationResult,omitempty"`
+	// The outcome of applying each bucket property the last time it was
+	// updated.
+	// +kubebuilder:validation:Optional
+	SubresourceConditions []*SubresourceCondition `json:"subresourceConditions,omitempty"`
 }
 
 // Bucket is the Schema for the Buckets API
@@ -0,0 +1,110 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BucketAccessSpec defines the desired state of BucketAccess.
+type BucketAccessSpec struct {
+	// The Bucket to grant access to.
+	BucketRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"bucketRef"`
+	// The name of the ServiceAccount, in the namespace of the BucketAccess,
+	// of the workloads granted access.
+	ServiceAccountName *string `json:"serviceAccountName"`
+	// The level of access granted: read lists the bucket and reads objects,
+	// write additionally writes and deletes objects, and admin grants every
+	// S3 action. Defaults to write.
+	// +kubebuilder:validation:Enum=read;write;admin
+	// +optional
+	AccessLevel *string `json:"accessLevel,omitempty"`
+	// Restricts the access granted to the objects whose key starts with the
+	// prefix, e.g. team-a/.
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+	// How access is granted. Role, the default, creates an IAM Role resource
+	// that the ServiceAccount can assume, whose inline policy grants the
+	// access. BucketPolicy adds a statement granting the access to the IAM
+	// role the ServiceAccount already assumes to the policy of the bucket.
+	// +kubebuilder:validation:Enum=Role;BucketPolicy
+	// +optional
+	Mode *string `json:"mode,omitempty"`
+	// How the ServiceAccount assumes the role created in the Role mode:
+	// PodIdentity, the default, for EKS Pod Identity, or IRSA for IAM roles
+	// for service accounts. EKS Pod Identity also requires an association
+	// between the ServiceAccount and the role.
+	// +kubebuilder:validation:Enum=PodIdentity;IRSA
+	// +optional
+	IdentityType *string `json:"identityType,omitempty"`
+	// The ARN of the IAM OIDC provider of the cluster, required by the IRSA
+	// identity type.
+	// +optional
+	OIDCProviderARN *string `json:"oidcProviderARN,omitempty"`
+	// The ARN of the IAM principal granted access in the BucketPolicy mode.
+	// Defaults to the role of the eks.amazonaws.com/role-arn annotation of
+	// the ServiceAccount.
+	// +optional
+	PrincipalARN *string `json:"principalARN,omitempty"`
+}
+
+// BucketAccessStatus defines the observed state of BucketAccess.
+type BucketAccessStatus struct {
+	// The name of the bucket access is granted to.
+	// +kubebuilder:validation:Optional
+	BucketName *string `json:"bucketName,omitempty"`
+	// The region of the bucket access is granted to.
+	// +kubebuilder:validation:Optional
+	BucketRegion *string `json:"bucketRegion,omitempty"`
+	// The ARN of the IAM role created in the Role mode.
+	// +kubebuilder:validation:Optional
+	RoleARN *string `json:"roleARN,omitempty"`
+	// The ARN of the principal granted access through the bucket policy in
+	// the BucketPolicy mode.
+	// +kubebuilder:validation:Optional
+	PrincipalARN *string `json:"principalARN,omitempty"`
+	// The ACK.ResourceSynced condition reports whether access is granted, and
+	// the ACK.Terminal condition the problems with the spec.
+	// +kubebuilder:validation:Optional
+	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+}
+
+// BucketAccess is the Schema for the BucketAccesses API. A BucketAccess grants
+// the workloads running under a ServiceAccount access to a Bucket, and
+// revokes it when deleted.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="BUCKET",type=string,JSONPath=`.status.bucketName`
+// +kubebuilder:printcolumn:name="SERVICEACCOUNT",type=string,JSONPath=`.spec.serviceAccountName`
+// +kubebuilder:printcolumn:name="SYNCED",type=string,priority=0,JSONPath=".status.conditions[?(@.type==\"ACK.ResourceSynced\")].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",priority=0,JSONPath=".metadata.creationTimestamp"
+type BucketAccess struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BucketAccessSpec   `json:"spec,omitempty"`
+	Status            BucketAccessStatus `json:"status,omitempty"`
+}
+
+// BucketAccessList contains a list of BucketAccess
+// +kubebuilder:object:root=true
+type BucketAccessList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BucketAccess `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BucketAccess{}, &BucketAccessList{})
+}
@@ -0,0 +1,85 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BucketClassSpec holds defaults for the spec of the Buckets of a
+// BucketClass. The fields have the same meaning as in BucketSpec. The
+// controller merges them under the spec of each Bucket: fields and map keys
+// set in the Bucket take precedence, lists are taken as a whole from the
+// Bucket if it sets them, except for the tag set, whose tags are merged by
+// key.
+type BucketClassSpec struct {
+	// +optional
+	Accelerate *AccelerateConfiguration `json:"accelerate,omitempty"`
+	// +optional
+	Encryption *ServerSideEncryptionConfiguration `json:"encryption,omitempty"`
+	// +optional
+	Lifecycle *BucketLifecycleConfiguration `json:"lifecycle,omitempty"`
+	// +optional
+	Logging *BucketLoggingStatus `json:"logging,omitempty"`
+	// +optional
+	ObjectOwnership *string `json:"objectOwnership,omitempty"`
+	// +optional
+	OwnershipControls *OwnershipControls `json:"ownershipControls,omitempty"`
+	// +optional
+	PolicyDocument *BucketPolicyDocument `json:"policyDocument,omitempty"`
+	// +optional
+	PublicAccessBlock *PublicAccessBlockConfiguration `json:"publicAccessBlock,omitempty"`
+	// +optional
+	RequestPayment *RequestPaymentConfiguration `json:"requestPayment,omitempty"`
+	// +optional
+	Tagging *Tagging `json:"tagging,omitempty"`
+	// +optional
+	Versioning *VersioningConfiguration `json:"versioning,omitempty"`
+}
+
+// AppliedBucketClass describes the BucketClass whose defaults were merged
+// into the spec of a Bucket.
+type AppliedBucketClass struct {
+	// The name of the BucketClass.
+	Name *string `json:"name"`
+	// The paths of the spec fields set from the BucketClass, e.g. encryption
+	// or tagging.tagSet[team] for a tag.
+	// +optional
+	Fields []*string `json:"fields,omitempty"`
+}
+
+// BucketClass is the Schema for the BucketClasses API. A BucketClass holds
+// bucket settings shared by many Buckets, which select it by name with
+// spec.bucketClassName. The BucketClass annotated with
+// s3.services.k8s.aws/is-default-class: "true" applies to the Buckets
+// created without selecting a class.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+type BucketClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BucketClassSpec `json:"spec,omitempty"`
+}
+
+// BucketClassList contains a list of BucketClass
+// +kubebuilder:object:root=true
+type BucketClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BucketClass `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BucketClass{}, &BucketClassList{})
+}
@@ -0,0 +1,96 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+// BucketPolicyDocument is a bucket policy expressed as structured fields
+// rather than as a JSON string. The controller renders it to JSON before
+// calling PutBucketPolicy.
+//
+// String values may contain the following placeholders, which the controller
+// resolves before applying the policy:
+//
+//   - ${bucket.name}: the name of the bucket
+//   - ${bucket.arn}: the ARN of the bucket
+//   - ${account.id}: the ID of the AWS account that owns the bucket
+//   - ${partition}: the AWS partition of the bucket, e.g. aws or aws-cn
+//
+// IAM policy variables such as ${aws:username} are left untouched.
+type BucketPolicyDocument struct {
+	// The identifier of the policy.
+	// +optional
+	ID *string `json:"id,omitempty"`
+	// The statements of the policy.
+	Statements []*BucketPolicyStatement `json:"statements"`
+	// The version of the policy language. Defaults to 2012-10-17.
+	// +optional
+	Version *string `json:"version,omitempty"`
+}
+
+// BucketPolicyStatement is a single statement of a BucketPolicyDocument.
+type BucketPolicyStatement struct {
+	// The actions the statement applies to, e.g. s3:GetObject.
+	// +optional
+	Actions []*string `json:"actions,omitempty"`
+	// The conditions under which the statement applies.
+	// +optional
+	Conditions []*BucketPolicyCondition `json:"conditions,omitempty"`
+	// Whether the statement allows or denies access. One of Allow or Deny.
+	Effect *string `json:"effect"`
+	// The actions the statement does not apply to.
+	// +optional
+	NotActions []*string `json:"notActions,omitempty"`
+	// The principals the statement does not apply to.
+	// +optional
+	NotPrincipal *BucketPolicyPrincipal `json:"notPrincipal,omitempty"`
+	// The resources the statement does not apply to.
+	// +optional
+	NotResources []*string `json:"notResources,omitempty"`
+	// The principals the statement applies to.
+	// +optional
+	Principal *BucketPolicyPrincipal `json:"principal,omitempty"`
+	// The resources the statement applies to, e.g. ${bucket.arn}/*.
+	// +optional
+	Resources []*string `json:"resources,omitempty"`
+	// An optional identifier for the statement.
+	// +optional
+	Sid *string `json:"sid,omitempty"`
+}
+
+// BucketPolicyPrincipal lists the principals of a BucketPolicyStatement by
+// type. Use an AWS principal of "*" to designate everyone.
+type BucketPolicyPrincipal struct {
+	// AWS accounts, IAM users and IAM roles.
+	// +optional
+	AWS []*string `json:"aws,omitempty"`
+	// Canonical user IDs.
+	// +optional
+	CanonicalUser []*string `json:"canonicalUser,omitempty"`
+	// Web identity and SAML identity providers.
+	// +optional
+	Federated []*string `json:"federated,omitempty"`
+	// AWS service principals, e.g. logging.s3.amazonaws.com.
+	// +optional
+	Service []*string `json:"service,omitempty"`
+}
+
+// BucketPolicyCondition is a single condition of a BucketPolicyStatement,
+// e.g. the operator StringEquals applied to the key aws:SourceVpce.
+type BucketPolicyCondition struct {
+	// The condition key, e.g. aws:SourceVpce.
+	Key *string `json:"key"`
+	// The condition operator, e.g. StringEquals.
+	Operator *string `json:"operator"`
+	// The values the key is compared to.
+	Values []*string `json:"values"`
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+// ConnectionDetailsTarget is the Secret or ConfigMap the connection details
+// of a Bucket are written to.
+type ConnectionDetailsTarget struct {
+	// The kind of the object, Secret or ConfigMap. Defaults to Secret.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +optional
+	Kind *string `json:"kind,omitempty"`
+	// The name of the object, in the namespace of the Bucket.
+	Name *string `json:"name"`
+}
@@ -0,0 +1,135 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Multi-Region Access Points are managed through the S3 Control API rather
+// than the S3 API the rest of this package is generated from, so the
+// MultiRegionAccessPoint types are maintained by hand.
+
+// MultiRegionAccessPointSpec defines the desired state of MultiRegionAccessPoint.
+type MultiRegionAccessPointSpec struct {
+
+	// The name of the Multi-Region Access Point.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
+	// +kubebuilder:validation:Required
+	Name *string `json:"name"`
+	// The Multi-Region Access Point policy, as a JSON string. It is compared
+	// with the policy returned by S3 by the permissions it grants rather than
+	// by its serialization.
+	//
+	// S3 cannot remove the policy of a Multi-Region Access Point, so unsetting
+	// it leaves the current policy in place.
+	Policy *string `json:"policy,omitempty"`
+	// The public access block configuration of the Multi-Region Access Point.
+	// S3 blocks all public access when it is not set.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
+	PublicAccessBlock *PublicAccessBlockConfiguration `json:"publicAccessBlock,omitempty"`
+	// The buckets the Multi-Region Access Point routes requests to, at most one
+	// per Region.
+	//
+	// The buckets cannot be changed once the Multi-Region Access Point is
+	// created, only their traffic dial percentages.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	Regions []*MultiRegionAccessPointRegion `json:"regions"`
+}
+
+// MultiRegionAccessPointRegion is a bucket a Multi-Region Access Point routes
+// requests to.
+type MultiRegionAccessPointRegion struct {
+	// The name of the bucket.
+	Bucket    *string                                  `json:"bucket,omitempty"`
+	BucketRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"bucketRef,omitempty"`
+	// The ID of the AWS account that owns the bucket, when it is not the account
+	// of the Multi-Region Access Point.
+	BucketAccountID *string `json:"bucketAccountID,omitempty"`
+	// The percentage of the requests to the Multi-Region Access Point routed
+	// to the bucket: 100 makes the bucket active and 0 passive. The routes of
+	// the buckets without a traffic dial percentage are not managed, which
+	// leaves them active unless changed outside of the controller.
+	//
+	// Failing over from one bucket to another is done by swapping their
+	// traffic dial percentages.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	TrafficDialPercentage *int64 `json:"trafficDialPercentage,omitempty"`
+}
+
+// MultiRegionAccessPointRoute is the routing configuration of a bucket of a
+// Multi-Region Access Point.
+type MultiRegionAccessPointRoute struct {
+	Bucket                *string `json:"bucket,omitempty"`
+	Region                *string `json:"region,omitempty"`
+	TrafficDialPercentage *int64  `json:"trafficDialPercentage,omitempty"`
+}
+
+// MultiRegionAccessPointStatus defines the observed state of MultiRegionAccessPoint
+type MultiRegionAccessPointStatus struct {
+	// All CRs managed by ACK have a common `Status.ACKResourceMetadata` member
+	// that is used to contain resource sync state, account ownership,
+	// constructed ARN for the resource
+	// +kubebuilder:validation:Optional
+	ACKResourceMetadata *ackv1alpha1.ResourceMetadata `json:"ackResourceMetadata"`
+	// All CRs managed by ACK have a common `Status.Conditions` member that
+	// contains a collection of `ackv1alpha1.Condition` objects that describe
+	// the various terminal states of the CR and its backend AWS service API
+	// resource
+	// +kubebuilder:validation:Optional
+	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// The alias of the Multi-Region Access Point, which can be used wherever a
+	// bucket name is expected.
+	// +kubebuilder:validation:Optional
+	Alias *string `json:"alias,omitempty"`
+	// When the Multi-Region Access Point was created.
+	// +kubebuilder:validation:Optional
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+	// The request token ARN of the asynchronous operation on the Multi-Region
+	// Access Point in progress, if any.
+	// +kubebuilder:validation:Optional
+	RequestTokenARN *string `json:"requestTokenARN,omitempty"`
+	// The routing configuration of the buckets of the Multi-Region Access
+	// Point.
+	// +kubebuilder:validation:Optional
+	Routes []*MultiRegionAccessPointRoute `json:"routes,omitempty"`
+	// The state of the Multi-Region Access Point, e.g. CREATING or READY.
+	// +kubebuilder:validation:Optional
+	State *string `json:"state,omitempty"`
+}
+
+// MultiRegionAccessPoint is the Schema for the MultiRegionAccessPoints API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+type MultiRegionAccessPoint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              MultiRegionAccessPointSpec   `json:"spec,omitempty"`
+	Status            MultiRegionAccessPointStatus `json:"status,omitempty"`
+}
+
+// MultiRegionAccessPointList contains a list of MultiRegionAccessPoint
+// +kubebuilder:object:root=true
+type MultiRegionAccessPointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MultiRegionAccessPoint `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MultiRegionAccessPoint{}, &MultiRegionAccessPointList{})
+}
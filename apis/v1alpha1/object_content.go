@@ -0,0 +1,45 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// ObjectContent is the content of an Object, given inline or read from a key
+// of a ConfigMap or Secret. At most one of its fields may be set.
+type ObjectContent struct {
+	// A key of a ConfigMap holding the content. Binary data keys are
+	// supported.
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeyReference `json:"configMapKeyRef,omitempty"`
+	// The content, as a string.
+	// +optional
+	Data *string `json:"data,omitempty"`
+	// A key of a Secret holding the content.
+	// +optional
+	SecretKeyRef *ackv1alpha1.SecretKeyReference `json:"secretKeyRef,omitempty"`
+}
+
+// ConfigMapKeyReference designates a key of a ConfigMap, in the namespace of
+// the referencing resource unless a namespace is given.
+type ConfigMapKeyReference struct {
+	// The name of the ConfigMap.
+	Name string `json:"name"`
+	// The namespace of the ConfigMap.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// The key of the ConfigMap.
+	Key string `json:"key"`
+}
@@ -0,0 +1,46 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SubresourceConditionStatus is the outcome of applying a single bucket
+// property.
+type SubresourceConditionStatus string
+
+const (
+	// SubresourceConditionStatusSynced means the property was applied.
+	SubresourceConditionStatusSynced SubresourceConditionStatus = "Synced"
+	// SubresourceConditionStatusError means applying the property failed,
+	// or was not attempted because a property it depends on failed.
+	SubresourceConditionStatusError SubresourceConditionStatus = "Error"
+)
+
+// SubresourceCondition describes whether the controller managed to apply one
+// property of a bucket, such as its policy or lifecycle configuration, the
+// last time that property was updated.
+type SubresourceCondition struct {
+	// Name of the bucket property, e.g. Policy or Lifecycle.
+	Name string `json:"name"`
+	// Status of the property, one of Synced or Error.
+	Status SubresourceConditionStatus `json:"status"`
+	// Last time the status of the property changed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// The error returned while applying the property, if any.
+	// +optional
+	Message *string `json:"message,omitempty"`
+}
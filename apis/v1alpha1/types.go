@@ -108,10 +108,12 @@ type AnalyticsFilter struct {
 
 // Contains information about where to publish the analytics results.
 type AnalyticsS3BucketDestination struct {
-	Bucket          *string `json:"bucket,omitempty"`
-	BucketAccountID *string `json:"bucketAccountID,omitempty"`
-	Format          *string `json:"format,omitempty"`
-	Prefix          *string `json:"prefix,omitempty"`
+	Bucket *string `json:"bucket,omitempty"`
+	// Reference field for Bucket
+	BucketRef       *ackv1alpha1.AWSResourceReferenceWrapper `json:"bucketRef,omitempty"`
+	BucketAccountID *string                                  `json:"bucketAccountID,omitempty"`
+	Format          *string                                  `json:"format,omitempty"`
+	Prefix          *string                                  `json:"prefix,omitempty"`
 }
 
 // Specifies the information about the bucket that will be created. For more
@@ -253,6 +255,8 @@ type Destination struct {
 	AccessControlTranslation *AccessControlTranslation `json:"accessControlTranslation,omitempty"`
 	Account                  *string                   `json:"account,omitempty"`
 	Bucket                   *string                   `json:"bucket,omitempty"`
+	// Reference field for Bucket
+	BucketRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"bucketRef,omitempty"`
 	// Specifies encryption-related information for an Amazon S3 bucket that is
 	// a destination for replicated objects.
 	//
@@ -273,6 +277,13 @@ type Destination struct {
 	StorageClass    *string          `json:"storageClass,omitempty"`
 }
 
+// The destination information for the S3 Metadata configuration.
+type DestinationResult struct {
+	TableBucketARN  *string `json:"tableBucketARN,omitempty"`
+	TableBucketType *string `json:"tableBucketType,omitempty"`
+	TableNamespace  *string `json:"tableNamespace,omitempty"`
+}
+
 // Contains the type of server-side encryption used.
 type Encryption struct {
 	EncryptionType *string `json:"encryptionType,omitempty"`
@@ -289,6 +300,8 @@ type Encryption struct {
 // bucket owner.
 type EncryptionConfiguration struct {
 	ReplicaKMSKeyID *string `json:"replicaKMSKeyID,omitempty"`
+	// Reference field for ReplicaKMSKeyID
+	ReplicaKMSKeyRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"replicaKMSKeyRef,omitempty"`
 }
 
 // Container for all error elements.
@@ -296,6 +309,14 @@ type Error struct {
 	Key *string `json:"key,omitempty"`
 }
 
+// If an S3 Metadata V1 CreateBucketMetadataTableConfiguration or V2 CreateBucketMetadataConfiguration
+// request succeeds, but S3 Metadata was unable to create the table, this structure
+// contains the error code and error message.
+type ErrorDetails struct {
+	ErrorCode    *string `json:"errorCode,omitempty"`
+	ErrorMessage *string `json:"errorMessage,omitempty"`
+}
+
 // The error information.
 type ErrorDocument struct {
 	Key *string `json:"key,omitempty"`
@@ -424,6 +445,8 @@ type InventoryFilter struct {
 type InventoryS3BucketDestination struct {
 	AccountID *string `json:"accountID,omitempty"`
 	Bucket    *string `json:"bucket,omitempty"`
+	// Reference field for Bucket
+	BucketRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"bucketRef,omitempty"`
 	// Contains the type of server-side encryption used to encrypt the S3 Inventory
 	// results.
 	Encryption *InventoryEncryption `json:"encryption,omitempty"`
@@ -436,6 +459,50 @@ type InventorySchedule struct {
 	Frequency *string `json:"frequency,omitempty"`
 }
 
+// The inventory table configuration for an S3 Metadata configuration.
+type InventoryTableConfiguration struct {
+	ConfigurationState *string `json:"configurationState,omitempty"`
+	// The encryption settings for an S3 Metadata journal table or inventory table
+	// configuration.
+	EncryptionConfiguration *MetadataTableEncryptionConfiguration `json:"encryptionConfiguration,omitempty"`
+}
+
+// The inventory table configuration for an S3 Metadata configuration.
+type InventoryTableConfigurationResult struct {
+	ConfigurationState *string `json:"configurationState,omitempty"`
+	// If an S3 Metadata V1 CreateBucketMetadataTableConfiguration or V2 CreateBucketMetadataConfiguration
+	// request succeeds, but S3 Metadata was unable to create the table, this structure
+	// contains the error code and error message.
+	Error       *ErrorDetails `json:"error,omitempty"`
+	TableARN    *string       `json:"tableARN,omitempty"`
+	TableName   *string       `json:"tableName,omitempty"`
+	TableStatus *string       `json:"tableStatus,omitempty"`
+}
+
+// The journal table configuration for an S3 Metadata configuration.
+type JournalTableConfiguration struct {
+	// The encryption settings for an S3 Metadata journal table or inventory table
+	// configuration.
+	EncryptionConfiguration *MetadataTableEncryptionConfiguration `json:"encryptionConfiguration,omitempty"`
+	// The journal table record expiration settings for a journal table in an S3
+	// Metadata configuration.
+	RecordExpiration *RecordExpiration `json:"recordExpiration,omitempty"`
+}
+
+// The journal table configuration for the S3 Metadata configuration.
+type JournalTableConfigurationResult struct {
+	// If an S3 Metadata V1 CreateBucketMetadataTableConfiguration or V2 CreateBucketMetadataConfiguration
+	// request succeeds, but S3 Metadata was unable to create the table, this structure
+	// contains the error code and error message.
+	Error *ErrorDetails `json:"error,omitempty"`
+	// The journal table record expiration settings for a journal table in an S3
+	// Metadata configuration.
+	RecordExpiration *RecordExpiration `json:"recordExpiration,omitempty"`
+	TableARN         *string           `json:"tableARN,omitempty"`
+	TableName        *string           `json:"tableName,omitempty"`
+	TableStatus      *string           `json:"tableStatus,omitempty"`
+}
+
 // A container for object key name prefix and suffix filtering rules.
 type KeyFilter struct {
 	// A list of containers for the key-value pair that defines the criteria for
@@ -455,6 +522,8 @@ type LambdaFunctionConfiguration struct {
 	// If you don't provide one, Amazon S3 will assign an ID.
 	ID                *string `json:"id,omitempty"`
 	LambdaFunctionARN *string `json:"lambdaFunctionARN,omitempty"`
+	// Reference field for LambdaFunctionARN
+	LambdaFunctionRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"lambdaFunctionRef,omitempty"`
 }
 
 // Container for the expiration for the lifecycle of the object.
@@ -557,9 +626,36 @@ type LocationInfo struct {
 // (https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTlogging.html)
 // in the Amazon S3 API Reference.
 type LoggingEnabled struct {
-	TargetBucket *string        `json:"targetBucket,omitempty"`
-	TargetGrants []*TargetGrant `json:"targetGrants,omitempty"`
-	TargetPrefix *string        `json:"targetPrefix,omitempty"`
+	TargetBucket *string `json:"targetBucket,omitempty"`
+	// Reference field for TargetBucket
+	TargetBucketRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"targetBucketRef,omitempty"`
+	TargetGrants    []*TargetGrant                           `json:"targetGrants,omitempty"`
+	TargetPrefix    *string                                  `json:"targetPrefix,omitempty"`
+}
+
+// The S3 Metadata configuration for a general purpose bucket.
+type MetadataConfiguration struct {
+	// The inventory table configuration for an S3 Metadata configuration.
+	InventoryTableConfiguration *InventoryTableConfiguration `json:"inventoryTableConfiguration,omitempty"`
+	// The journal table configuration for an S3 Metadata configuration.
+	JournalTableConfiguration *JournalTableConfiguration `json:"journalTableConfiguration,omitempty"`
+}
+
+// The S3 Metadata configuration for a general purpose bucket.
+type MetadataConfigurationResult struct {
+	// The destination information for the S3 Metadata configuration.
+	DestinationResult *DestinationResult `json:"destinationResult,omitempty"`
+	// The inventory table configuration for an S3 Metadata configuration.
+	InventoryTableConfigurationResult *InventoryTableConfigurationResult `json:"inventoryTableConfigurationResult,omitempty"`
+	// The journal table configuration for the S3 Metadata configuration.
+	JournalTableConfigurationResult *JournalTableConfigurationResult `json:"journalTableConfigurationResult,omitempty"`
+}
+
+// The encryption settings for an S3 Metadata journal table or inventory table
+// configuration.
+type MetadataTableEncryptionConfiguration struct {
+	KMSKeyARN    *string `json:"kmsKeyARN,omitempty"`
+	SSEAlgorithm *string `json:"sseAlgorithm,omitempty"`
 }
 
 // A container specifying replication metrics-related settings enabling replication
@@ -662,7 +758,7 @@ type NotificationConfigurationFilter struct {
 }
 
 // An object consists of data and its descriptive metadata.
-type Object struct {
+type Object_SDK struct {
 	Key *string `json:"key,omitempty"`
 	// Container for the owner's display name and ID.
 	Owner *Owner `json:"owner,omitempty"`
@@ -778,6 +874,15 @@ type QueueConfiguration struct {
 	// If you don't provide one, Amazon S3 will assign an ID.
 	ID       *string `json:"id,omitempty"`
 	QueueARN *string `json:"queueARN,omitempty"`
+	// Reference field for QueueARN
+	QueueRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"queueRef,omitempty"`
+}
+
+// The journal table record expiration settings for a journal table in an S3
+// Metadata configuration.
+type RecordExpiration struct {
+	Days       *int64  `json:"days,omitempty"`
+	Expiration *string `json:"expiration,omitempty"`
 }
 
 // Specifies how requests are redirected. In the event of an error, you can
@@ -977,7 +1082,9 @@ type SSEKMSEncryption struct {
 //     options for server-side encryption: SSE-S3 and SSE-KMS.
 type ServerSideEncryptionByDefault struct {
 	KMSMasterKeyID *string `json:"kmsMasterKeyID,omitempty"`
-	SSEAlgorithm   *string `json:"sseAlgorithm,omitempty"`
+	// Reference field for KMSMasterKeyID
+	KMSMasterKeyRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"kmsMasterKeyRef,omitempty"`
+	SSEAlgorithm    *string                                  `json:"sseAlgorithm,omitempty"`
 }
 
 // Specifies the default server-side-encryption configuration.
@@ -1103,6 +1210,8 @@ type TopicConfiguration struct {
 	// If you don't provide one, Amazon S3 will assign an ID.
 	ID       *string `json:"id,omitempty"`
 	TopicARN *string `json:"topicARN,omitempty"`
+	// Reference field for TopicARN
+	TopicRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"topicRef,omitempty"`
 }
 
 // Specifies when an object transitions to a specified storage class. For more
@@ -124,6 +124,214 @@ func (in *AccessControlTranslation) DeepCopy() *AccessControlTranslation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPoint) DeepCopyInto(out *AccessPoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPoint.
+func (in *AccessPoint) DeepCopy() *AccessPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessPoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPointList) DeepCopyInto(out *AccessPointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccessPoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPointList.
+func (in *AccessPointList) DeepCopy() *AccessPointList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessPointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPointScope) DeepCopyInto(out *AccessPointScope) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPointScope.
+func (in *AccessPointScope) DeepCopy() *AccessPointScope {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPointScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPointSpec) DeepCopyInto(out *AccessPointSpec) {
+	*out = *in
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(string)
+		**out = **in
+	}
+	if in.BucketRef != nil {
+		in, out := &in.BucketRef, &out.BucketRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BucketAccountID != nil {
+		in, out := &in.BucketAccountID, &out.BucketAccountID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicAccessBlockConfiguration != nil {
+		in, out := &in.PublicAccessBlockConfiguration, &out.PublicAccessBlockConfiguration
+		*out = new(PublicAccessBlockConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(AccessPointScope)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPCConfiguration != nil {
+		in, out := &in.VPCConfiguration, &out.VPCConfiguration
+		*out = new(VPCConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPointSpec.
+func (in *AccessPointSpec) DeepCopy() *AccessPointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPointStatus) DeepCopyInto(out *AccessPointStatus) {
+	*out = *in
+	if in.ACKResourceMetadata != nil {
+		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
+		*out = new(corev1alpha1.ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Alias != nil {
+		in, out := &in.Alias, &out.Alias
+		*out = new(string)
+		**out = **in
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make(map[string]*string, len(*in))
+		for key, val := range *in {
+			var outVal *string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(string)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.NetworkOrigin != nil {
+		in, out := &in.NetworkOrigin, &out.NetworkOrigin
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPointStatus.
+func (in *AccessPointStatus) DeepCopy() *AccessPointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AnalyticsAndOperator) DeepCopyInto(out *AnalyticsAndOperator) {
 	*out = *in
@@ -243,6 +451,11 @@ func (in *AnalyticsS3BucketDestination) DeepCopyInto(out *AnalyticsS3BucketDesti
 		*out = new(string)
 		**out = **in
 	}
+	if in.BucketRef != nil {
+		in, out := &in.BucketRef, &out.BucketRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BucketAccountID != nil {
 		in, out := &in.BucketAccountID, &out.BucketAccountID
 		*out = new(string)
@@ -253,50 +466,369 @@ func (in *AnalyticsS3BucketDestination) DeepCopyInto(out *AnalyticsS3BucketDesti
 		*out = new(string)
 		**out = **in
 	}
-	if in.Prefix != nil {
-		in, out := &in.Prefix, &out.Prefix
-		*out = new(string)
-		**out = **in
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalyticsS3BucketDestination.
+func (in *AnalyticsS3BucketDestination) DeepCopy() *AnalyticsS3BucketDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(AnalyticsS3BucketDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedBucketClass) DeepCopyInto(out *AppliedBucketClass) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedBucketClass.
+func (in *AppliedBucketClass) DeepCopy() *AppliedBucketClass {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedBucketClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bucket) DeepCopyInto(out *Bucket) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bucket.
+func (in *Bucket) DeepCopy() *Bucket {
+	if in == nil {
+		return nil
+	}
+	out := new(Bucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Bucket) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketAccess) DeepCopyInto(out *BucketAccess) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketAccess.
+func (in *BucketAccess) DeepCopy() *BucketAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BucketAccess) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketAccessList) DeepCopyInto(out *BucketAccessList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BucketAccess, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketAccessList.
+func (in *BucketAccessList) DeepCopy() *BucketAccessList {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketAccessList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BucketAccessList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketAccessSpec) DeepCopyInto(out *BucketAccessSpec) {
+	*out = *in
+	if in.BucketRef != nil {
+		in, out := &in.BucketRef, &out.BucketRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountName != nil {
+		in, out := &in.ServiceAccountName, &out.ServiceAccountName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessLevel != nil {
+		in, out := &in.AccessLevel, &out.AccessLevel
+		*out = new(string)
+		**out = **in
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(string)
+		**out = **in
+	}
+	if in.IdentityType != nil {
+		in, out := &in.IdentityType, &out.IdentityType
+		*out = new(string)
+		**out = **in
+	}
+	if in.OIDCProviderARN != nil {
+		in, out := &in.OIDCProviderARN, &out.OIDCProviderARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrincipalARN != nil {
+		in, out := &in.PrincipalARN, &out.PrincipalARN
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketAccessSpec.
+func (in *BucketAccessSpec) DeepCopy() *BucketAccessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketAccessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketAccessStatus) DeepCopyInto(out *BucketAccessStatus) {
+	*out = *in
+	if in.BucketName != nil {
+		in, out := &in.BucketName, &out.BucketName
+		*out = new(string)
+		**out = **in
+	}
+	if in.BucketRegion != nil {
+		in, out := &in.BucketRegion, &out.BucketRegion
+		*out = new(string)
+		**out = **in
+	}
+	if in.RoleARN != nil {
+		in, out := &in.RoleARN, &out.RoleARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrincipalARN != nil {
+		in, out := &in.PrincipalARN, &out.PrincipalARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketAccessStatus.
+func (in *BucketAccessStatus) DeepCopy() *BucketAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketClass) DeepCopyInto(out *BucketClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketClass.
+func (in *BucketClass) DeepCopy() *BucketClass {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BucketClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketClassList) DeepCopyInto(out *BucketClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BucketClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketClassList.
+func (in *BucketClassList) DeepCopy() *BucketClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BucketClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketClassSpec) DeepCopyInto(out *BucketClassSpec) {
+	*out = *in
+	if in.Accelerate != nil {
+		in, out := &in.Accelerate, &out.Accelerate
+		*out = new(AccelerateConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(ServerSideEncryptionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(BucketLifecycleConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(BucketLoggingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectOwnership != nil {
+		in, out := &in.ObjectOwnership, &out.ObjectOwnership
+		*out = new(string)
+		**out = **in
+	}
+	if in.OwnershipControls != nil {
+		in, out := &in.OwnershipControls, &out.OwnershipControls
+		*out = new(OwnershipControls)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PolicyDocument != nil {
+		in, out := &in.PolicyDocument, &out.PolicyDocument
+		*out = new(BucketPolicyDocument)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PublicAccessBlock != nil {
+		in, out := &in.PublicAccessBlock, &out.PublicAccessBlock
+		*out = new(PublicAccessBlockConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestPayment != nil {
+		in, out := &in.RequestPayment, &out.RequestPayment
+		*out = new(RequestPaymentConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tagging != nil {
+		in, out := &in.Tagging, &out.Tagging
+		*out = new(Tagging)
+		(*in).DeepCopyInto(*out)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalyticsS3BucketDestination.
-func (in *AnalyticsS3BucketDestination) DeepCopy() *AnalyticsS3BucketDestination {
-	if in == nil {
-		return nil
+	if in.Versioning != nil {
+		in, out := &in.Versioning, &out.Versioning
+		*out = new(VersioningConfiguration)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(AnalyticsS3BucketDestination)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Bucket) DeepCopyInto(out *Bucket) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bucket.
-func (in *Bucket) DeepCopy() *Bucket {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketClassSpec.
+func (in *BucketClassSpec) DeepCopy() *BucketClassSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Bucket)
+	out := new(BucketClassSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Bucket) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketInfo) DeepCopyInto(out *BucketInfo) {
 	*out = *in
@@ -400,6 +932,227 @@ func (in *BucketLoggingStatus) DeepCopy() *BucketLoggingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicyCondition) DeepCopyInto(out *BucketPolicyCondition) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+	if in.Operator != nil {
+		in, out := &in.Operator, &out.Operator
+		*out = new(string)
+		**out = **in
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyCondition.
+func (in *BucketPolicyCondition) DeepCopy() *BucketPolicyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicyDocument) DeepCopyInto(out *BucketPolicyDocument) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Statements != nil {
+		in, out := &in.Statements, &out.Statements
+		*out = make([]*BucketPolicyStatement, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(BucketPolicyStatement)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyDocument.
+func (in *BucketPolicyDocument) DeepCopy() *BucketPolicyDocument {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicyDocument)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicyPrincipal) DeepCopyInto(out *BucketPolicyPrincipal) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.CanonicalUser != nil {
+		in, out := &in.CanonicalUser, &out.CanonicalUser
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Federated != nil {
+		in, out := &in.Federated, &out.Federated
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyPrincipal.
+func (in *BucketPolicyPrincipal) DeepCopy() *BucketPolicyPrincipal {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicyPrincipal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicyStatement) DeepCopyInto(out *BucketPolicyStatement) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*BucketPolicyCondition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(BucketPolicyCondition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Effect != nil {
+		in, out := &in.Effect, &out.Effect
+		*out = new(string)
+		**out = **in
+	}
+	if in.NotActions != nil {
+		in, out := &in.NotActions, &out.NotActions
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.NotPrincipal != nil {
+		in, out := &in.NotPrincipal, &out.NotPrincipal
+		*out = new(BucketPolicyPrincipal)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NotResources != nil {
+		in, out := &in.NotResources, &out.NotResources
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Principal != nil {
+		in, out := &in.Principal, &out.Principal
+		*out = new(BucketPolicyPrincipal)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Sid != nil {
+		in, out := &in.Sid, &out.Sid
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyStatement.
+func (in *BucketPolicyStatement) DeepCopy() *BucketPolicyStatement {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicyStatement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketSpec) DeepCopyInto(out *BucketSpec) {
 	*out = *in
@@ -429,6 +1182,11 @@ func (in *BucketSpec) DeepCopyInto(out *BucketSpec) {
 			}
 		}
 	}
+	if in.BucketClassName != nil {
+		in, out := &in.BucketClassName, &out.BucketClassName
+		*out = new(string)
+		**out = **in
+	}
 	if in.CORS != nil {
 		in, out := &in.CORS, &out.CORS
 		*out = new(CORSConfiguration)
@@ -501,6 +1259,16 @@ func (in *BucketSpec) DeepCopyInto(out *BucketSpec) {
 		*out = new(BucketLoggingStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ManagedFields != nil {
+		in, out := &in.ManagedFields, &out.ManagedFields
+		*out = new(string)
+		**out = **in
+	}
+	if in.MetadataConfiguration != nil {
+		in, out := &in.MetadataConfiguration, &out.MetadataConfiguration
+		*out = new(MetadataConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Metrics != nil {
 		in, out := &in.Metrics, &out.Metrics
 		*out = make([]*MetricsConfiguration, len(*in))
@@ -552,6 +1320,11 @@ func (in *BucketSpec) DeepCopyInto(out *BucketSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PolicyDocument != nil {
+		in, out := &in.PolicyDocument, &out.PolicyDocument
+		*out = new(BucketPolicyDocument)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PublicAccessBlock != nil {
 		in, out := &in.PublicAccessBlock, &out.PublicAccessBlock
 		*out = new(PublicAccessBlockConfiguration)
@@ -582,6 +1355,11 @@ func (in *BucketSpec) DeepCopyInto(out *BucketSpec) {
 		*out = new(WebsiteConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WriteConnectionDetailsTo != nil {
+		in, out := &in.WriteConnectionDetailsTo, &out.WriteConnectionDetailsTo
+		*out = new(ConnectionDetailsTarget)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketSpec.
@@ -602,22 +1380,69 @@ func (in *BucketStatus) DeepCopyInto(out *BucketStatus) {
 		*out = new(corev1alpha1.ResourceMetadata)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]*corev1alpha1.Condition, len(*in))
+	if in.BucketClass != nil {
+		in, out := &in.BucketClass, &out.BucketClass
+		*out = new(AppliedBucketClass)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BucketRegion != nil {
+		in, out := &in.BucketRegion, &out.BucketRegion
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.DestinationGrants != nil {
+		in, out := &in.DestinationGrants, &out.DestinationGrants
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.ForceDeleteObjectsDeleted != nil {
+		in, out := &in.ForceDeleteObjectsDeleted, &out.ForceDeleteObjectsDeleted
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ForceDeleteUploadsAborted != nil {
+		in, out := &in.ForceDeleteUploadsAborted, &out.ForceDeleteUploadsAborted
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Location != nil {
+		in, out := &in.Location, &out.Location
+		*out = new(string)
+		**out = **in
+	}
+	if in.MetadataConfigurationResult != nil {
+		in, out := &in.MetadataConfigurationResult, &out.MetadataConfigurationResult
+		*out = new(MetadataConfigurationResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SubresourceConditions != nil {
+		in, out := &in.SubresourceConditions, &out.SubresourceConditions
+		*out = make([]*SubresourceCondition, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
-				*out = new(corev1alpha1.Condition)
+				*out = new(SubresourceCondition)
 				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
-	if in.Location != nil {
-		in, out := &in.Location, &out.Location
-		*out = new(string)
-		**out = **in
-	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketStatus.
@@ -804,6 +1629,46 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeyReference) DeepCopyInto(out *ConfigMapKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeyReference.
+func (in *ConfigMapKeyReference) DeepCopy() *ConfigMapKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionDetailsTarget) DeepCopyInto(out *ConnectionDetailsTarget) {
+	*out = *in
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionDetailsTarget.
+func (in *ConnectionDetailsTarget) DeepCopy() *ConnectionDetailsTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionDetailsTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CreateBucketConfiguration) DeepCopyInto(out *CreateBucketConfiguration) {
 	*out = *in
@@ -947,6 +1812,11 @@ func (in *Destination) DeepCopyInto(out *Destination) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.BucketRef != nil {
+		in, out := &in.BucketRef, &out.BucketRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EncryptionConfiguration != nil {
 		in, out := &in.EncryptionConfiguration, &out.EncryptionConfiguration
 		*out = new(EncryptionConfiguration)
@@ -979,6 +1849,36 @@ func (in *Destination) DeepCopy() *Destination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DestinationResult) DeepCopyInto(out *DestinationResult) {
+	*out = *in
+	if in.TableBucketARN != nil {
+		in, out := &in.TableBucketARN, &out.TableBucketARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.TableBucketType != nil {
+		in, out := &in.TableBucketType, &out.TableBucketType
+		*out = new(string)
+		**out = **in
+	}
+	if in.TableNamespace != nil {
+		in, out := &in.TableNamespace, &out.TableNamespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DestinationResult.
+func (in *DestinationResult) DeepCopy() *DestinationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(DestinationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Encryption) DeepCopyInto(out *Encryption) {
 	*out = *in
@@ -1012,6 +1912,11 @@ func (in *EncryptionConfiguration) DeepCopyInto(out *EncryptionConfiguration) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ReplicaKMSKeyRef != nil {
+		in, out := &in.ReplicaKMSKeyRef, &out.ReplicaKMSKeyRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionConfiguration.
@@ -1044,6 +1949,31 @@ func (in *Error) DeepCopy() *Error {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorDetails) DeepCopyInto(out *ErrorDetails) {
+	*out = *in
+	if in.ErrorCode != nil {
+		in, out := &in.ErrorCode, &out.ErrorCode
+		*out = new(string)
+		**out = **in
+	}
+	if in.ErrorMessage != nil {
+		in, out := &in.ErrorMessage, &out.ErrorMessage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorDetails.
+func (in *ErrorDetails) DeepCopy() *ErrorDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ErrorDocument) DeepCopyInto(out *ErrorDocument) {
 	*out = *in
@@ -1445,6 +2375,11 @@ func (in *InventoryS3BucketDestination) DeepCopyInto(out *InventoryS3BucketDesti
 		*out = new(string)
 		**out = **in
 	}
+	if in.BucketRef != nil {
+		in, out := &in.BucketRef, &out.BucketRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Encryption != nil {
 		in, out := &in.Encryption, &out.Encryption
 		*out = new(InventoryEncryption)
@@ -1492,6 +2427,136 @@ func (in *InventorySchedule) DeepCopy() *InventorySchedule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryTableConfiguration) DeepCopyInto(out *InventoryTableConfiguration) {
+	*out = *in
+	if in.ConfigurationState != nil {
+		in, out := &in.ConfigurationState, &out.ConfigurationState
+		*out = new(string)
+		**out = **in
+	}
+	if in.EncryptionConfiguration != nil {
+		in, out := &in.EncryptionConfiguration, &out.EncryptionConfiguration
+		*out = new(MetadataTableEncryptionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryTableConfiguration.
+func (in *InventoryTableConfiguration) DeepCopy() *InventoryTableConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryTableConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryTableConfigurationResult) DeepCopyInto(out *InventoryTableConfigurationResult) {
+	*out = *in
+	if in.ConfigurationState != nil {
+		in, out := &in.ConfigurationState, &out.ConfigurationState
+		*out = new(string)
+		**out = **in
+	}
+	if in.Error != nil {
+		in, out := &in.Error, &out.Error
+		*out = new(ErrorDetails)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TableARN != nil {
+		in, out := &in.TableARN, &out.TableARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.TableName != nil {
+		in, out := &in.TableName, &out.TableName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TableStatus != nil {
+		in, out := &in.TableStatus, &out.TableStatus
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryTableConfigurationResult.
+func (in *InventoryTableConfigurationResult) DeepCopy() *InventoryTableConfigurationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryTableConfigurationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JournalTableConfiguration) DeepCopyInto(out *JournalTableConfiguration) {
+	*out = *in
+	if in.EncryptionConfiguration != nil {
+		in, out := &in.EncryptionConfiguration, &out.EncryptionConfiguration
+		*out = new(MetadataTableEncryptionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecordExpiration != nil {
+		in, out := &in.RecordExpiration, &out.RecordExpiration
+		*out = new(RecordExpiration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JournalTableConfiguration.
+func (in *JournalTableConfiguration) DeepCopy() *JournalTableConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(JournalTableConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JournalTableConfigurationResult) DeepCopyInto(out *JournalTableConfigurationResult) {
+	*out = *in
+	if in.Error != nil {
+		in, out := &in.Error, &out.Error
+		*out = new(ErrorDetails)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecordExpiration != nil {
+		in, out := &in.RecordExpiration, &out.RecordExpiration
+		*out = new(RecordExpiration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TableARN != nil {
+		in, out := &in.TableARN, &out.TableARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.TableName != nil {
+		in, out := &in.TableName, &out.TableName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TableStatus != nil {
+		in, out := &in.TableStatus, &out.TableStatus
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JournalTableConfigurationResult.
+func (in *JournalTableConfigurationResult) DeepCopy() *JournalTableConfigurationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(JournalTableConfigurationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeyFilter) DeepCopyInto(out *KeyFilter) {
 	*out = *in
@@ -1547,6 +2612,11 @@ func (in *LambdaFunctionConfiguration) DeepCopyInto(out *LambdaFunctionConfigura
 		*out = new(string)
 		**out = **in
 	}
+	if in.LambdaFunctionRef != nil {
+		in, out := &in.LambdaFunctionRef, &out.LambdaFunctionRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LambdaFunctionConfiguration.
@@ -1761,73 +2831,158 @@ func (in *Location) DeepCopyInto(out *Location) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Location.
-func (in *Location) DeepCopy() *Location {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Location.
+func (in *Location) DeepCopy() *Location {
+	if in == nil {
+		return nil
+	}
+	out := new(Location)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationInfo) DeepCopyInto(out *LocationInfo) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationInfo.
+func (in *LocationInfo) DeepCopy() *LocationInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggingEnabled) DeepCopyInto(out *LoggingEnabled) {
+	*out = *in
+	if in.TargetBucket != nil {
+		in, out := &in.TargetBucket, &out.TargetBucket
+		*out = new(string)
+		**out = **in
+	}
+	if in.TargetBucketRef != nil {
+		in, out := &in.TargetBucketRef, &out.TargetBucketRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetGrants != nil {
+		in, out := &in.TargetGrants, &out.TargetGrants
+		*out = make([]*TargetGrant, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(TargetGrant)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.TargetPrefix != nil {
+		in, out := &in.TargetPrefix, &out.TargetPrefix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingEnabled.
+func (in *LoggingEnabled) DeepCopy() *LoggingEnabled {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggingEnabled)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataConfiguration) DeepCopyInto(out *MetadataConfiguration) {
+	*out = *in
+	if in.InventoryTableConfiguration != nil {
+		in, out := &in.InventoryTableConfiguration, &out.InventoryTableConfiguration
+		*out = new(InventoryTableConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JournalTableConfiguration != nil {
+		in, out := &in.JournalTableConfiguration, &out.JournalTableConfiguration
+		*out = new(JournalTableConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataConfiguration.
+func (in *MetadataConfiguration) DeepCopy() *MetadataConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(Location)
+	out := new(MetadataConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocationInfo) DeepCopyInto(out *LocationInfo) {
+func (in *MetadataConfigurationResult) DeepCopyInto(out *MetadataConfigurationResult) {
 	*out = *in
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(string)
-		**out = **in
+	if in.DestinationResult != nil {
+		in, out := &in.DestinationResult, &out.DestinationResult
+		*out = new(DestinationResult)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Type != nil {
-		in, out := &in.Type, &out.Type
-		*out = new(string)
-		**out = **in
+	if in.InventoryTableConfigurationResult != nil {
+		in, out := &in.InventoryTableConfigurationResult, &out.InventoryTableConfigurationResult
+		*out = new(InventoryTableConfigurationResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JournalTableConfigurationResult != nil {
+		in, out := &in.JournalTableConfigurationResult, &out.JournalTableConfigurationResult
+		*out = new(JournalTableConfigurationResult)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationInfo.
-func (in *LocationInfo) DeepCopy() *LocationInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataConfigurationResult.
+func (in *MetadataConfigurationResult) DeepCopy() *MetadataConfigurationResult {
 	if in == nil {
 		return nil
 	}
-	out := new(LocationInfo)
+	out := new(MetadataConfigurationResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoggingEnabled) DeepCopyInto(out *LoggingEnabled) {
+func (in *MetadataTableEncryptionConfiguration) DeepCopyInto(out *MetadataTableEncryptionConfiguration) {
 	*out = *in
-	if in.TargetBucket != nil {
-		in, out := &in.TargetBucket, &out.TargetBucket
+	if in.KMSKeyARN != nil {
+		in, out := &in.KMSKeyARN, &out.KMSKeyARN
 		*out = new(string)
 		**out = **in
 	}
-	if in.TargetGrants != nil {
-		in, out := &in.TargetGrants, &out.TargetGrants
-		*out = make([]*TargetGrant, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(TargetGrant)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.TargetPrefix != nil {
-		in, out := &in.TargetPrefix, &out.TargetPrefix
+	if in.SSEAlgorithm != nil {
+		in, out := &in.SSEAlgorithm, &out.SSEAlgorithm
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingEnabled.
-func (in *LoggingEnabled) DeepCopy() *LoggingEnabled {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataTableEncryptionConfiguration.
+func (in *MetadataTableEncryptionConfiguration) DeepCopy() *MetadataTableEncryptionConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(LoggingEnabled)
+	out := new(MetadataTableEncryptionConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1953,6 +3108,232 @@ func (in *MetricsFilter) DeepCopy() *MetricsFilter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRegionAccessPoint) DeepCopyInto(out *MultiRegionAccessPoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRegionAccessPoint.
+func (in *MultiRegionAccessPoint) DeepCopy() *MultiRegionAccessPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRegionAccessPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiRegionAccessPoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRegionAccessPointList) DeepCopyInto(out *MultiRegionAccessPointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MultiRegionAccessPoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRegionAccessPointList.
+func (in *MultiRegionAccessPointList) DeepCopy() *MultiRegionAccessPointList {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRegionAccessPointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiRegionAccessPointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRegionAccessPointRegion) DeepCopyInto(out *MultiRegionAccessPointRegion) {
+	*out = *in
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(string)
+		**out = **in
+	}
+	if in.BucketRef != nil {
+		in, out := &in.BucketRef, &out.BucketRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BucketAccountID != nil {
+		in, out := &in.BucketAccountID, &out.BucketAccountID
+		*out = new(string)
+		**out = **in
+	}
+	if in.TrafficDialPercentage != nil {
+		in, out := &in.TrafficDialPercentage, &out.TrafficDialPercentage
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRegionAccessPointRegion.
+func (in *MultiRegionAccessPointRegion) DeepCopy() *MultiRegionAccessPointRegion {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRegionAccessPointRegion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRegionAccessPointRoute) DeepCopyInto(out *MultiRegionAccessPointRoute) {
+	*out = *in
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(string)
+		**out = **in
+	}
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.TrafficDialPercentage != nil {
+		in, out := &in.TrafficDialPercentage, &out.TrafficDialPercentage
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRegionAccessPointRoute.
+func (in *MultiRegionAccessPointRoute) DeepCopy() *MultiRegionAccessPointRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRegionAccessPointRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRegionAccessPointSpec) DeepCopyInto(out *MultiRegionAccessPointSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicAccessBlock != nil {
+		in, out := &in.PublicAccessBlock, &out.PublicAccessBlock
+		*out = new(PublicAccessBlockConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]*MultiRegionAccessPointRegion, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MultiRegionAccessPointRegion)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRegionAccessPointSpec.
+func (in *MultiRegionAccessPointSpec) DeepCopy() *MultiRegionAccessPointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRegionAccessPointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRegionAccessPointStatus) DeepCopyInto(out *MultiRegionAccessPointStatus) {
+	*out = *in
+	if in.ACKResourceMetadata != nil {
+		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
+		*out = new(corev1alpha1.ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Alias != nil {
+		in, out := &in.Alias, &out.Alias
+		*out = new(string)
+		**out = **in
+	}
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RequestTokenARN != nil {
+		in, out := &in.RequestTokenARN, &out.RequestTokenARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]*MultiRegionAccessPointRoute, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MultiRegionAccessPointRoute)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRegionAccessPointStatus.
+func (in *MultiRegionAccessPointStatus) DeepCopy() *MultiRegionAccessPointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRegionAccessPointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultipartUpload) DeepCopyInto(out *MultipartUpload) {
 	*out = *in
@@ -2081,76 +3462,140 @@ func (in *NotificationConfiguration) DeepCopy() *NotificationConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(NotificationConfiguration)
+	out := new(NotificationConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationConfigurationFilter) DeepCopyInto(out *NotificationConfigurationFilter) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(KeyFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationConfigurationFilter.
+func (in *NotificationConfigurationFilter) DeepCopy() *NotificationConfigurationFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationConfigurationFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Object) DeepCopyInto(out *Object) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Object.
+func (in *Object) DeepCopy() *Object {
+	if in == nil {
+		return nil
+	}
+	out := new(Object)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Object) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotificationConfigurationFilter) DeepCopyInto(out *NotificationConfigurationFilter) {
+func (in *ObjectContent) DeepCopyInto(out *ObjectContent) {
 	*out = *in
-	if in.Key != nil {
-		in, out := &in.Key, &out.Key
-		*out = new(KeyFilter)
-		(*in).DeepCopyInto(*out)
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeyReference)
+		**out = **in
+	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1alpha1.SecretKeyReference)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationConfigurationFilter.
-func (in *NotificationConfigurationFilter) DeepCopy() *NotificationConfigurationFilter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectContent.
+func (in *ObjectContent) DeepCopy() *ObjectContent {
 	if in == nil {
 		return nil
 	}
-	out := new(NotificationConfigurationFilter)
+	out := new(ObjectContent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Object) DeepCopyInto(out *Object) {
+func (in *ObjectIdentifier) DeepCopyInto(out *ObjectIdentifier) {
 	*out = *in
 	if in.Key != nil {
 		in, out := &in.Key, &out.Key
 		*out = new(string)
 		**out = **in
 	}
-	if in.Owner != nil {
-		in, out := &in.Owner, &out.Owner
-		*out = new(Owner)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Object.
-func (in *Object) DeepCopy() *Object {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectIdentifier.
+func (in *ObjectIdentifier) DeepCopy() *ObjectIdentifier {
 	if in == nil {
 		return nil
 	}
-	out := new(Object)
+	out := new(ObjectIdentifier)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectIdentifier) DeepCopyInto(out *ObjectIdentifier) {
+func (in *ObjectList) DeepCopyInto(out *ObjectList) {
 	*out = *in
-	if in.Key != nil {
-		in, out := &in.Key, &out.Key
-		*out = new(string)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Object, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectIdentifier.
-func (in *ObjectIdentifier) DeepCopy() *ObjectIdentifier {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectList.
+func (in *ObjectList) DeepCopy() *ObjectList {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectIdentifier)
+	out := new(ObjectList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ObjectList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectLockConfiguration) DeepCopyInto(out *ObjectLockConfiguration) {
 	*out = *in
@@ -2215,6 +3660,173 @@ func (in *ObjectLockRule) DeepCopy() *ObjectLockRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectSpec) DeepCopyInto(out *ObjectSpec) {
+	*out = *in
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(string)
+		**out = **in
+	}
+	if in.BucketRef != nil {
+		in, out := &in.BucketRef, &out.BucketRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BucketKeyEnabled != nil {
+		in, out := &in.BucketKeyEnabled, &out.BucketKeyEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CacheControl != nil {
+		in, out := &in.CacheControl, &out.CacheControl
+		*out = new(string)
+		**out = **in
+	}
+	if in.Content != nil {
+		in, out := &in.Content, &out.Content
+		*out = new(ObjectContent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContentDisposition != nil {
+		in, out := &in.ContentDisposition, &out.ContentDisposition
+		*out = new(string)
+		**out = **in
+	}
+	if in.ContentEncoding != nil {
+		in, out := &in.ContentEncoding, &out.ContentEncoding
+		*out = new(string)
+		**out = **in
+	}
+	if in.ContentLanguage != nil {
+		in, out := &in.ContentLanguage, &out.ContentLanguage
+		*out = new(string)
+		**out = **in
+	}
+	if in.ContentType != nil {
+		in, out := &in.ContentType, &out.ContentType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]*string, len(*in))
+		for key, val := range *in {
+			var outVal *string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(string)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ObjectLockLegalHoldStatus != nil {
+		in, out := &in.ObjectLockLegalHoldStatus, &out.ObjectLockLegalHoldStatus
+		*out = new(string)
+		**out = **in
+	}
+	if in.ObjectLockMode != nil {
+		in, out := &in.ObjectLockMode, &out.ObjectLockMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.ObjectLockRetainUntilDate != nil {
+		in, out := &in.ObjectLockRetainUntilDate, &out.ObjectLockRetainUntilDate
+		*out = (*in).DeepCopy()
+	}
+	if in.SSEKMSKeyID != nil {
+		in, out := &in.SSEKMSKeyID, &out.SSEKMSKeyID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServerSideEncryption != nil {
+		in, out := &in.ServerSideEncryption, &out.ServerSideEncryption
+		*out = new(string)
+		**out = **in
+	}
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]*Tag, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Tag)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSpec.
+func (in *ObjectSpec) DeepCopy() *ObjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStatus) DeepCopyInto(out *ObjectStatus) {
+	*out = *in
+	if in.ACKResourceMetadata != nil {
+		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
+		*out = new(corev1alpha1.ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ChecksumSHA256 != nil {
+		in, out := &in.ChecksumSHA256, &out.ChecksumSHA256
+		*out = new(string)
+		**out = **in
+	}
+	if in.ETag != nil {
+		in, out := &in.ETag, &out.ETag
+		*out = new(string)
+		**out = **in
+	}
+	if in.VersionID != nil {
+		in, out := &in.VersionID, &out.VersionID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStatus.
+func (in *ObjectStatus) DeepCopy() *ObjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectVersion) DeepCopyInto(out *ObjectVersion) {
 	*out = *in
@@ -2240,6 +3852,31 @@ func (in *ObjectVersion) DeepCopy() *ObjectVersion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Object_SDK) DeepCopyInto(out *Object_SDK) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+	if in.Owner != nil {
+		in, out := &in.Owner, &out.Owner
+		*out = new(Owner)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Object_SDK.
+func (in *Object_SDK) DeepCopy() *Object_SDK {
+	if in == nil {
+		return nil
+	}
+	out := new(Object_SDK)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OutputLocation) DeepCopyInto(out *OutputLocation) {
 	*out = *in
@@ -2395,6 +4032,11 @@ func (in *QueueConfiguration) DeepCopyInto(out *QueueConfiguration) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.QueueRef != nil {
+		in, out := &in.QueueRef, &out.QueueRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueueConfiguration.
@@ -2407,6 +4049,31 @@ func (in *QueueConfiguration) DeepCopy() *QueueConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordExpiration) DeepCopyInto(out *RecordExpiration) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordExpiration.
+func (in *RecordExpiration) DeepCopy() *RecordExpiration {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordExpiration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Redirect) DeepCopyInto(out *Redirect) {
 	*out = *in
@@ -2827,6 +4494,11 @@ func (in *ServerSideEncryptionByDefault) DeepCopyInto(out *ServerSideEncryptionB
 		*out = new(string)
 		**out = **in
 	}
+	if in.KMSMasterKeyRef != nil {
+		in, out := &in.KMSMasterKeyRef, &out.KMSMasterKeyRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SSEAlgorithm != nil {
 		in, out := &in.SSEAlgorithm, &out.SSEAlgorithm
 		*out = new(string)
@@ -2965,6 +4637,30 @@ func (in *StorageClassAnalysisDataExport) DeepCopy() *StorageClassAnalysisDataEx
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubresourceCondition) DeepCopyInto(out *SubresourceCondition) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubresourceCondition.
+func (in *SubresourceCondition) DeepCopy() *SubresourceCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(SubresourceCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tag) DeepCopyInto(out *Tag) {
 	*out = *in
@@ -3095,6 +4791,11 @@ func (in *TopicConfiguration) DeepCopyInto(out *TopicConfiguration) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TopicRef != nil {
+		in, out := &in.TopicRef, &out.TopicRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicConfiguration.
@@ -3136,6 +4837,26 @@ func (in *Transition) DeepCopy() *Transition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCConfiguration) DeepCopyInto(out *VPCConfiguration) {
+	*out = *in
+	if in.VPCID != nil {
+		in, out := &in.VPCID, &out.VPCID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCConfiguration.
+func (in *VPCConfiguration) DeepCopy() *VPCConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VersioningConfiguration) DeepCopyInto(out *VersioningConfiguration) {
 	*out = *in
@@ -0,0 +1,39 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	flag "github.com/spf13/pflag"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	bucketresource "github.com/aws-controllers-k8s/s3-controller/pkg/resource/bucket"
+)
+
+// The flags configuring how Buckets are managed are registered here, before
+// main.go parses the command line, where regenerating the controller does not
+// drop them.
+func init() {
+	flag.StringVar(
+		&bucketresource.MandatoryPolicyStatementsFile,
+		"bucket-policy-mandatory-statements-file", "",
+		"Path of a JSON file holding an array of IAM policy statements, "+
+			"identified by their Sid, that are merged into the policy of every bucket.",
+	)
+	flag.BoolVar(
+		&bucketresource.DefaultDeletionProtection,
+		"bucket-deletion-protection", false,
+		"Protect buckets from deletion unless their "+
+			svcapitypes.AnnotationDeletionProtection+" annotation is set to \"false\".",
+	)
+}
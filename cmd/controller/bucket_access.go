@@ -0,0 +1,53 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	svctypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/bucketaccess"
+)
+
+// setupBucketAccessController registers the controller of BucketAccess
+// resources with the manager. BucketAccesses stand for no AWS resource, so
+// they have no resource manager and are not reconciled by the service
+// controller, whose AWS configuration they share nonetheless.
+func setupBucketAccessController(
+	mgr ctrlrt.Manager,
+	sc acktypes.ServiceController,
+	cfg ackcfg.Config,
+) error {
+	gvk := svctypes.GroupVersion.WithKind("BucketAccess")
+	newPolicyAPI := func(ctx context.Context, region string) (bucketaccess.PolicyAPI, error) {
+		clientcfg, err := sc.NewAWSConfig(
+			ctx, ackv1alpha1.AWSRegion(region), &cfg.EndpointURL, "", gvk, nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return svcsdk.NewFromConfig(clientcfg, func(o *svcsdk.Options) {
+			o.UsePathStyle = cfg.UsePathStyle
+		}), nil
+	}
+	return bucketaccess.NewReconciler(
+		mgr.GetClient(), mgr.GetAPIReader(), cfg, newPolicyAPI,
+	).SetupWithManager(mgr)
+}
@@ -0,0 +1,46 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	flag "github.com/spf13/pflag"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	"github.com/aws-controllers-k8s/s3-controller/pkg/connectiondetails"
+)
+
+// enableConnectionDetails is true if the connection details of Buckets are
+// written to the objects they name. Writing them requires permission to
+// create, update and delete Secrets and ConfigMaps in every watched
+// namespace, so it is only granted to controllers enabling it.
+var enableConnectionDetails bool
+
+func init() {
+	flag.BoolVar(
+		&enableConnectionDetails,
+		"enable-bucket-connection-details", false,
+		"Write the connection details of Buckets to the Secret or ConfigMap "+
+			"named by their spec.writeConnectionDetailsTo.",
+	)
+}
+
+// setupConnectionDetailsController registers the controller writing the
+// connection details of Buckets with the manager. It runs alongside the
+// reconciler of Buckets of the service controller, which has no client to
+// write Kubernetes objects with.
+func setupConnectionDetailsController(mgr ctrlrt.Manager) error {
+	return connectiondetails.NewReconciler(
+		mgr.GetClient(), mgr.GetAPIReader(), mgr.GetEventRecorder("ack-"+awsServiceAlias+"-controller"),
+	).SetupWithManager(mgr)
+}
@@ -0,0 +1,43 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+)
+
+// setupCustomControllers registers with the manager the controllers running
+// alongside the service controller, for the resources it does not reconcile.
+// main.go, generated from templates/cmd/controller/main.go.tpl, calls it once
+// the service controller is bound to the manager, so the controllers are
+// registered here, where regenerating the controller does not drop them.
+func setupCustomControllers(
+	mgr ctrlrt.Manager,
+	sc acktypes.ServiceController,
+	cfg ackcfg.Config,
+) error {
+	if err := setupBucketAccessController(mgr, sc, cfg); err != nil {
+		return fmt.Errorf("unable to set up the BucketAccess controller: %w", err)
+	}
+	if enableConnectionDetails {
+		if err := setupConnectionDetailsController(mgr); err != nil {
+			return fmt.Errorf("unable to set up the connection details controller: %w", err)
+		}
+	}
+	return nil
+}
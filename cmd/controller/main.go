@@ -22,12 +22,16 @@ import (
 	"runtime/debug"
 
 	iamapitypes "github.com/aws-controllers-k8s/iam-controller/apis/v1alpha1"
+	kmsapitypes "github.com/aws-controllers-k8s/kms-controller/apis/v1alpha1"
+	lambdaapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
 	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
 	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
 	ackrtutil "github.com/aws-controllers-k8s/runtime/pkg/util"
 	ackrtwebhook "github.com/aws-controllers-k8s/runtime/pkg/webhook"
+	snsapitypes "github.com/aws-controllers-k8s/sns-controller/apis/v1alpha1"
+	sqsapitypes "github.com/aws-controllers-k8s/sqs-controller/apis/v1alpha1"
 	flag "github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -43,6 +47,7 @@ import (
 	svcresource "github.com/aws-controllers-k8s/s3-controller/pkg/resource"
 
 	_ "github.com/aws-controllers-k8s/s3-controller/pkg/resource/bucket"
+	_ "github.com/aws-controllers-k8s/s3-controller/pkg/resource/object"
 
 	"github.com/aws-controllers-k8s/s3-controller/pkg/version"
 )
@@ -75,6 +80,10 @@ func init() {
 	_ = svctypes.AddToScheme(scheme)
 	_ = ackv1alpha1.AddToScheme(scheme)
 	_ = iamapitypes.AddToScheme(scheme)
+	_ = kmsapitypes.AddToScheme(scheme)
+	_ = lambdaapitypes.AddToScheme(scheme)
+	_ = snsapitypes.AddToScheme(scheme)
+	_ = sqsapitypes.AddToScheme(scheme)
 }
 
 func main() {
@@ -208,6 +217,13 @@ func main() {
 		)
 		os.Exit(1)
 	}
+	if err = setupCustomControllers(mgr, sc, ackCfg); err != nil {
+		setupLog.Error(
+			err, "unable to set up the custom controllers",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
 
 	if err = mgr.AddHealthzCheck("health", ctrlrthealthz.Ping); err != nil {
 		setupLog.Error(
@@ -0,0 +1,22 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+// Resources managed through the S3 Control API are not generated from the S3
+// API model like the resources imported by main.go, so they are registered
+// here, where regenerating the controller does not drop them.
+import (
+	_ "github.com/aws-controllers-k8s/s3-controller/pkg/resource/access_point"
+	_ "github.com/aws-controllers-k8s/s3-controller/pkg/resource/multi_region_access_point"
+)
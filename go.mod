@@ -4,7 +4,11 @@ go 1.25.0
 
 require (
 	github.com/aws-controllers-k8s/iam-controller v1.7.2
+	github.com/aws-controllers-k8s/kms-controller v1.0.0
+	github.com/aws-controllers-k8s/lambda-controller v1.0.0
 	github.com/aws-controllers-k8s/runtime v0.62.0
+	github.com/aws-controllers-k8s/sns-controller v1.0.0
+	github.com/aws-controllers-k8s/sqs-controller v1.0.0
 	github.com/aws/aws-sdk-go v1.49.0
 	github.com/aws/aws-sdk-go-v2 v1.41.5
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.97.3
@@ -14,6 +18,7 @@ require (
 	github.com/pkg/errors v0.9.1
 	github.com/spf13/pflag v1.0.9
 	github.com/stretchr/testify v1.11.1
+	golang.org/x/sync v0.21.0
 	k8s.io/api v0.35.0
 	k8s.io/apimachinery v0.35.0
 	k8s.io/client-go v0.35.0
@@ -74,7 +79,6 @@ require (
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/net v0.56.0 // indirect
 	golang.org/x/oauth2 v0.30.0 // indirect
-	golang.org/x/sync v0.21.0 // indirect
 	golang.org/x/sys v0.46.0 // indirect
 	golang.org/x/term v0.44.0 // indirect
 	golang.org/x/text v0.39.0 // indirect
@@ -48,6 +48,27 @@ If release name contains chart name it will be used as a full name.
 {{- printf "%s/%s" $secret_mount_path .Values.aws.credentials.secretKey -}}
 {{- end -}}
 
+{{/* The name of the webhook service, certificate and serving certificate secret */}}
+{{- define "ack-s3-controller.webhook.name" -}}
+{{ include "ack-s3-controller.app.fullname" . }}-webhook
+{{- end -}}
+
+{{/* The directory the webhook server reads its serving certificate from */}}
+{{- define "ack-s3-controller.webhook.cert_dir" -}}
+{{- "/tmp/k8s-webhook-server/serving-certs" -}}
+{{- end -}}
+
+{{/* The path the mandatory bucket policy statements are mounted */}}
+{{- define "ack-s3-controller.bucket-policy.mount_path" -}}
+{{- "/etc/ack/bucket-policy" -}}
+{{- end -}}
+
+{{/* The path of the mandatory bucket policy statements file */}}
+{{- define "ack-s3-controller.bucket-policy.mandatory-statements-path" -}}
+{{ $mount_path := include "ack-s3-controller.bucket-policy.mount_path" . }}
+{{- printf "%s/mandatory-statements.json" $mount_path -}}
+{{- end -}}
+
 {{/* The rules a of ClusterRole or Role */}}
 {{- define "ack-s3-controller.rbac-rules" -}}
 rules:
@@ -57,9 +78,16 @@ rules:
   - configmaps
   - secrets
   verbs:
+{{- if .Values.bucket.connectionDetails }}
+  - create
+  - delete
+{{- end }}
   - get
   - list
   - patch
+{{- if .Values.bucket.connectionDetails }}
+  - update
+{{- end }}
   - watch
 - apiGroups:
   - ""
@@ -69,18 +97,63 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - ""
+  resources:
+  - serviceaccounts
+  verbs:
+  - get
+{{- if .Values.bucket.connectionDetails }}
+- apiGroups:
+  - events.k8s.io
+  resources:
+  - events
+  verbs:
+  - create
+  - patch
+{{- end }}
 - apiGroups:
   - iam.services.k8s.aws
   resources:
   - roles
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - patch
+  - update
+- apiGroups:
+  - iam.services.k8s.aws
+  resources:
   - roles/status
   verbs:
   - get
   - list
+- apiGroups:
+  - kms.services.k8s.aws
+  resources:
+  - keys
+  - keys/status
+  verbs:
+  - get
+  - list
+- apiGroups:
+  - lambda.services.k8s.aws
+  resources:
+  - functions
+  - functions/status
+  verbs:
+  - get
+  - list
 - apiGroups:
   - s3.services.k8s.aws
   resources:
+  - accesspoints
+  - bucketaccesses
   - buckets
+  - multiregionaccesspoints
+  - objects
   verbs:
   - create
   - delete
@@ -92,11 +165,38 @@ rules:
 - apiGroups:
   - s3.services.k8s.aws
   resources:
+  - accesspoints/status
+  - bucketaccesses/status
   - buckets/status
+  - multiregionaccesspoints/status
+  - objects/status
   verbs:
   - get
   - patch
   - update
+- apiGroups:
+  - s3.services.k8s.aws
+  resources:
+  - bucketclasses
+  verbs:
+  - get
+  - list
+- apiGroups:
+  - sns.services.k8s.aws
+  resources:
+  - topics
+  - topics/status
+  verbs:
+  - get
+  - list
+- apiGroups:
+  - sqs.services.k8s.aws
+  resources:
+  - queues
+  - queues/status
+  verbs:
+  - get
+  - list
 - apiGroups:
   - services.k8s.aws
   resources:
@@ -0,0 +1,188 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucketaccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
+)
+
+// In the BucketPolicy mode, the access of a BucketAccess is granted through
+// statements in the policy of the bucket, whose principal is the IAM role the
+// ServiceAccount already assumes. The statements are identified by their
+// Sid, made of iampolicy.BucketAccessSidPrefix, the namespace and name of the
+// BucketAccess and the index of the statement. The Bucket resource leaves
+// them out of the policy it reads back from S3 and keeps them when putting
+// its own policy.
+
+// grantBucketPolicy puts the statements granting the access of the given
+// BucketAccess into the policy of the bucket.
+func (r *Reconciler) grantBucketPolicy(
+	ctx context.Context,
+	ba *svcapitypes.BucketAccess,
+	bucketName string,
+	bucketRegion string,
+	bucketARN string,
+	level iampolicy.AccessLevel,
+) error {
+	principal := aws.ToString(ba.Spec.PrincipalARN)
+	if principal == "" {
+		key := types.NamespacedName{Namespace: ba.Namespace, Name: aws.ToString(ba.Spec.ServiceAccountName)}
+		sa := &corev1.ServiceAccount{}
+		if err := r.apiReader.Get(ctx, key, sa); err != nil {
+			if apierrors.IsNotFound(err) {
+				return pending("service account %s not found", key)
+			}
+			return err
+		}
+		if principal = sa.Annotations[AnnotationRoleARN]; principal == "" {
+			return pending("service account %s has no %s annotation and principalARN is not set",
+				key, AnnotationRoleARN)
+		}
+	}
+
+	statements := iampolicy.BucketAccessStatements(bucketARN, level, aws.ToString(ba.Spec.Prefix))
+	for i, statement := range statements {
+		statement.Sid = grantSidPrefix(ba) + strconv.Itoa(i)
+		statement.Principal = map[string][]string{"AWS": {principal}}
+	}
+	ba.Status.PrincipalARN = aws.String(principal)
+	return r.putGrants(ctx, ba, bucketName, bucketRegion, statements)
+}
+
+// revokeBucketPolicy removes the statements granting the access of the given
+// BucketAccess from the policy of the bucket it last granted access to, if
+// any. Buckets that no longer exist are skipped.
+func (r *Reconciler) revokeBucketPolicy(ctx context.Context, ba *svcapitypes.BucketAccess) error {
+	if ba.Status.PrincipalARN == nil || ba.Status.BucketName == nil {
+		return nil
+	}
+	err := r.putGrants(ctx, ba, *ba.Status.BucketName, aws.ToString(ba.Status.BucketRegion), nil)
+	if err != nil && !isAPIError(err, "NoSuchBucket") {
+		return err
+	}
+	ba.Status.PrincipalARN = nil
+	return nil
+}
+
+// putGrants replaces the statements of the given BucketAccess in the policy
+// of the bucket with the given ones. The policy is only written if this
+// changes it.
+func (r *Reconciler) putGrants(
+	ctx context.Context,
+	ba *svcapitypes.BucketAccess,
+	bucketName string,
+	bucketRegion string,
+	statements []*iampolicy.Statement,
+) error {
+	api, err := r.policyAPI(ctx, bucketRegion)
+	if err != nil {
+		return err
+	}
+	var current *string
+	resp, err := api.GetBucketPolicy(ctx, &svcsdk.GetBucketPolicyInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		if !isAPIError(err, "NoSuchBucketPolicy") {
+			return err
+		}
+	} else {
+		current = resp.Policy
+	}
+
+	doc := &iampolicy.Document{Version: iampolicy.PolicyVersion}
+	if current != nil {
+		if doc, err = iampolicy.Parse(*current); err != nil {
+			return fmt.Errorf("cannot merge the grants of the BucketAccess into the policy of bucket %q: %w",
+				bucketName, err)
+		}
+	}
+	merged := make([]*iampolicy.Statement, 0, len(doc.Statement)+len(statements))
+	for _, statement := range doc.Statement {
+		if !isGrantOf(ba, statement.Sid) {
+			merged = append(merged, statement)
+		}
+	}
+	doc.Statement = append(merged, statements...)
+
+	if len(doc.Statement) == 0 {
+		if current == nil {
+			return nil
+		}
+		_, err := api.DeleteBucketPolicy(ctx, &svcsdk.DeleteBucketPolicyInput{Bucket: aws.String(bucketName)})
+		return err
+	}
+	policy, err := doc.Render()
+	if err != nil {
+		return err
+	}
+	if current != nil && iampolicy.Equal(*current, policy) {
+		return nil
+	}
+	_, err = api.PutBucketPolicy(ctx, &svcsdk.PutBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+		Policy: aws.String(policy),
+	})
+	return err
+}
+
+// policyAPI returns the client of the S3 API in the given region.
+func (r *Reconciler) policyAPI(ctx context.Context, region string) (PolicyAPI, error) {
+	if api, ok := r.policyAPIs.Load(region); ok {
+		return api.(PolicyAPI), nil
+	}
+	api, err := r.newPolicyAPI(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	r.policyAPIs.Store(region, api)
+	return api, nil
+}
+
+// grantSidPrefix returns the prefix of the Sids of the statements of the
+// given BucketAccess. Namespaces cannot contain dots, so the prefixes of two
+// BucketAccesses never make up the Sid of a statement of both.
+func grantSidPrefix(ba *svcapitypes.BucketAccess) string {
+	return iampolicy.BucketAccessSidPrefix + ba.Namespace + "." + ba.Name + "."
+}
+
+// isGrantOf returns true if the Sid is the one of a statement of the given
+// BucketAccess.
+func isGrantOf(ba *svcapitypes.BucketAccess, sid string) bool {
+	index, ok := strings.CutPrefix(sid, grantSidPrefix(ba))
+	if !ok {
+		return false
+	}
+	_, err := strconv.ParseUint(index, 10, 32)
+	return err == nil
+}
+
+// isAPIError returns true if the error is an error of the AWS API with the
+// given code.
+func isAPIError(err error, code string) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == code
+}
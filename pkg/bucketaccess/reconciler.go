@@ -0,0 +1,386 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package bucketaccess implements the controller of BucketAccess resources,
+// which grant the workloads running under a ServiceAccount least-privilege
+// access to a Bucket, either through an IAM Role resource of the IAM
+// controller or through statements in the policy of the bucket, and revoke
+// it once deleted.
+//
+// Unlike the other resources of the controller, a BucketAccess stands for no
+// AWS resource of its own, so it is reconciled by a plain controller-runtime
+// controller rather than through a resource manager of the ACK runtime.
+package bucketaccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
+)
+
+// +kubebuilder:rbac:groups=s3.services.k8s.aws,resources=bucketaccesses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=s3.services.k8s.aws,resources=bucketaccesses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=iam.services.k8s.aws,resources=roles,verbs=get;list;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get
+
+const (
+	// The modes of a BucketAccess, see BucketAccessSpec.Mode.
+	ModeRole         = "Role"
+	ModeBucketPolicy = "BucketPolicy"
+
+	// The identity types of a BucketAccess in the Role mode, see
+	// BucketAccessSpec.IdentityType.
+	IdentityTypePodIdentity = "PodIdentity"
+	IdentityTypeIRSA        = "IRSA"
+
+	// AnnotationRoleARN is the annotation of the ServiceAccounts holding the
+	// ARN of the IAM role they assume, which is the principal granted access
+	// in the BucketPolicy mode unless the spec sets one.
+	AnnotationRoleARN = "eks.amazonaws.com/role-arn"
+
+	// finalizerName is the finalizer keeping a BucketAccess around until the
+	// access it grants is revoked.
+	finalizerName = "finalizers.s3.services.k8s.aws/BucketAccess"
+
+	// requeuePending is how long to wait before checking again on a
+	// BucketAccess waiting on other resources, whose changes do not trigger
+	// a reconciliation.
+	requeuePending = 15 * time.Second
+	// resyncPeriod is how long to wait before reconciling a BucketAccess
+	// whose access is granted again.
+	resyncPeriod = 10 * time.Hour
+)
+
+// PolicyAPI is the subset of the S3 API used to grant access through bucket
+// policies.
+type PolicyAPI interface {
+	GetBucketPolicy(context.Context, *svcsdk.GetBucketPolicyInput, ...func(*svcsdk.Options)) (*svcsdk.GetBucketPolicyOutput, error)
+	PutBucketPolicy(context.Context, *svcsdk.PutBucketPolicyInput, ...func(*svcsdk.Options)) (*svcsdk.PutBucketPolicyOutput, error)
+	DeleteBucketPolicy(context.Context, *svcsdk.DeleteBucketPolicyInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteBucketPolicyOutput, error)
+}
+
+// PolicyAPIFactory returns a client of the S3 API in the given region.
+type PolicyAPIFactory func(ctx context.Context, region string) (PolicyAPI, error)
+
+// Reconciler reconciles BucketAccess resources. BucketAccesses are read from
+// the cache of the manager, while Buckets, Roles and ServiceAccounts are read
+// directly from the API server so as not to watch every one of them.
+type Reconciler struct {
+	kc           client.Client
+	apiReader    client.Reader
+	cfg          ackcfg.Config
+	newPolicyAPI PolicyAPIFactory
+
+	// policyAPIs caches the clients of the S3 API by region.
+	policyAPIs sync.Map
+}
+
+// NewReconciler returns a Reconciler writing through the given client,
+// reading the resources it does not watch through the given reader, and
+// getting the clients of the S3 API from the given factory. The
+// configuration of the service controller decides, like for the references
+// of the other resources, whether a BucketAccess may reference a Bucket in
+// another namespace.
+func NewReconciler(
+	kc client.Client,
+	apiReader client.Reader,
+	cfg ackcfg.Config,
+	newPolicyAPI PolicyAPIFactory,
+) *Reconciler {
+	return &Reconciler{
+		kc:           kc,
+		apiReader:    apiReader,
+		cfg:          cfg,
+		newPolicyAPI: newPolicyAPI,
+	}
+}
+
+// SetupWithManager registers the Reconciler with the given manager.
+func (r *Reconciler) SetupWithManager(mgr ctrlrt.Manager) error {
+	return ctrlrt.NewControllerManagedBy(mgr).
+		Named("bucketaccess").
+		For(&svcapitypes.BucketAccess{}).
+		Complete(r)
+}
+
+// terminalError is returned for BucketAccesses whose spec cannot be acted on
+// as it is.
+type terminalError struct {
+	err error
+}
+
+func (e *terminalError) Error() string {
+	return e.err.Error()
+}
+
+func terminal(format string, args ...interface{}) error {
+	return &terminalError{fmt.Errorf(format, args...)}
+}
+
+// pendingError is returned while a BucketAccess waits on other resources.
+type pendingError struct {
+	message string
+}
+
+func (e *pendingError) Error() string {
+	return e.message
+}
+
+func pending(format string, args ...interface{}) error {
+	return &pendingError{fmt.Sprintf(format, args...)}
+}
+
+// Reconcile grants the access of the given BucketAccess, or revokes it if
+// the BucketAccess is being deleted, and reports the outcome in its
+// conditions.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrlrt.Request) (ctrlrt.Result, error) {
+	ba := &svcapitypes.BucketAccess{}
+	if err := r.kc.Get(ctx, req.NamespacedName, ba); err != nil {
+		return ctrlrt.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !ba.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(ba, finalizerName) {
+			return ctrlrt.Result{}, nil
+		}
+		if err := r.revoke(ctx, ba); err != nil {
+			var pendingErr *pendingError
+			if errors.As(err, &pendingErr) {
+				log.FromContext(ctx).V(1).Info("waiting to revoke access", "reason", err.Error())
+				return ctrlrt.Result{RequeueAfter: requeuePending}, nil
+			}
+			return ctrlrt.Result{}, err
+		}
+		controllerutil.RemoveFinalizer(ba, finalizerName)
+		return ctrlrt.Result{}, r.kc.Update(ctx, ba)
+	}
+
+	if controllerutil.AddFinalizer(ba, finalizerName) {
+		if err := r.kc.Update(ctx, ba); err != nil {
+			return ctrlrt.Result{}, err
+		}
+	}
+
+	latest := ba.DeepCopy()
+	err := r.grant(ctx, ba)
+
+	var result ctrlrt.Result
+	var terminalErr *terminalError
+	var pendingErr *pendingError
+	switch {
+	case err == nil:
+		setCondition(ba, ackv1alpha1.ConditionTypeTerminal, corev1.ConditionFalse, "")
+		setCondition(ba, ackv1alpha1.ConditionTypeResourceSynced, corev1.ConditionTrue, "access granted")
+		result.RequeueAfter = resyncPeriod
+	case errors.As(err, &terminalErr):
+		setCondition(ba, ackv1alpha1.ConditionTypeTerminal, corev1.ConditionTrue, err.Error())
+		setCondition(ba, ackv1alpha1.ConditionTypeResourceSynced, corev1.ConditionFalse, err.Error())
+		err = nil
+	case errors.As(err, &pendingErr):
+		setCondition(ba, ackv1alpha1.ConditionTypeTerminal, corev1.ConditionFalse, "")
+		setCondition(ba, ackv1alpha1.ConditionTypeResourceSynced, corev1.ConditionFalse, err.Error())
+		result.RequeueAfter = requeuePending
+		err = nil
+	default:
+		setCondition(ba, ackv1alpha1.ConditionTypeResourceSynced, corev1.ConditionFalse, err.Error())
+	}
+
+	if !equality.Semantic.DeepEqual(latest.Status, ba.Status) {
+		if updateErr := r.kc.Status().Update(ctx, ba); updateErr != nil && err == nil {
+			err = updateErr
+		}
+	}
+	if err != nil {
+		return ctrlrt.Result{}, err
+	}
+	return result, nil
+}
+
+// grant grants the access of the given BucketAccess, and revokes the access
+// it granted before in another mode or to another bucket.
+func (r *Reconciler) grant(ctx context.Context, ba *svcapitypes.BucketAccess) error {
+	if aws.ToString(ba.Spec.ServiceAccountName) == "" {
+		return terminal("serviceAccountName is required")
+	}
+	level, err := iampolicy.ParseAccessLevel(aws.ToString(ba.Spec.AccessLevel))
+	if err != nil {
+		return &terminalError{err}
+	}
+	bucket, err := r.getBucket(ctx, ba)
+	if err != nil {
+		return err
+	}
+	bucketName := aws.ToString(bucket.Spec.Name)
+	bucketARN := string(*bucket.Status.ACKResourceMetadata.ARN)
+	bucketRegion := string(*bucket.Status.ACKResourceMetadata.Region)
+
+	switch mode := modeOf(ba); mode {
+	case ModeRole:
+		if err := r.revokeBucketPolicy(ctx, ba); err != nil {
+			return err
+		}
+		ba.Status.BucketName = aws.String(bucketName)
+		ba.Status.BucketRegion = aws.String(bucketRegion)
+		return r.grantRole(ctx, ba, bucketName, bucketARN, level)
+	case ModeBucketPolicy:
+		if err := r.revokeRole(ctx, ba); err != nil {
+			var pendingErr *pendingError
+			if !errors.As(err, &pendingErr) {
+				return err
+			}
+		}
+		if aws.ToString(ba.Status.BucketName) != bucketName ||
+			aws.ToString(ba.Status.BucketRegion) != bucketRegion {
+			if err := r.revokeBucketPolicy(ctx, ba); err != nil {
+				return err
+			}
+		}
+		ba.Status.BucketName = aws.String(bucketName)
+		ba.Status.BucketRegion = aws.String(bucketRegion)
+		return r.grantBucketPolicy(ctx, ba, bucketName, bucketRegion, bucketARN, level)
+	default:
+		return terminal("unknown mode %q, expected %q or %q", mode, ModeRole, ModeBucketPolicy)
+	}
+}
+
+// revoke revokes the access granted by the given BucketAccess in any mode.
+func (r *Reconciler) revoke(ctx context.Context, ba *svcapitypes.BucketAccess) error {
+	if err := r.revokeBucketPolicy(ctx, ba); err != nil {
+		return err
+	}
+	return r.revokeRole(ctx, ba)
+}
+
+// getBucket returns the Bucket referenced by the given BucketAccess, once it
+// is synced.
+func (r *Reconciler) getBucket(
+	ctx context.Context,
+	ba *svcapitypes.BucketAccess,
+) (*svcapitypes.Bucket, error) {
+	ref := ba.Spec.BucketRef
+	if ref == nil || ref.From == nil || aws.ToString(ref.From.Name) == "" {
+		return nil, terminal("bucketRef.from.name is required")
+	}
+	namespace, err := ackrt.ResolveCrossNamespaceReference(
+		ctx,
+		r.cfg.EnableCrossNamespace,
+		&ba.Status.Conditions,
+		ackrt.CrossNamespaceRefKindResource,
+		ba.Namespace,
+		ref.From.Namespace,
+		*ref.From.Name,
+	)
+	if err != nil {
+		return nil, &terminalError{err}
+	}
+	key := types.NamespacedName{Namespace: namespace, Name: *ref.From.Name}
+
+	bucket := &svcapitypes.Bucket{}
+	if err := r.apiReader.Get(ctx, key, bucket); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, pending("bucket %s not found", key)
+		}
+		return nil, err
+	}
+	if !isSynced(bucket.Status.Conditions) {
+		return nil, pending("bucket %s is not synced yet", key)
+	}
+	metadata := bucket.Status.ACKResourceMetadata
+	if metadata == nil || metadata.ARN == nil || metadata.Region == nil || bucket.Spec.Name == nil {
+		return nil, pending("bucket %s has no ARN yet", key)
+	}
+	return bucket, nil
+}
+
+// modeOf returns the mode of the given BucketAccess.
+func modeOf(ba *svcapitypes.BucketAccess) string {
+	if mode := aws.ToString(ba.Spec.Mode); mode != "" {
+		return mode
+	}
+	return ModeRole
+}
+
+// isSynced returns true if the ACK.ResourceSynced condition of a resource
+// with the given conditions is true.
+func isSynced(conditions []*ackv1alpha1.Condition) bool {
+	for _, cond := range conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeResourceSynced {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// terminalMessage returns the message of the ACK.Terminal condition of
+// a resource with the given conditions, and whether it is true.
+func terminalMessage(conditions []*ackv1alpha1.Condition) (string, bool) {
+	for _, cond := range conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeTerminal && cond.Status == corev1.ConditionTrue {
+			return aws.ToString(cond.Message), true
+		}
+	}
+	return "", false
+}
+
+// setCondition sets the condition of the given type of the BucketAccess,
+// only updating its transition time when its status changes.
+func setCondition(
+	ba *svcapitypes.BucketAccess,
+	condType ackv1alpha1.ConditionType,
+	status corev1.ConditionStatus,
+	message string,
+) {
+	var msg *string
+	if message != "" {
+		msg = aws.String(message)
+	}
+	for _, cond := range ba.Status.Conditions {
+		if cond.Type != condType {
+			continue
+		}
+		if cond.Status != status {
+			now := metav1.Now()
+			cond.LastTransitionTime = &now
+		}
+		cond.Status = status
+		cond.Message = msg
+		return
+	}
+	now := metav1.Now()
+	ba.Status.Conditions = append(ba.Status.Conditions, &ackv1alpha1.Condition{
+		Type:               condType,
+		Status:             status,
+		Message:            msg,
+		LastTransitionTime: &now,
+	})
+}
@@ -0,0 +1,337 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucketaccess
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	iamapitypes "github.com/aws-controllers-k8s/iam-controller/apis/v1alpha1"
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+const (
+	namespace = "team-a"
+	bucketARN = "arn:aws:s3:::my-bucket"
+)
+
+// fakePolicyAPI holds the policies of buckets by name.
+type fakePolicyAPI struct {
+	policies map[string]string
+}
+
+func (f *fakePolicyAPI) GetBucketPolicy(
+	ctx context.Context, input *svcsdk.GetBucketPolicyInput, opts ...func(*svcsdk.Options),
+) (*svcsdk.GetBucketPolicyOutput, error) {
+	policy, ok := f.policies[*input.Bucket]
+	if !ok {
+		return nil, &smithy.GenericAPIError{Code: "NoSuchBucketPolicy"}
+	}
+	return &svcsdk.GetBucketPolicyOutput{Policy: aws.String(policy)}, nil
+}
+
+func (f *fakePolicyAPI) PutBucketPolicy(
+	ctx context.Context, input *svcsdk.PutBucketPolicyInput, opts ...func(*svcsdk.Options),
+) (*svcsdk.PutBucketPolicyOutput, error) {
+	f.policies[*input.Bucket] = *input.Policy
+	return &svcsdk.PutBucketPolicyOutput{}, nil
+}
+
+func (f *fakePolicyAPI) DeleteBucketPolicy(
+	ctx context.Context, input *svcsdk.DeleteBucketPolicyInput, opts ...func(*svcsdk.Options),
+) (*svcsdk.DeleteBucketPolicyOutput, error) {
+	delete(f.policies, *input.Bucket)
+	return &svcsdk.DeleteBucketPolicyOutput{}, nil
+}
+
+func newReconciler(t *testing.T, objs ...client.Object) (*Reconciler, client.Client, *fakePolicyAPI) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, svcapitypes.AddToScheme(scheme))
+	require.NoError(t, iamapitypes.AddToScheme(scheme))
+	kc := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&svcapitypes.BucketAccess{}, &svcapitypes.Bucket{}, &iamapitypes.Role{}).
+		WithObjects(objs...).
+		Build()
+	api := &fakePolicyAPI{policies: map[string]string{}}
+	newPolicyAPI := func(ctx context.Context, region string) (PolicyAPI, error) {
+		return api, nil
+	}
+	return NewReconciler(kc, kc, ackcfg.Config{EnableCrossNamespace: true}, newPolicyAPI), kc, api
+}
+
+// syncedStatus returns the conditions and metadata the controllers set on
+// the resources they created.
+func syncedStatus(arn string) ([]*ackv1alpha1.Condition, *ackv1alpha1.ResourceMetadata) {
+	region := ackv1alpha1.AWSRegion("us-west-2")
+	return []*ackv1alpha1.Condition{{
+		Type:   ackv1alpha1.ConditionTypeResourceSynced,
+		Status: corev1.ConditionTrue,
+	}}, &ackv1alpha1.ResourceMetadata{
+		ARN:    (*ackv1alpha1.AWSResourceName)(aws.String(arn)),
+		Region: &region,
+	}
+}
+
+func newBucket() *svcapitypes.Bucket {
+	bucket := &svcapitypes.Bucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-bucket", Namespace: namespace},
+		Spec:       svcapitypes.BucketSpec{Name: aws.String("my-bucket")},
+	}
+	bucket.Status.Conditions, bucket.Status.ACKResourceMetadata = syncedStatus(bucketARN)
+	return bucket
+}
+
+func newBucketAccess(name string, mode string) *svcapitypes.BucketAccess {
+	return &svcapitypes.BucketAccess{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: svcapitypes.BucketAccessSpec{
+			BucketRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+				From: &ackv1alpha1.AWSResourceReference{Name: aws.String("my-bucket")},
+			},
+			ServiceAccountName: aws.String("app"),
+			AccessLevel:        aws.String("read"),
+			Mode:               aws.String(mode),
+		},
+	}
+}
+
+func reconcile(t *testing.T, r *Reconciler, ba *svcapitypes.BucketAccess) ctrlrt.Result {
+	result, err := r.Reconcile(context.Background(), ctrlrt.Request{
+		NamespacedName: client.ObjectKeyFromObject(ba),
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func syncedCondition(t *testing.T, kc client.Client, ba *svcapitypes.BucketAccess) *ackv1alpha1.Condition {
+	require.NoError(t, kc.Get(context.Background(), client.ObjectKeyFromObject(ba), ba))
+	for _, cond := range ba.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeResourceSynced {
+			return cond
+		}
+	}
+	t.Fatal("no ACK.ResourceSynced condition")
+	return nil
+}
+
+func Test_Reconcile_Role(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+	ba := newBucketAccess("reader", ModeRole)
+	r, kc, _ := newReconciler(t, newBucket(), ba)
+
+	result := reconcile(t, r, ba)
+	assert.Equal(requeuePending, result.RequeueAfter)
+	assert.Equal(corev1.ConditionFalse, syncedCondition(t, kc, ba).Status)
+	assert.Contains(ba.Finalizers, finalizerName)
+
+	role := &iamapitypes.Role{}
+	require.NoError(kc.Get(ctx, roleKey(ba), role))
+	assert.True(metav1.IsControlledBy(role, ba))
+	assert.Equal("team-a.reader", *role.Spec.Name)
+	assert.JSONEq(`{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Effect": "Allow",
+			"Principal": {"Service": ["pods.eks.amazonaws.com"]},
+			"Action": ["sts:AssumeRole", "sts:TagSession"],
+			"Condition": {"StringEquals": {
+				"aws:RequestTag/kubernetes-namespace": ["team-a"],
+				"aws:RequestTag/kubernetes-service-account": ["app"]
+			}}
+		}]
+	}`, *role.Spec.AssumeRolePolicyDocument)
+	assert.Contains(*role.Spec.InlinePolicies[accessPolicyName], `"s3:GetObject"`)
+
+	roleARN := "arn:aws:iam::111122223333:role/team-a.reader"
+	role.Status.Conditions, role.Status.ACKResourceMetadata = syncedStatus(roleARN)
+	require.NoError(kc.Status().Update(ctx, role))
+	result = reconcile(t, r, ba)
+	assert.Equal(resyncPeriod, result.RequeueAfter)
+	assert.Equal(corev1.ConditionTrue, syncedCondition(t, kc, ba).Status)
+	assert.Equal(roleARN, aws.ToString(ba.Status.RoleARN))
+
+	// Changing the access level updates the inline policy of the role
+	ba.Spec.AccessLevel = aws.String("write")
+	require.NoError(kc.Update(ctx, ba))
+	reconcile(t, r, ba)
+	require.NoError(kc.Get(ctx, roleKey(ba), role))
+	assert.Contains(*role.Spec.InlinePolicies[accessPolicyName], `"s3:PutObject"`)
+
+	// The role is deleted before the BucketAccess
+	require.NoError(kc.Delete(ctx, ba))
+	reconcile(t, r, ba)
+	assert.True(apierrors.IsNotFound(kc.Get(ctx, roleKey(ba), role)))
+	reconcile(t, r, ba)
+	assert.True(apierrors.IsNotFound(kc.Get(ctx, client.ObjectKeyFromObject(ba), ba)))
+}
+
+func Test_Reconcile_Role_Terminal(t *testing.T) {
+	assert := assert.New(t)
+	ba := newBucketAccess("reader", ModeRole)
+	ba.Spec.IdentityType = aws.String(IdentityTypeIRSA)
+	r, kc, _ := newReconciler(t, newBucket(), ba)
+
+	result := reconcile(t, r, ba)
+	assert.Zero(result.RequeueAfter)
+	cond := syncedCondition(t, kc, ba)
+	assert.Equal(corev1.ConditionFalse, cond.Status)
+	assert.Contains(aws.ToString(cond.Message), "oidcProviderARN is required")
+	message, ok := terminalMessage(ba.Status.Conditions)
+	assert.True(ok)
+	assert.Equal(aws.ToString(cond.Message), message)
+}
+
+func Test_Reconcile_CrossNamespaceBucket(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	bucket := newBucket()
+	bucket.Namespace = "team-b"
+	ba := newBucketAccess("reader", ModeRole)
+	ba.Spec.BucketRef.From.Namespace = aws.String("team-b")
+	r, kc, _ := newReconciler(t, bucket, ba)
+
+	// Bucket references cannot cross namespaces when disabled
+	r.cfg.EnableCrossNamespace = false
+	result := reconcile(t, r, ba)
+	assert.Zero(result.RequeueAfter)
+	cond := syncedCondition(t, kc, ba)
+	assert.Equal(corev1.ConditionFalse, cond.Status)
+	assert.Contains(aws.ToString(cond.Message), "targetNamespace:team-b")
+	_, ok := terminalMessage(ba.Status.Conditions)
+	assert.True(ok)
+
+	r.cfg.EnableCrossNamespace = true
+	reconcile(t, r, ba)
+	assert.NoError(kc.Get(ctx, roleKey(ba), &iamapitypes.Role{}))
+}
+
+func Test_Reconcile_BucketPolicy(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+	ba := newBucketAccess("reader", ModeBucketPolicy)
+	ba.Spec.Prefix = aws.String("logs/")
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: namespace}}
+	r, kc, api := newReconciler(t, newBucket(), ba, sa)
+	existing := `{"Version":"2012-10-17","Statement":[{"Sid":"Existing","Effect":"Deny",` +
+		`"Principal":"*","Action":"s3:*","Resource":"arn:aws:s3:::my-bucket/*",` +
+		`"Condition":{"Bool":{"aws:SecureTransport":"false"}}}]}`
+	api.policies["my-bucket"] = existing
+
+	// The ServiceAccount has yet to be annotated with its role
+	result := reconcile(t, r, ba)
+	assert.Equal(requeuePending, result.RequeueAfter)
+	assert.Contains(aws.ToString(syncedCondition(t, kc, ba).Message), AnnotationRoleARN)
+
+	principal := "arn:aws:iam::111122223333:role/app"
+	sa.Annotations = map[string]string{AnnotationRoleARN: principal}
+	require.NoError(kc.Update(ctx, sa))
+	reconcile(t, r, ba)
+	assert.Equal(corev1.ConditionTrue, syncedCondition(t, kc, ba).Status)
+	assert.Equal(principal, aws.ToString(ba.Status.PrincipalARN))
+	assert.JSONEq(`{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Sid": "Existing",
+				"Effect": "Deny",
+				"Principal": {"AWS": ["*"]},
+				"Action": ["s3:*"],
+				"Resource": ["arn:aws:s3:::my-bucket/*"],
+				"Condition": {"Bool": {"aws:SecureTransport": ["false"]}}
+			},
+			{
+				"Sid": "ACKBucketAccess-team-a.reader.0",
+				"Effect": "Allow",
+				"Principal": {"AWS": ["arn:aws:iam::111122223333:role/app"]},
+				"Action": ["s3:GetBucketLocation", "s3:ListBucket", "s3:ListBucketVersions"],
+				"Resource": ["arn:aws:s3:::my-bucket"],
+				"Condition": {"StringLike": {"s3:prefix": ["logs/*"]}}
+			},
+			{
+				"Sid": "ACKBucketAccess-team-a.reader.1",
+				"Effect": "Allow",
+				"Principal": {"AWS": ["arn:aws:iam::111122223333:role/app"]},
+				"Action": ["s3:GetObject", "s3:GetObjectTagging", "s3:GetObjectVersion"],
+				"Resource": ["arn:aws:s3:::my-bucket/logs/*"]
+			}
+		]
+	}`, api.policies["my-bucket"])
+
+	// Switching to the Role mode revokes the grants
+	ba.Spec.Mode = aws.String(ModeRole)
+	require.NoError(kc.Update(ctx, ba))
+	reconcile(t, r, ba)
+	require.NoError(kc.Get(ctx, client.ObjectKeyFromObject(ba), ba))
+	assert.NotContains(api.policies["my-bucket"], "ACKBucketAccess-")
+	assert.Nil(ba.Status.PrincipalARN)
+
+	// Deleting the BucketAccess deletes the policy it is alone in
+	ba.Spec.Mode = aws.String(ModeBucketPolicy)
+	require.NoError(kc.Update(ctx, ba))
+	delete(api.policies, "my-bucket")
+	reconcile(t, r, ba)
+	require.Contains(api.policies, "my-bucket")
+	require.NoError(kc.Delete(ctx, ba))
+	reconcile(t, r, ba)
+	assert.NotContains(api.policies, "my-bucket")
+	assert.True(apierrors.IsNotFound(kc.Get(ctx, client.ObjectKeyFromObject(ba), ba)))
+}
+
+func Test_roleName(t *testing.T) {
+	assert := assert.New(t)
+	ba := newBucketAccess(strings.Repeat("a", 63), ModeRole)
+	name := roleName(ba)
+	assert.Len(name, maxRoleNameLength)
+	assert.True(strings.HasPrefix(name, "team-a.aaaa"))
+
+	other := newBucketAccess(strings.Repeat("a", 62)+"b", ModeRole)
+	assert.NotEqual(name, roleName(other))
+
+	// Dashes in namespaces and names do not make names collide
+	ba = newBucketAccess("b-reader", ModeRole)
+	other = newBucketAccess("reader", ModeRole)
+	other.Namespace = "team-a-b"
+	assert.NotEqual(roleName(ba), roleName(other))
+}
+
+func Test_isGrantOf(t *testing.T) {
+	assert := assert.New(t)
+	ba := newBucketAccess("x", ModeBucketPolicy)
+	assert.True(isGrantOf(ba, "ACKBucketAccess-team-a.x.0"))
+	assert.False(isGrantOf(ba, "ACKBucketAccess-team-a.x.0.1"), "grant of the BucketAccess x.0")
+	assert.False(isGrantOf(ba, "ACKBucketAccess-team-a.xy.0"))
+	assert.False(isGrantOf(ba, "ACKDestinationGrant-Logging-x"))
+}
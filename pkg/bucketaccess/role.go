@@ -0,0 +1,220 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucketaccess
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	iamapitypes "github.com/aws-controllers-k8s/iam-controller/apis/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
+)
+
+const (
+	// accessPolicyName is the name of the inline policy granting the access
+	// of a BucketAccess in the Role mode.
+	accessPolicyName = "s3-bucket-access"
+	// maxRoleNameLength is the length IAM accepts for the names of roles.
+	maxRoleNameLength = 64
+	// roleNameHashLength is the length of the hash ending the names of roles
+	// that would otherwise be too long.
+	roleNameHashLength = 8
+)
+
+// In the Role mode, the access of a BucketAccess is granted through an IAM
+// Role resource of the same name and namespace, controlled by the
+// BucketAccess so that it is garbage collected along with it. The role trusts
+// the ServiceAccount of the BucketAccess, through EKS Pod Identity or IRSA,
+// and its inline policy grants the access to the bucket.
+
+// grantRole creates or updates the Role resource of the given BucketAccess,
+// and records the ARN of the role once it is synced.
+func (r *Reconciler) grantRole(
+	ctx context.Context,
+	ba *svcapitypes.BucketAccess,
+	bucketName string,
+	bucketARN string,
+	level iampolicy.AccessLevel,
+) error {
+	trust, err := trustPolicy(ba)
+	if err != nil {
+		return &terminalError{err}
+	}
+	policy, err := iampolicy.BucketAccessPolicy(bucketARN, level, aws.ToString(ba.Spec.Prefix))
+	if err != nil {
+		return err
+	}
+
+	key := roleKey(ba)
+	role := &iamapitypes.Role{}
+	err = r.apiReader.Get(ctx, key, role)
+	if apierrors.IsNotFound(err) {
+		role = &iamapitypes.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: iamapitypes.RoleSpec{
+				Name:                     aws.String(roleName(ba)),
+				AssumeRolePolicyDocument: aws.String(trust),
+				Description:              aws.String(fmt.Sprintf("Access to S3 bucket %s", bucketName)),
+				InlinePolicies:           map[string]*string{accessPolicyName: aws.String(policy)},
+			},
+		}
+		if err := controllerutil.SetControllerReference(ba, role, r.kc.Scheme()); err != nil {
+			return err
+		}
+		if err := r.kc.Create(ctx, role); err != nil {
+			return err
+		}
+		return pending("role %s is being created", key)
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(role, ba) {
+		return terminal("role %s already exists and is not managed by the BucketAccess", key)
+	}
+
+	if !iampolicy.Equal(aws.ToString(role.Spec.AssumeRolePolicyDocument), trust) ||
+		!iampolicy.Equal(aws.ToString(role.Spec.InlinePolicies[accessPolicyName]), policy) ||
+		len(role.Spec.InlinePolicies) != 1 {
+		role.Spec.AssumeRolePolicyDocument = aws.String(trust)
+		role.Spec.Description = aws.String(fmt.Sprintf("Access to S3 bucket %s", bucketName))
+		role.Spec.InlinePolicies = map[string]*string{accessPolicyName: aws.String(policy)}
+		if err := r.kc.Update(ctx, role); err != nil {
+			return err
+		}
+		return pending("role %s is being updated", key)
+	}
+
+	if message, ok := terminalMessage(role.Status.Conditions); ok {
+		return terminal("role %s cannot be created: %s", key, message)
+	}
+	if !isSynced(role.Status.Conditions) {
+		return pending("role %s is not synced yet", key)
+	}
+	if role.Status.ACKResourceMetadata == nil || role.Status.ACKResourceMetadata.ARN == nil {
+		return pending("role %s has no ARN yet", key)
+	}
+	ba.Status.RoleARN = aws.String(string(*role.Status.ACKResourceMetadata.ARN))
+	return nil
+}
+
+// revokeRole deletes the Role resource of the given BucketAccess, and
+// returns a pending error until it is gone.
+func (r *Reconciler) revokeRole(ctx context.Context, ba *svcapitypes.BucketAccess) error {
+	key := roleKey(ba)
+	role := &iamapitypes.Role{}
+	if err := r.apiReader.Get(ctx, key, role); err != nil {
+		if apierrors.IsNotFound(err) {
+			ba.Status.RoleARN = nil
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(role, ba) {
+		ba.Status.RoleARN = nil
+		return nil
+	}
+	if role.DeletionTimestamp.IsZero() {
+		if err := r.kc.Delete(ctx, role); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return pending("role %s is being deleted", key)
+}
+
+// roleKey returns the key of the Role resource of the given BucketAccess.
+func roleKey(ba *svcapitypes.BucketAccess) types.NamespacedName {
+	return types.NamespacedName{Namespace: ba.Namespace, Name: ba.Name}
+}
+
+// roleName returns the name of the IAM role of the given BucketAccess, made
+// of its namespace and name joined by a dot, which namespaces cannot contain,
+// so that no two BucketAccesses get the same name. Names too long for IAM are
+// shortened and end with an underscore, which Kubernetes names cannot
+// contain either, followed by a hash of the full name.
+func roleName(ba *svcapitypes.BucketAccess) string {
+	name := ba.Namespace + "." + ba.Name
+	if len(name) <= maxRoleNameLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:])[:roleNameHashLength]
+	return name[:maxRoleNameLength-roleNameHashLength-1] + "_" + hash
+}
+
+// trustPolicy returns the trust policy letting the ServiceAccount of the
+// given BucketAccess assume its role.
+func trustPolicy(ba *svcapitypes.BucketAccess) (string, error) {
+	serviceAccount := aws.ToString(ba.Spec.ServiceAccountName)
+
+	var statement *iampolicy.Statement
+	switch identityType := aws.ToString(ba.Spec.IdentityType); identityType {
+	case "", IdentityTypePodIdentity:
+		statement = &iampolicy.Statement{
+			Effect:    "Allow",
+			Principal: map[string][]string{"Service": {"pods.eks.amazonaws.com"}},
+			Action:    []string{"sts:AssumeRole", "sts:TagSession"},
+			Condition: map[string]map[string][]string{
+				"StringEquals": {
+					"aws:RequestTag/kubernetes-namespace":       {ba.Namespace},
+					"aws:RequestTag/kubernetes-service-account": {serviceAccount},
+				},
+			},
+		}
+	case IdentityTypeIRSA:
+		providerARN := aws.ToString(ba.Spec.OIDCProviderARN)
+		if providerARN == "" {
+			return "", fmt.Errorf("oidcProviderARN is required by the %s identity type", IdentityTypeIRSA)
+		}
+		_, issuer, ok := strings.Cut(providerARN, ":oidc-provider/")
+		if !ok || issuer == "" {
+			return "", fmt.Errorf("oidcProviderARN %q is not the ARN of an IAM OIDC provider", providerARN)
+		}
+		statement = &iampolicy.Statement{
+			Effect:    "Allow",
+			Principal: map[string][]string{"Federated": {providerARN}},
+			Action:    []string{"sts:AssumeRoleWithWebIdentity"},
+			Condition: map[string]map[string][]string{
+				"StringEquals": {
+					issuer + ":sub": {"system:serviceaccount:" + ba.Namespace + ":" + serviceAccount},
+					issuer + ":aud": {"sts.amazonaws.com"},
+				},
+			},
+		}
+	default:
+		return "", fmt.Errorf(
+			"unknown identity type %q, expected %q or %q",
+			identityType, IdentityTypePodIdentity, IdentityTypeIRSA,
+		)
+	}
+
+	doc := &iampolicy.Document{
+		Version:   iampolicy.PolicyVersion,
+		Statement: []*iampolicy.Statement{statement},
+	}
+	return doc.Render()
+}
@@ -0,0 +1,139 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package connectiondetails writes the connection details of Buckets, that
+// is everything applications need to reach a bucket, into the Secret or
+// ConfigMap named by their writeConnectionDetailsTo field.
+package connectiondetails
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/resource/bucket"
+)
+
+// The keys of the connection details.
+const (
+	KeyBucketName = "BUCKET_NAME"
+	KeyRegion     = "BUCKET_REGION"
+	KeyARN        = "BUCKET_ARN"
+	// KeyEndpointURL is the URL of the regional endpoint of S3, or of the
+	// zonal endpoint of directory buckets.
+	KeyEndpointURL = "BUCKET_ENDPOINT_URL"
+	// KeyDualStackEndpointURL is the URL of the dual-stack (IPv4 and IPv6)
+	// regional endpoint of S3. General purpose buckets only.
+	KeyDualStackEndpointURL = "BUCKET_DUALSTACK_ENDPOINT_URL"
+	// KeyVirtualHostedURL is the virtual-hosted-style URL of the bucket.
+	KeyVirtualHostedURL = "BUCKET_VIRTUAL_HOSTED_URL"
+	// KeyWebsiteURL is the URL of the website endpoint of the bucket. Buckets
+	// with a website configuration only.
+	KeyWebsiteURL = "BUCKET_WEBSITE_URL"
+	// KeyZonalEndpointURL is the URL of the zonal endpoint of the
+	// Availability Zone or Local Zone of the bucket. Directory buckets only.
+	KeyZonalEndpointURL = "BUCKET_ZONAL_ENDPOINT_URL"
+)
+
+// dashWebsiteRegions are the regions whose website endpoints separate the
+// region with a dash rather than a dot, see
+// https://docs.aws.amazon.com/general/latest/gr/s3.html#s3_website_region_endpoints
+var dashWebsiteRegions = map[string]bool{
+	"ap-northeast-1": true,
+	"ap-southeast-1": true,
+	"ap-southeast-2": true,
+	"eu-west-1":      true,
+	"sa-east-1":      true,
+	"us-east-1":      true,
+	"us-gov-west-1":  true,
+	"us-west-1":      true,
+	"us-west-2":      true,
+}
+
+// ForBucket returns the connection details of the given Bucket, or nil until
+// the controller has recorded its ARN and region. The partition, and with it
+// the DNS suffix of the endpoints, is taken from the ARN.
+func ForBucket(ko *svcapitypes.Bucket) map[string]string {
+	metadata := ko.Status.ACKResourceMetadata
+	if metadata == nil || metadata.ARN == nil || metadata.Region == nil || ko.Spec.Name == nil {
+		return nil
+	}
+	name := *ko.Spec.Name
+	arn := string(*metadata.ARN)
+	region := string(*metadata.Region)
+	suffix := dnsSuffix(arn, region)
+
+	details := map[string]string{
+		KeyBucketName: name,
+		KeyRegion:     region,
+		KeyARN:        arn,
+	}
+	if bucket.IsDirectoryBucketName(name) {
+		zone := zoneID(ko)
+		if zone == "" {
+			return details
+		}
+		zonal := fmt.Sprintf("s3express-%s.%s.%s", zone, region, suffix)
+		details[KeyEndpointURL] = "https://" + zonal
+		details[KeyZonalEndpointURL] = "https://" + zonal
+		details[KeyVirtualHostedURL] = "https://" + name + "." + zonal
+		return details
+	}
+
+	details[KeyEndpointURL] = fmt.Sprintf("https://s3.%s.%s", region, suffix)
+	details[KeyDualStackEndpointURL] = fmt.Sprintf("https://s3.dualstack.%s.%s", region, suffix)
+	details[KeyVirtualHostedURL] = fmt.Sprintf("https://%s.s3.%s.%s", name, region, suffix)
+	if ko.Spec.Website != nil {
+		separator := "."
+		if dashWebsiteRegions[region] {
+			separator = "-"
+		}
+		details[KeyWebsiteURL] = fmt.Sprintf("http://%s.s3-website%s%s.%s", name, separator, region, suffix)
+	}
+	return details
+}
+
+// dnsSuffix returns the DNS suffix of the partition of the given ARN,
+// falling back to the partition of the region.
+func dnsSuffix(arn string, region string) string {
+	partitions := endpoints.DefaultPartitions()
+	if fields := strings.SplitN(arn, ":", 3); len(fields) == 3 && fields[0] == "arn" {
+		for _, p := range partitions {
+			if p.ID() == fields[1] {
+				return p.DNSSuffix()
+			}
+		}
+	}
+	if p, ok := endpoints.PartitionForRegion(partitions, region); ok {
+		return p.DNSSuffix()
+	}
+	return "amazonaws.com"
+}
+
+// zoneID returns the ID of the Availability Zone or Local Zone of the given
+// directory bucket, taken from its CreateBucketConfiguration, or else from
+// its name, which is made of a base name, the zone ID and the --x-s3 suffix.
+func zoneID(ko *svcapitypes.Bucket) string {
+	if config := ko.Spec.CreateBucketConfiguration; config != nil &&
+		config.Location != nil && aws.ToString(config.Location.Name) != "" {
+		return *config.Location.Name
+	}
+	base := strings.TrimSuffix(aws.ToString(ko.Spec.Name), "--x-s3")
+	if i := strings.LastIndex(base, "--"); i >= 0 {
+		return base[i+2:]
+	}
+	return ""
+}
@@ -0,0 +1,149 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package connectiondetails
+
+import (
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+func newBucket(name string, arn string, region string) *svcapitypes.Bucket {
+	ko := &svcapitypes.Bucket{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       svcapitypes.BucketSpec{Name: aws.String(name)},
+	}
+	if arn != "" {
+		awsRegion := ackv1alpha1.AWSRegion(region)
+		ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{
+			ARN:    (*ackv1alpha1.AWSResourceName)(aws.String(arn)),
+			Region: &awsRegion,
+		}
+	}
+	return ko
+}
+
+func Test_ForBucket(t *testing.T) {
+	withWebsite := func(ko *svcapitypes.Bucket) *svcapitypes.Bucket {
+		ko.Spec.Website = &svcapitypes.WebsiteConfiguration{}
+		return ko
+	}
+	withLocation := func(ko *svcapitypes.Bucket) *svcapitypes.Bucket {
+		ko.Spec.CreateBucketConfiguration = &svcapitypes.CreateBucketConfiguration{
+			Location: &svcapitypes.LocationInfo{
+				Name: aws.String("usw2-az1"),
+				Type: aws.String("AvailabilityZone"),
+			},
+		}
+		return ko
+	}
+
+	tests := []struct {
+		name     string
+		ko       *svcapitypes.Bucket
+		expected map[string]string
+	}{
+		{
+			name:     "not created yet",
+			ko:       newBucket("my-bucket", "", ""),
+			expected: nil,
+		},
+		{
+			name: "general purpose bucket",
+			ko:   newBucket("my-bucket", "arn:aws:s3:::my-bucket", "eu-central-1"),
+			expected: map[string]string{
+				KeyBucketName:           "my-bucket",
+				KeyRegion:               "eu-central-1",
+				KeyARN:                  "arn:aws:s3:::my-bucket",
+				KeyEndpointURL:          "https://s3.eu-central-1.amazonaws.com",
+				KeyDualStackEndpointURL: "https://s3.dualstack.eu-central-1.amazonaws.com",
+				KeyVirtualHostedURL:     "https://my-bucket.s3.eu-central-1.amazonaws.com",
+			},
+		},
+		{
+			name: "website in a region separated by a dot",
+			ko:   withWebsite(newBucket("site", "arn:aws:s3:::site", "eu-central-1")),
+			expected: map[string]string{
+				KeyBucketName:           "site",
+				KeyRegion:               "eu-central-1",
+				KeyARN:                  "arn:aws:s3:::site",
+				KeyEndpointURL:          "https://s3.eu-central-1.amazonaws.com",
+				KeyDualStackEndpointURL: "https://s3.dualstack.eu-central-1.amazonaws.com",
+				KeyVirtualHostedURL:     "https://site.s3.eu-central-1.amazonaws.com",
+				KeyWebsiteURL:           "http://site.s3-website.eu-central-1.amazonaws.com",
+			},
+		},
+		{
+			name: "website in a region separated by a dash",
+			ko:   withWebsite(newBucket("site", "arn:aws:s3:::site", "us-west-2")),
+			expected: map[string]string{
+				KeyBucketName:           "site",
+				KeyRegion:               "us-west-2",
+				KeyARN:                  "arn:aws:s3:::site",
+				KeyEndpointURL:          "https://s3.us-west-2.amazonaws.com",
+				KeyDualStackEndpointURL: "https://s3.dualstack.us-west-2.amazonaws.com",
+				KeyVirtualHostedURL:     "https://site.s3.us-west-2.amazonaws.com",
+				KeyWebsiteURL:           "http://site.s3-website-us-west-2.amazonaws.com",
+			},
+		},
+		{
+			name: "china partition",
+			ko:   newBucket("my-bucket", "arn:aws-cn:s3:::my-bucket", "cn-north-1"),
+			expected: map[string]string{
+				KeyBucketName:           "my-bucket",
+				KeyRegion:               "cn-north-1",
+				KeyARN:                  "arn:aws-cn:s3:::my-bucket",
+				KeyEndpointURL:          "https://s3.cn-north-1.amazonaws.com.cn",
+				KeyDualStackEndpointURL: "https://s3.dualstack.cn-north-1.amazonaws.com.cn",
+				KeyVirtualHostedURL:     "https://my-bucket.s3.cn-north-1.amazonaws.com.cn",
+			},
+		},
+		{
+			name: "directory bucket",
+			ko: withLocation(newBucket("data--usw2-az1--x-s3",
+				"arn:aws:s3express:us-west-2:111122223333:bucket/data--usw2-az1--x-s3", "us-west-2")),
+			expected: map[string]string{
+				KeyBucketName:       "data--usw2-az1--x-s3",
+				KeyRegion:           "us-west-2",
+				KeyARN:              "arn:aws:s3express:us-west-2:111122223333:bucket/data--usw2-az1--x-s3",
+				KeyEndpointURL:      "https://s3express-usw2-az1.us-west-2.amazonaws.com",
+				KeyZonalEndpointURL: "https://s3express-usw2-az1.us-west-2.amazonaws.com",
+				KeyVirtualHostedURL: "https://data--usw2-az1--x-s3.s3express-usw2-az1.us-west-2.amazonaws.com",
+			},
+		},
+		{
+			name: "directory bucket zone taken from the name",
+			ko: newBucket("data--use1-az4--x-s3",
+				"arn:aws:s3express:us-east-1:111122223333:bucket/data--use1-az4--x-s3", "us-east-1"),
+			expected: map[string]string{
+				KeyBucketName:       "data--use1-az4--x-s3",
+				KeyRegion:           "us-east-1",
+				KeyARN:              "arn:aws:s3express:us-east-1:111122223333:bucket/data--use1-az4--x-s3",
+				KeyEndpointURL:      "https://s3express-use1-az4.us-east-1.amazonaws.com",
+				KeyZonalEndpointURL: "https://s3express-use1-az4.us-east-1.amazonaws.com",
+				KeyVirtualHostedURL: "https://data--use1-az4--x-s3.s3express-use1-az4.us-east-1.amazonaws.com",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ForBucket(tt.ko))
+		})
+	}
+}
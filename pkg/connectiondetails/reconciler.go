@@ -0,0 +1,261 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package connectiondetails
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps;secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+const (
+	// The kinds of objects connection details are written to, see
+	// ConnectionDetailsTarget.Kind.
+	KindSecret    = "Secret"
+	KindConfigMap = "ConfigMap"
+
+	// LabelConnectionDetails is the label set on the Secrets and ConfigMaps
+	// holding the connection details of a Bucket, which also controls them.
+	LabelConnectionDetails = "s3.services.k8s.aws/connection-details"
+
+	// reasonConflict is the reason of the events recorded on Buckets whose
+	// connection details cannot be written to an object they do not own.
+	reasonConflict = "ConnectionDetailsConflict"
+)
+
+// Reconciler writes the connection details of Buckets. It is triggered by
+// every change to a Bucket, including to its status, so the connection
+// details are written as soon as the bucket is created and kept up to date
+// afterwards. Secrets and ConfigMaps are read directly from the API server so
+// as not to watch every one of them.
+type Reconciler struct {
+	kc        client.Client
+	apiReader client.Reader
+	recorder  events.EventRecorder
+
+	// targets holds, by Bucket, the kind and name of the object the
+	// connection details were last written to, or an empty string if none,
+	// so that stale objects are only looked for when it changes.
+	targets sync.Map
+}
+
+// NewReconciler returns a Reconciler writing through the given client,
+// reading Secrets and ConfigMaps through the given reader, and recording
+// events with the given recorder.
+func NewReconciler(
+	kc client.Client,
+	apiReader client.Reader,
+	recorder events.EventRecorder,
+) *Reconciler {
+	return &Reconciler{
+		kc:        kc,
+		apiReader: apiReader,
+		recorder:  recorder,
+	}
+}
+
+// SetupWithManager registers the Reconciler with the given manager.
+func (r *Reconciler) SetupWithManager(mgr ctrlrt.Manager) error {
+	return ctrlrt.NewControllerManagedBy(mgr).
+		Named("bucket-connection-details").
+		For(&svcapitypes.Bucket{}).
+		Complete(r)
+}
+
+// Reconcile writes the connection details of the given Bucket into the
+// object named by its writeConnectionDetailsTo field, and deletes the other
+// objects it wrote them to before. Deleted Buckets are left to the garbage
+// collector.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrlrt.Request) (ctrlrt.Result, error) {
+	ko := &svcapitypes.Bucket{}
+	if err := r.kc.Get(ctx, req.NamespacedName, ko); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.targets.Delete(req.NamespacedName)
+		}
+		return ctrlrt.Result{}, client.IgnoreNotFound(err)
+	}
+	if !ko.DeletionTimestamp.IsZero() {
+		return ctrlrt.Result{}, nil
+	}
+
+	var target client.Object
+	if spec := ko.Spec.WriteConnectionDetailsTo; spec != nil && aws.ToString(spec.Name) != "" {
+		details := ForBucket(ko)
+		if details == nil {
+			// The status update recording the ARN triggers another
+			// reconciliation
+			return ctrlrt.Result{}, nil
+		}
+		var err error
+		if target, err = r.write(ctx, ko, kindOf(spec), *spec.Name, details); err != nil {
+			return ctrlrt.Result{}, err
+		}
+	}
+
+	current := ""
+	if target != nil {
+		current = kindOf(ko.Spec.WriteConnectionDetailsTo) + "/" + target.GetName()
+	}
+	if last, ok := r.targets.Load(req.NamespacedName); ok && last == current {
+		return ctrlrt.Result{}, nil
+	}
+	if err := r.deleteStale(ctx, ko, target); err != nil {
+		return ctrlrt.Result{}, err
+	}
+	r.targets.Store(req.NamespacedName, current)
+	return ctrlrt.Result{}, nil
+}
+
+// write writes the connection details into the Secret or ConfigMap of the
+// given name, which is created if needed, and returns it. Objects the Bucket
+// does not control are left alone and nil is returned.
+func (r *Reconciler) write(
+	ctx context.Context,
+	ko *svcapitypes.Bucket,
+	kind string,
+	name string,
+	details map[string]string,
+) (client.Object, error) {
+	var obj client.Object
+	var apply func() bool
+	switch kind {
+	case KindConfigMap:
+		cm := &corev1.ConfigMap{}
+		obj = cm
+		apply = func() bool {
+			if maps.Equal(cm.Data, details) {
+				return false
+			}
+			cm.Data = details
+			return true
+		}
+	default:
+		secret := &corev1.Secret{}
+		obj = secret
+		apply = func() bool {
+			data := make(map[string][]byte, len(details))
+			for k, v := range details {
+				data[k] = []byte(v)
+			}
+			if secret.Type == corev1.SecretTypeOpaque &&
+				maps.EqualFunc(secret.Data, data, func(a, b []byte) bool { return string(a) == string(b) }) {
+				return false
+			}
+			secret.Type = corev1.SecretTypeOpaque
+			secret.Data = data
+			return true
+		}
+	}
+
+	key := types.NamespacedName{Namespace: ko.Namespace, Name: name}
+	err := r.apiReader.Get(ctx, key, obj)
+	if apierrors.IsNotFound(err) {
+		obj.SetNamespace(key.Namespace)
+		obj.SetName(key.Name)
+		obj.SetLabels(map[string]string{LabelConnectionDetails: "true"})
+		if err := controllerutil.SetControllerReference(ko, obj, r.kc.Scheme()); err != nil {
+			return nil, err
+		}
+		apply()
+		return obj, r.kc.Create(ctx, obj)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !metav1.IsControlledBy(obj, ko) {
+		log.FromContext(ctx).Info("not writing connection details to an object the bucket does not own",
+			"kind", kind, "name", name)
+		r.recorder.Eventf(ko, nil, corev1.EventTypeWarning, reasonConflict, "WriteConnectionDetails",
+			"%s %s already exists and is not owned by the Bucket", kind, name)
+		return nil, nil
+	}
+	if apply() {
+		return obj, r.kc.Update(ctx, obj)
+	}
+	return obj, nil
+}
+
+// deleteStale deletes the Secrets and ConfigMaps holding the connection
+// details of the given Bucket, other than the current target if any.
+func (r *Reconciler) deleteStale(
+	ctx context.Context,
+	ko *svcapitypes.Bucket,
+	target client.Object,
+) error {
+	isTarget := func(kind string, name string) bool {
+		if target == nil || target.GetName() != name {
+			return false
+		}
+		_, isSecret := target.(*corev1.Secret)
+		return isSecret == (kind == KindSecret)
+	}
+	opts := []client.ListOption{
+		client.InNamespace(ko.Namespace),
+		client.MatchingLabels{LabelConnectionDetails: "true"},
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := r.apiReader.List(ctx, secrets, opts...); err != nil {
+		return err
+	}
+	var stale []client.Object
+	for i := range secrets.Items {
+		if !isTarget(KindSecret, secrets.Items[i].Name) {
+			stale = append(stale, &secrets.Items[i])
+		}
+	}
+	configMaps := &corev1.ConfigMapList{}
+	if err := r.apiReader.List(ctx, configMaps, opts...); err != nil {
+		return err
+	}
+	for i := range configMaps.Items {
+		if !isTarget(KindConfigMap, configMaps.Items[i].Name) {
+			stale = append(stale, &configMaps.Items[i])
+		}
+	}
+
+	for _, obj := range stale {
+		if !metav1.IsControlledBy(obj, ko) {
+			continue
+		}
+		if err := r.kc.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// kindOf returns the kind of the object connection details are written to.
+func kindOf(target *svcapitypes.ConnectionDetailsTarget) string {
+	if kind := aws.ToString(target.Kind); kind != "" {
+		return kind
+	}
+	return KindSecret
+}
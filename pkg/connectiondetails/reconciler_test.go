@@ -0,0 +1,128 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package connectiondetails
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+func newReconciler(t *testing.T, objs ...client.Object) (*Reconciler, client.Client, *events.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, svcapitypes.AddToScheme(scheme))
+	kc := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	recorder := events.NewFakeRecorder(10)
+	return NewReconciler(kc, kc, recorder), kc, recorder
+}
+
+func reconcile(t *testing.T, r *Reconciler, ko *svcapitypes.Bucket) {
+	_, err := r.Reconcile(context.Background(), ctrlrt.Request{
+		NamespacedName: client.ObjectKeyFromObject(ko),
+	})
+	require.NoError(t, err)
+}
+
+func Test_Reconcile(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	ko := newBucket("my-bucket", "", "")
+	ko.Spec.WriteConnectionDetailsTo = &svcapitypes.ConnectionDetailsTarget{Name: aws.String("my-bucket-conn")}
+	r, kc, _ := newReconciler(t, ko)
+	key := types.NamespacedName{Namespace: "default", Name: "my-bucket-conn"}
+
+	// Nothing is written until the bucket is created
+	reconcile(t, r, ko)
+	assert.True(apierrors.IsNotFound(kc.Get(ctx, key, &corev1.Secret{})))
+
+	require.NoError(kc.Get(ctx, client.ObjectKeyFromObject(ko), ko))
+	region := ackv1alpha1.AWSRegion("us-west-2")
+	ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{
+		ARN:    (*ackv1alpha1.AWSResourceName)(aws.String("arn:aws:s3:::my-bucket")),
+		Region: &region,
+	}
+	require.NoError(kc.Update(ctx, ko))
+	reconcile(t, r, ko)
+	secret := &corev1.Secret{}
+	require.NoError(kc.Get(ctx, key, secret))
+	assert.True(metav1.IsControlledBy(secret, ko))
+	assert.Equal("true", secret.Labels[LabelConnectionDetails])
+	assert.Equal("my-bucket", string(secret.Data[KeyBucketName]))
+	assert.Equal("https://s3.us-west-2.amazonaws.com", string(secret.Data[KeyEndpointURL]))
+	assert.NotContains(secret.Data, KeyWebsiteURL)
+
+	// The details are kept up to date
+	require.NoError(kc.Get(ctx, client.ObjectKeyFromObject(ko), ko))
+	ko.Spec.Website = &svcapitypes.WebsiteConfiguration{}
+	require.NoError(kc.Update(ctx, ko))
+	reconcile(t, r, ko)
+	require.NoError(kc.Get(ctx, key, secret))
+	assert.Equal("http://my-bucket.s3-website-us-west-2.amazonaws.com", string(secret.Data[KeyWebsiteURL]))
+
+	// Switching to a ConfigMap deletes the Secret
+	require.NoError(kc.Get(ctx, client.ObjectKeyFromObject(ko), ko))
+	ko.Spec.WriteConnectionDetailsTo.Kind = aws.String(KindConfigMap)
+	require.NoError(kc.Update(ctx, ko))
+	reconcile(t, r, ko)
+	cm := &corev1.ConfigMap{}
+	require.NoError(kc.Get(ctx, key, cm))
+	assert.Equal("arn:aws:s3:::my-bucket", cm.Data[KeyARN])
+	assert.True(apierrors.IsNotFound(kc.Get(ctx, key, &corev1.Secret{})))
+
+	// Removing the field deletes the ConfigMap
+	require.NoError(kc.Get(ctx, client.ObjectKeyFromObject(ko), ko))
+	ko.Spec.WriteConnectionDetailsTo = nil
+	require.NoError(kc.Update(ctx, ko))
+	reconcile(t, r, ko)
+	assert.True(apierrors.IsNotFound(kc.Get(ctx, key, &corev1.ConfigMap{})))
+}
+
+func Test_Reconcile_Conflict(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	ko := newBucket("my-bucket", "arn:aws:s3:::my-bucket", "us-west-2")
+	ko.Spec.WriteConnectionDetailsTo = &svcapitypes.ConnectionDetailsTarget{Name: aws.String("existing")}
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}
+	r, kc, recorder := newReconciler(t, ko, existing)
+
+	reconcile(t, r, ko)
+	require.NoError(kc.Get(ctx, client.ObjectKeyFromObject(existing), existing))
+	assert.Equal(map[string][]byte{"password": []byte("hunter2")}, existing.Data)
+	require.Len(recorder.Events, 1)
+	assert.Contains(<-recorder.Events, reasonConflict)
+}
@@ -0,0 +1,126 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package iampolicy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PolicyVersion is the current policy language version, used in the
+// policies the controller renders.
+const PolicyVersion = "2012-10-17"
+
+// BucketAccessSidPrefix starts the Sid of the statements granting the access
+// of a BucketAccess resource in the policy of a bucket, which the Bucket
+// resource leaves alone.
+const BucketAccessSidPrefix = "ACKBucketAccess-"
+
+// AccessLevel is the level of access to a bucket granted by
+// BucketAccessStatements.
+type AccessLevel string
+
+const (
+	// AccessLevelRead grants listing the bucket and reading its objects.
+	AccessLevelRead AccessLevel = "read"
+	// AccessLevelWrite additionally grants writing and deleting objects.
+	AccessLevelWrite AccessLevel = "write"
+	// AccessLevelAdmin grants every S3 action on the bucket and its objects.
+	AccessLevelAdmin AccessLevel = "admin"
+)
+
+var (
+	readBucketActions  = []string{"s3:GetBucketLocation", "s3:ListBucket", "s3:ListBucketVersions"}
+	readObjectActions  = []string{"s3:GetObject", "s3:GetObjectTagging", "s3:GetObjectVersion"}
+	writeObjectActions = []string{
+		"s3:AbortMultipartUpload", "s3:DeleteObject", "s3:DeleteObjectTagging",
+		"s3:DeleteObjectVersion", "s3:ListMultipartUploadParts", "s3:PutObject",
+		"s3:PutObjectTagging",
+	}
+)
+
+// ParseAccessLevel returns the access level named by level, which defaults
+// to AccessLevelWrite when empty.
+func ParseAccessLevel(level string) (AccessLevel, error) {
+	switch AccessLevel(strings.ToLower(level)) {
+	case "", AccessLevelWrite:
+		return AccessLevelWrite, nil
+	case AccessLevelRead:
+		return AccessLevelRead, nil
+	case AccessLevelAdmin:
+		return AccessLevelAdmin, nil
+	}
+	return "", fmt.Errorf(
+		"unknown access level %q, expected one of %q, %q or %q",
+		level, AccessLevelRead, AccessLevelWrite, AccessLevelAdmin,
+	)
+}
+
+// BucketAccessStatements returns the statements granting the given level of
+// access to the bucket with the given ARN, restricted to the objects whose
+// key starts with prefix if it is not empty. The statements have no
+// principal, so that they can be used in identity policies as well as, once
+// given one, in bucket policies.
+func BucketAccessStatements(
+	bucketARN string,
+	level AccessLevel,
+	prefix string,
+) []*Statement {
+	objectsARN := bucketARN + "/" + prefix + "*"
+	if level == AccessLevelAdmin && prefix == "" {
+		return []*Statement{{
+			Effect:   "Allow",
+			Action:   []string{"s3:*"},
+			Resource: []string{bucketARN, objectsARN},
+		}}
+	}
+
+	objectActions := append([]string{}, readObjectActions...)
+	switch level {
+	case AccessLevelWrite:
+		objectActions = append(append([]string{}, objectActions...), writeObjectActions...)
+	case AccessLevelAdmin:
+		// Bucket-level actions cannot be restricted to a prefix, so the
+		// administrators of a prefix only get to list it
+		objectActions = []string{"s3:*"}
+	}
+	listBucket := &Statement{
+		Effect:   "Allow",
+		Action:   append([]string{}, readBucketActions...),
+		Resource: []string{bucketARN},
+	}
+	if prefix != "" {
+		listBucket.Condition = map[string]map[string][]string{
+			"StringLike": {"s3:prefix": {prefix + "*"}},
+		}
+	}
+	return []*Statement{
+		listBucket,
+		{
+			Effect:   "Allow",
+			Action:   objectActions,
+			Resource: []string{objectsARN},
+		},
+	}
+}
+
+// BucketAccessPolicy returns the identity policy granting the given level of
+// access to the bucket, see BucketAccessStatements.
+func BucketAccessPolicy(bucketARN string, level AccessLevel, prefix string) (string, error) {
+	doc := &Document{
+		Version:   PolicyVersion,
+		Statement: BucketAccessStatements(bucketARN, level, prefix),
+	}
+	return doc.Render()
+}
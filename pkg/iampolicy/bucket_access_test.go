@@ -0,0 +1,72 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package iampolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketAccessPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		level  string
+		prefix string
+		want   string
+	}{
+		{
+			name:  "admin",
+			level: "admin",
+			want: `{"Version":"2012-10-17","Statement":[
+				{"Effect":"Allow","Action":["s3:*"],"Resource":["arn:aws:s3:::my-bucket","arn:aws:s3:::my-bucket/*"]}
+			]}`,
+		},
+		{
+			name:   "admin of a prefix",
+			level:  "Admin",
+			prefix: "team-a/",
+			want: `{"Version":"2012-10-17","Statement":[
+				{"Effect":"Allow","Action":["s3:GetBucketLocation","s3:ListBucket","s3:ListBucketVersions"],
+				 "Resource":["arn:aws:s3:::my-bucket"],"Condition":{"StringLike":{"s3:prefix":["team-a/*"]}}},
+				{"Effect":"Allow","Action":["s3:*"],"Resource":["arn:aws:s3:::my-bucket/team-a/*"]}
+			]}`,
+		},
+		{
+			name:  "write by default",
+			level: "",
+			want: `{"Version":"2012-10-17","Statement":[
+				{"Effect":"Allow","Action":["s3:GetBucketLocation","s3:ListBucket","s3:ListBucketVersions"],
+				 "Resource":["arn:aws:s3:::my-bucket"]},
+				{"Effect":"Allow","Action":["s3:GetObject","s3:GetObjectTagging","s3:GetObjectVersion",
+				 "s3:AbortMultipartUpload","s3:DeleteObject","s3:DeleteObjectTagging","s3:DeleteObjectVersion",
+				 "s3:ListMultipartUploadParts","s3:PutObject","s3:PutObjectTagging"],
+				 "Resource":["arn:aws:s3:::my-bucket/*"]}
+			]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, err := ParseAccessLevel(tt.level)
+			require.NoError(t, err)
+			policy, err := BucketAccessPolicy("arn:aws:s3:::my-bucket", level, tt.prefix)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, policy)
+		})
+	}
+
+	_, err := ParseAccessLevel("owner")
+	assert.Error(t, err)
+}
@@ -0,0 +1,450 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package iampolicy compares the IAM resource policies S3 attaches to buckets
+// and access points by the permissions they grant rather than by their
+// serialization, and renders the policies granting access to buckets.
+package iampolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultVersion is the policy language version IAM assumes when a policy
+// document does not specify one.
+const DefaultVersion = "2008-10-17"
+
+// accountRootARNRegex matches the ARN S3 rewrites a bare account ID principal
+// into.
+var accountRootARNRegex = regexp.MustCompile(`^arn:[a-z-]+:iam::(\d{12}):root$`)
+
+// Document models a resource policy. Elements that IAM accepts either as
+// a single value or as an array are always held as arrays, and the wildcard
+// principal is held as {"AWS": ["*"]}.
+type Document struct {
+	Version   string       `json:"Version,omitempty"`
+	ID        string       `json:"Id,omitempty"`
+	Statement []*Statement `json:"Statement"`
+}
+
+// Statement models a policy statement. Principals map a principal type
+// to its values, and conditions map an operator to condition keys and their
+// values, all as strings.
+type Statement struct {
+	Sid          string                         `json:"Sid,omitempty"`
+	Effect       string                         `json:"Effect"`
+	Principal    map[string][]string            `json:"Principal,omitempty"`
+	NotPrincipal map[string][]string            `json:"NotPrincipal,omitempty"`
+	Action       []string                       `json:"Action,omitempty"`
+	NotAction    []string                       `json:"NotAction,omitempty"`
+	Resource     []string                       `json:"Resource,omitempty"`
+	NotResource  []string                       `json:"NotResource,omitempty"`
+	Condition    map[string]map[string][]string `json:"Condition,omitempty"`
+}
+
+// Parse parses a resource policy. Unknown elements are rejected rather
+// than ignored, so that documents differing only in elements the model does
+// not know about are never considered equal.
+func Parse(policy string) (*Document, error) {
+	var raw struct {
+		Version   string          `json:"Version"`
+		ID        string          `json:"Id"`
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := decodeStrict([]byte(policy), &raw); err != nil {
+		return nil, err
+	}
+
+	var rawStatements []json.RawMessage
+	if bytes.HasPrefix(bytes.TrimSpace(raw.Statement), []byte("[")) {
+		if err := json.Unmarshal(raw.Statement, &rawStatements); err != nil {
+			return nil, err
+		}
+	} else {
+		rawStatements = []json.RawMessage{raw.Statement}
+	}
+
+	doc := &Document{
+		Version: raw.Version,
+		ID:      raw.ID,
+	}
+	for _, rawStatement := range rawStatements {
+		statement, err := ParseStatement(rawStatement)
+		if err != nil {
+			return nil, err
+		}
+		doc.Statement = append(doc.Statement, statement)
+	}
+	return doc, nil
+}
+
+// ParseStatement parses a single policy statement.
+func ParseStatement(data []byte) (*Statement, error) {
+	var raw struct {
+		Sid          string          `json:"Sid"`
+		Effect       string          `json:"Effect"`
+		Principal    json.RawMessage `json:"Principal"`
+		NotPrincipal json.RawMessage `json:"NotPrincipal"`
+		Action       json.RawMessage `json:"Action"`
+		NotAction    json.RawMessage `json:"NotAction"`
+		Resource     json.RawMessage `json:"Resource"`
+		NotResource  json.RawMessage `json:"NotResource"`
+		Condition    json.RawMessage `json:"Condition"`
+	}
+	if err := decodeStrict(data, &raw); err != nil {
+		return nil, err
+	}
+
+	var err error
+	statement := &Statement{
+		Sid:    raw.Sid,
+		Effect: raw.Effect,
+	}
+	if statement.Principal, err = parsePrincipal(raw.Principal); err != nil {
+		return nil, err
+	}
+	if statement.NotPrincipal, err = parsePrincipal(raw.NotPrincipal); err != nil {
+		return nil, err
+	}
+	if statement.Action, err = parseValues(raw.Action); err != nil {
+		return nil, err
+	}
+	if statement.NotAction, err = parseValues(raw.NotAction); err != nil {
+		return nil, err
+	}
+	if statement.Resource, err = parseValues(raw.Resource); err != nil {
+		return nil, err
+	}
+	if statement.NotResource, err = parseValues(raw.NotResource); err != nil {
+		return nil, err
+	}
+	if statement.Condition, err = parseCondition(raw.Condition); err != nil {
+		return nil, err
+	}
+	return statement, nil
+}
+
+// parsePrincipal parses a Principal or NotPrincipal element. The
+// wildcard principal is equivalent to {"AWS": "*"}.
+func parsePrincipal(data json.RawMessage) (map[string][]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var wildcard string
+	if err := json.Unmarshal(data, &wildcard); err == nil {
+		if wildcard != "*" {
+			return nil, fmt.Errorf("invalid principal %q", wildcard)
+		}
+		return map[string][]string{"AWS": {"*"}}, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	principal := make(map[string][]string, len(raw))
+	for principalType, rawValues := range raw {
+		values, err := parseValues(rawValues)
+		if err != nil {
+			return nil, err
+		}
+		principal[principalType] = values
+	}
+	return principal, nil
+}
+
+// parseCondition parses a Condition element.
+func parseCondition(data json.RawMessage) (map[string]map[string][]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var raw map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	condition := make(map[string]map[string][]string, len(raw))
+	for operator, rawKeys := range raw {
+		keys := make(map[string][]string, len(rawKeys))
+		for key, rawValues := range rawKeys {
+			values, err := parseValues(rawValues)
+			if err != nil {
+				return nil, err
+			}
+			keys[key] = values
+		}
+		condition[operator] = keys
+	}
+	return condition, nil
+}
+
+// parseValues parses an element that may either be a single value or
+// an array of values into an array of strings. Booleans and numbers are kept
+// in their JSON representation, which is also how S3 returns them.
+func parseValues(data json.RawMessage) ([]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var rawValues []json.RawMessage
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		if err := json.Unmarshal(data, &rawValues); err != nil {
+			return nil, err
+		}
+	} else {
+		rawValues = []json.RawMessage{data}
+	}
+
+	values := make([]string, 0, len(rawValues))
+	for _, rawValue := range rawValues {
+		var value string
+		if err := json.Unmarshal(rawValue, &value); err != nil {
+			var scalar interface{}
+			if err := json.Unmarshal(rawValue, &scalar); err != nil {
+				return nil, err
+			}
+			switch scalar.(type) {
+			case bool, float64:
+				value = string(bytes.TrimSpace(rawValue))
+			default:
+				return nil, fmt.Errorf("invalid policy value %s", rawValue)
+			}
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// decodeStrict decodes data into v, failing on unknown fields.
+func decodeStrict(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// Canonicalize reduces every element of the document that IAM accepts in
+// several equivalent forms to a single one, so that two documents granting
+// the same permissions serialize identically. Besides ordering, this undoes
+// the rewrites S3 applies to a policy before returning it from
+// GetBucketPolicy or GetAccessPointPolicy.
+func (d *Document) Canonicalize() error {
+	if d.Version == "" {
+		d.Version = DefaultVersion
+	}
+	keys := map[*Statement]string{}
+	for _, statement := range d.Statement {
+		// Action names and condition keys are case insensitive
+		statement.Action = canonicalValues(statement.Action, strings.ToLower)
+		statement.NotAction = canonicalValues(statement.NotAction, strings.ToLower)
+		statement.Resource = canonicalValues(statement.Resource, nil)
+		statement.NotResource = canonicalValues(statement.NotResource, nil)
+		statement.Principal = canonicalPrincipal(statement.Principal)
+		statement.NotPrincipal = canonicalPrincipal(statement.NotPrincipal)
+		if statement.Condition != nil {
+			condition := make(map[string]map[string][]string, len(statement.Condition))
+			for operator, values := range statement.Condition {
+				condition[operator] = make(map[string][]string, len(values))
+				for key, value := range values {
+					condition[operator][strings.ToLower(key)] = canonicalValues(value, nil)
+				}
+			}
+			statement.Condition = condition
+		}
+
+		key, err := json.Marshal(statement)
+		if err != nil {
+			return err
+		}
+		keys[statement] = string(key)
+	}
+
+	// Statements are evaluated as a set, so order them by their canonical
+	// serialization.
+	slices.SortFunc(d.Statement, func(a, b *Statement) int {
+		return strings.Compare(keys[a], keys[b])
+	})
+	d.Statement = slices.CompactFunc(d.Statement, func(a, b *Statement) bool {
+		return keys[a] == keys[b]
+	})
+	return nil
+}
+
+// canonicalPrincipal reduces account root ARNs to the bare account ID
+// S3 rewrites them from, and sorts the values of each principal type.
+func canonicalPrincipal(principal map[string][]string) map[string][]string {
+	if principal == nil {
+		return nil
+	}
+	canonical := make(map[string][]string, len(principal))
+	for principalType, values := range principal {
+		var normalize func(string) string
+		if principalType == "AWS" {
+			normalize = func(value string) string {
+				if match := accountRootARNRegex.FindStringSubmatch(value); match != nil {
+					return match[1]
+				}
+				return value
+			}
+		}
+		canonical[principalType] = canonicalValues(values, normalize)
+	}
+	return canonical
+}
+
+// canonicalValues returns the distinct values, sorted, after applying
+// normalize to each of them if it is not nil.
+func canonicalValues(values []string, normalize func(string) string) []string {
+	if values == nil {
+		return nil
+	}
+	canonical := make([]string, 0, len(values))
+	for _, value := range values {
+		if normalize != nil {
+			value = normalize(value)
+		}
+		canonical = append(canonical, value)
+	}
+	slices.Sort(canonical)
+	return slices.Compact(canonical)
+}
+
+// Render returns the JSON serialization of the document.
+func (d *Document) Render() (string, error) {
+	rendered, err := json.Marshal(d)
+	if err != nil {
+		return "", err
+	}
+	return string(rendered), nil
+}
+
+// GrantsPublicAccess returns true if the document has an unconditional Allow
+// statement whose principal is everyone.
+func (d *Document) GrantsPublicAccess() bool {
+	for _, statement := range d.Statement {
+		if statement.Effect == "Allow" && len(statement.Condition) == 0 &&
+			slices.Contains(statement.Principal["AWS"], "*") {
+			return true
+		}
+	}
+	return false
+}
+
+// Canonical returns the canonical serialization of a resource policy.
+func Canonical(policy string) (string, error) {
+	doc, err := Parse(policy)
+	if err != nil {
+		return "", err
+	}
+	if err := doc.Canonicalize(); err != nil {
+		return "", err
+	}
+	return doc.Render()
+}
+
+// Equal returns true if both resource policies grant the same permissions,
+// regardless of formatting, element order, the equivalent forms IAM accepts
+// for the same element and the rewrites S3 applies to a policy before
+// returning it. Policies that cannot be parsed are only equal to themselves.
+func Equal(a, b string) bool {
+	if a == b {
+		return true
+	}
+	canonicalA, err := Canonical(a)
+	if err != nil {
+		return false
+	}
+	canonicalB, err := Canonical(b)
+	if err != nil {
+		return false
+	}
+	return canonicalA == canonicalB
+}
+
+// GrantsPublicAccess returns true if the policy has an unconditional Allow
+// statement whose principal is everyone. Policies that cannot be parsed are
+// left for S3 to reject.
+func GrantsPublicAccess(policy string) bool {
+	doc, err := Parse(policy)
+	if err != nil {
+		return false
+	}
+	return doc.GrantsPublicAccess()
+}
+
+// Resolve returns a copy of the statement with its placeholders resolved by
+// placeholders.
+func (s *Statement) Resolve(placeholders *strings.Replacer) *Statement {
+	values := func(in []string) []string {
+		if in == nil {
+			return nil
+		}
+		out := make([]string, 0, len(in))
+		for _, value := range in {
+			out = append(out, placeholders.Replace(value))
+		}
+		return out
+	}
+	principal := func(in map[string][]string) map[string][]string {
+		if in == nil {
+			return nil
+		}
+		out := make(map[string][]string, len(in))
+		for principalType, v := range in {
+			out[principalType] = values(v)
+		}
+		return out
+	}
+
+	out := &Statement{
+		Sid:          s.Sid,
+		Effect:       s.Effect,
+		Principal:    principal(s.Principal),
+		NotPrincipal: principal(s.NotPrincipal),
+		Action:       values(s.Action),
+		NotAction:    values(s.NotAction),
+		Resource:     values(s.Resource),
+		NotResource:  values(s.NotResource),
+	}
+	if s.Condition != nil {
+		out.Condition = make(map[string]map[string][]string, len(s.Condition))
+		for operator, keys := range s.Condition {
+			out.Condition[operator] = principal(keys)
+		}
+	}
+	return out
+}
+
+// StatementsEqual returns true if both statements grant the same
+// permissions.
+func StatementsEqual(a, b *Statement) bool {
+	canonical := func(s *Statement) (string, error) {
+		// Canonicalize modifies the statements, so work on a copy.
+		doc := &Document{Statement: []*Statement{s.Resolve(strings.NewReplacer())}}
+		if err := doc.Canonicalize(); err != nil {
+			return "", err
+		}
+		return doc.Render()
+	}
+	canonicalA, err := canonical(a)
+	if err != nil {
+		return false
+	}
+	canonicalB, err := canonical(b)
+	if err != nil {
+		return false
+	}
+	return canonicalA == canonicalB
+}
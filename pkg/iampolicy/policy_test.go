@@ -0,0 +1,149 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package iampolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const publicReadPolicy = `{
+	"Version": "2012-10-17",
+	"Statement": [{
+		"Effect": "Allow",
+		"Principal": "*",
+		"Action": "s3:GetObject",
+		"Resource": "arn:aws:s3:::my-bucket/*"
+	}]
+}`
+
+const accountPolicy = `{
+	"Version": "2012-10-17",
+	"Statement": {
+		"Effect": "Allow",
+		"Principal": {"AWS": "arn:aws:iam::111122223333:root"},
+		"Action": "s3:GetObject",
+		"Resource": "arn:aws:s3:::my-bucket/*"
+	}
+}`
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		equal bool
+	}{
+		{
+			name:  "whitespace and key order",
+			a:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::my-bucket/*"}]}`,
+			b:     publicReadPolicy,
+			equal: true,
+		},
+		{
+			name:  "single statement and single element arrays",
+			a:     `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::my-bucket/*"]}}`,
+			b:     publicReadPolicy,
+			equal: true,
+		},
+		{
+			name:  "action order, case and duplicates",
+			a:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":["s3:GetObject","s3:ListBucket","s3:getobject"],"Resource":"*"}]}`,
+			b:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":["s3:ListBucket","s3:GetObject"],"Resource":"*"}]}`,
+			equal: true,
+		},
+		{
+			name: "statement order",
+			a: `{"Version":"2012-10-17","Statement":[
+				{"Sid":"Read","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"},
+				{"Sid":"Write","Effect":"Allow","Principal":{"AWS":"111122223333"},"Action":"s3:PutObject","Resource":"*"}]}`,
+			b: `{"Version":"2012-10-17","Statement":[
+				{"Sid":"Write","Effect":"Allow","Principal":{"AWS":"111122223333"},"Action":"s3:PutObject","Resource":"*"},
+				{"Sid":"Read","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`,
+			equal: true,
+		},
+		{
+			name:  "account principal rewritten by S3",
+			a:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["111122223333","arn:aws:iam::444455556666:role/reader"]},"Action":"s3:GetObject","Resource":"*"}]}`,
+			b:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::444455556666:role/reader","arn:aws:iam::111122223333:root"]},"Action":"s3:GetObject","Resource":"*"}]}`,
+			equal: true,
+		},
+		{
+			name:  "condition values and key case",
+			a:     `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:*","Resource":"*","Condition":{"Bool":{"aws:SecureTransport":false}}}]}`,
+			b:     `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:*","Resource":"*","Condition":{"Bool":{"aws:securetransport":["false"]}}}]}`,
+			equal: true,
+		},
+		{
+			name:  "default version",
+			a:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`,
+			b:     `{"Version":"2008-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`,
+			equal: true,
+		},
+		{
+			name:  "different effect",
+			a:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`,
+			b:     `{"Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`,
+			equal: false,
+		},
+		{
+			name:  "resource case is significant",
+			a:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::my-bucket/A/*"}]}`,
+			b:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::my-bucket/a/*"}]}`,
+			equal: false,
+		},
+		{
+			name:  "different sid",
+			a:     `{"Statement":[{"Sid":"A","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`,
+			b:     `{"Statement":[{"Sid":"B","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`,
+			equal: false,
+		},
+		{
+			name:  "different condition operator",
+			a:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"StringEquals":{"aws:SourceVpce":"vpce-1"}}}]}`,
+			b:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"StringLike":{"aws:SourceVpce":"vpce-1"}}}]}`,
+			equal: false,
+		},
+		{
+			name:  "unknown elements are never equal",
+			a:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Extra":1}]}`,
+			b:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Extra":2}]}`,
+			equal: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.equal, Equal(tt.a, tt.b))
+			assert.Equal(t, tt.equal, Equal(tt.b, tt.a))
+		})
+	}
+}
+
+func TestGrantsPublicAccess(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(GrantsPublicAccess(publicReadPolicy))
+	assert.True(GrantsPublicAccess(
+		`{"Statement": {"Effect": "Allow", "Principal": {"AWS": ["arn:aws:iam::111122223333:root", "*"]}}}`,
+	))
+	assert.False(GrantsPublicAccess(accountPolicy))
+	assert.False(GrantsPublicAccess(
+		`{"Statement": [{"Effect": "Deny", "Principal": "*"}]}`,
+	))
+	assert.False(GrantsPublicAccess(
+		`{"Statement": [{"Effect": "Allow", "Principal": "*", "Condition": {"IpAddress": {"aws:SourceIp": "192.0.2.0/24"}}}]}`,
+	))
+	assert.False(GrantsPublicAccess("not json"))
+}
@@ -0,0 +1,287 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package access_point
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3control"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3control/types"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	smithy "github.com/aws/smithy-go"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
+)
+
+// directoryAccessPointSuffix ends the name of every access point attached to
+// a directory bucket.
+const directoryAccessPointSuffix = "--xa-s3"
+
+// isDirectoryAccessPointName returns true if the access point name is the
+// name of an access point attached to a directory bucket.
+func isDirectoryAccessPointName(name string) bool {
+	return strings.HasSuffix(name, directoryAccessPointSuffix)
+}
+
+// validateAccessPointSpec checks the fields that S3 only supports for access
+// points attached to directory buckets.
+func validateAccessPointSpec(ko *svcapitypes.AccessPoint) error {
+	if ko.Spec.Scope != nil && !isDirectoryAccessPointName(aws.ToString(ko.Spec.Name)) {
+		return ackerr.NewTerminalError(fmt.Errorf(
+			"spec.scope is only supported for access points of directory buckets, whose name ends with %q",
+			directoryAccessPointSuffix,
+		))
+	}
+	return nil
+}
+
+// partition returns the AWS partition of the access points managed by rm. It
+// prefers the partition the controller resolved from its own identity and
+// falls back to the partition that contains rm's region, so that ARNs are
+// correct in the aws-cn and aws-us-gov partitions even when the former is
+// unset.
+func (rm *resourceManager) partition() string {
+	if rm.awsPartition != "" {
+		return string(rm.awsPartition)
+	}
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), string(rm.awsRegion)); ok {
+		return p.ID()
+	}
+	return endpoints.AwsPartitionID
+}
+
+// accessPointARN returns the ARN of the access point with the given name,
+// used when CreateAccessPoint does not return it.
+func (rm *resourceManager) accessPointARN(name string) string {
+	service := "s3"
+	if isDirectoryAccessPointName(name) {
+		service = "s3express"
+	}
+	return fmt.Sprintf(
+		"arn:%s:%s:%s:%s:accesspoint/%s",
+		rm.partition(), service, rm.awsRegion, rm.awsAccountID, name,
+	)
+}
+
+// newScope returns the S3 Control scope of an access point.
+func newScope(in *svcapitypes.AccessPointScope) *svcsdktypes.Scope {
+	res := &svcsdktypes.Scope{}
+	for _, permission := range in.Permissions {
+		if permission != nil {
+			res.Permissions = append(res.Permissions, svcsdktypes.ScopePermission(*permission))
+		}
+	}
+	for _, prefix := range in.Prefixes {
+		if prefix != nil {
+			res.Prefixes = append(res.Prefixes, *prefix)
+		}
+	}
+	return res
+}
+
+// newResourceScope returns the scope of an access point as set in its spec,
+// or nil if the scope is empty.
+func newResourceScope(in *svcsdktypes.Scope) *svcapitypes.AccessPointScope {
+	if in == nil || (len(in.Permissions) == 0 && len(in.Prefixes) == 0) {
+		return nil
+	}
+	res := &svcapitypes.AccessPointScope{}
+	for _, permission := range in.Permissions {
+		res.Permissions = append(res.Permissions, aws.String(string(permission)))
+	}
+	if len(in.Prefixes) > 0 {
+		res.Prefixes = aws.StringSlice(in.Prefixes)
+	}
+	return res
+}
+
+// isNotFound returns true if the error is an API error with one of the given
+// codes.
+func isNotFound(err error, codes ...string) bool {
+	var awsErr smithy.APIError
+	return errors.As(err, &awsErr) && slices.Contains(codes, awsErr.ErrorCode())
+}
+
+// addPolicyAndScopeToSpec reads the policy and, for access points of
+// directory buckets, the scope of the access point into its spec.
+func (rm *resourceManager) addPolicyAndScopeToSpec(
+	ctx context.Context,
+	ko *svcapitypes.AccessPoint,
+) error {
+	policy, err := rm.sdkapi.GetAccessPointPolicy(ctx, &svcsdk.GetAccessPointPolicyInput{
+		AccountId: aws.String(string(rm.awsAccountID)),
+		Name:      ko.Spec.Name,
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetAccessPointPolicy", err)
+	switch {
+	case isNotFound(err, "NoSuchAccessPointPolicy"):
+		ko.Spec.Policy = nil
+	case err != nil:
+		return err
+	default:
+		ko.Spec.Policy = policy.Policy
+	}
+
+	if !isDirectoryAccessPointName(aws.ToString(ko.Spec.Name)) {
+		ko.Spec.Scope = nil
+		return nil
+	}
+	scope, err := rm.sdkapi.GetAccessPointScope(ctx, &svcsdk.GetAccessPointScopeInput{
+		AccountId: aws.String(string(rm.awsAccountID)),
+		Name:      ko.Spec.Name,
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetAccessPointScope", err)
+	switch {
+	case isNotFound(err, "NoSuchAccessPointScope"):
+		ko.Spec.Scope = nil
+	case err != nil:
+		return err
+	default:
+		ko.Spec.Scope = newResourceScope(scope.Scope)
+	}
+	return nil
+}
+
+// putPolicy puts the policy of the spec of the access point.
+func (rm *resourceManager) putPolicy(
+	ctx context.Context,
+	ko *svcapitypes.AccessPoint,
+) error {
+	_, err := rm.sdkapi.PutAccessPointPolicy(ctx, &svcsdk.PutAccessPointPolicyInput{
+		AccountId: aws.String(string(rm.awsAccountID)),
+		Name:      ko.Spec.Name,
+		Policy:    ko.Spec.Policy,
+	})
+	rm.metrics.RecordAPICall("UPDATE", "PutAccessPointPolicy", err)
+	return err
+}
+
+// syncPolicy puts the policy of the desired access point, or deletes the
+// policy of the access point if the desired one has none.
+func (rm *resourceManager) syncPolicy(
+	ctx context.Context,
+	desired *resource,
+) error {
+	if aws.ToString(desired.ko.Spec.Policy) != "" {
+		return rm.putPolicy(ctx, desired.ko)
+	}
+	_, err := rm.sdkapi.DeleteAccessPointPolicy(ctx, &svcsdk.DeleteAccessPointPolicyInput{
+		AccountId: aws.String(string(rm.awsAccountID)),
+		Name:      desired.ko.Spec.Name,
+	})
+	rm.metrics.RecordAPICall("UPDATE", "DeleteAccessPointPolicy", err)
+	return err
+}
+
+// syncScope puts the scope of the desired access point, or deletes the scope
+// of the access point if the desired one has none.
+func (rm *resourceManager) syncScope(
+	ctx context.Context,
+	desired *resource,
+) error {
+	if desired.ko.Spec.Scope != nil {
+		_, err := rm.sdkapi.PutAccessPointScope(ctx, &svcsdk.PutAccessPointScopeInput{
+			AccountId: aws.String(string(rm.awsAccountID)),
+			Name:      desired.ko.Spec.Name,
+			Scope:     newScope(desired.ko.Spec.Scope),
+		})
+		rm.metrics.RecordAPICall("UPDATE", "PutAccessPointScope", err)
+		return err
+	}
+	_, err := rm.sdkapi.DeleteAccessPointScope(ctx, &svcsdk.DeleteAccessPointScopeInput{
+		AccountId: aws.String(string(rm.awsAccountID)),
+		Name:      desired.ko.Spec.Name,
+	})
+	rm.metrics.RecordAPICall("UPDATE", "DeleteAccessPointScope", err)
+	return err
+}
+
+// customUpdateAccessPoint updates the policy and scope of the access point,
+// the only properties S3 allows to change once it is created.
+func (rm *resourceManager) customUpdateAccessPoint(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.customUpdateAccessPoint")
+	defer func() {
+		exit(err)
+	}()
+	if err := validateAccessPointSpec(desired.ko); err != nil {
+		return nil, err
+	}
+
+	ko := desired.ko.DeepCopy()
+	rm.setStatusDefaults(ko)
+
+	if delta.DifferentAt("Spec.Policy") {
+		if err := rm.syncPolicy(ctx, desired); err != nil {
+			return nil, err
+		}
+	}
+	if delta.DifferentAt("Spec.Scope") {
+		if err := rm.syncScope(ctx, desired); err != nil {
+			return nil, err
+		}
+	}
+	return &resource{ko}, nil
+}
+
+// customPreCompare ignores the differences between the desired and latest
+// access points that are only in the form S3 returns them in.
+func customPreCompare(
+	a *resource,
+	b *resource,
+) {
+	// S3 rewrites parts of the policy before returning it from
+	// GetAccessPointPolicy, so only diff the policy when the permissions it
+	// grants differ.
+	if a.ko.Spec.Policy != nil && b.ko.Spec.Policy != nil &&
+		iampolicy.Equal(*a.ko.Spec.Policy, *b.ko.Spec.Policy) {
+		b.ko.Spec.Policy = a.ko.Spec.Policy
+	}
+	// An empty policy is no policy.
+	if aws.ToString(a.ko.Spec.Policy) == "" && b.ko.Spec.Policy == nil {
+		b.ko.Spec.Policy = a.ko.Spec.Policy
+	}
+
+	// The permissions and prefixes of a scope are sets.
+	if a.ko.Spec.Scope != nil && b.ko.Spec.Scope != nil &&
+		sameValues(a.ko.Spec.Scope.Permissions, b.ko.Spec.Scope.Permissions) &&
+		sameValues(a.ko.Spec.Scope.Prefixes, b.ko.Spec.Scope.Prefixes) {
+		b.ko.Spec.Scope = a.ko.Spec.Scope
+	}
+}
+
+// sameValues returns true if both lists hold the same values, regardless of
+// their order and duplicates.
+func sameValues(a, b []*string) bool {
+	canonical := func(in []*string) []string {
+		out := aws.ToStringSlice(in)
+		slices.Sort(out)
+		return slices.Compact(out)
+	}
+	return slices.Equal(canonical(a), canonical(b))
+}
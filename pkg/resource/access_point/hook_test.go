@@ -0,0 +1,350 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package access_point
+
+import (
+	"context"
+	"testing"
+
+	smithy "github.com/aws/smithy-go"
+	smithymiddleware "github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3control"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3control/types"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+const readPolicy = `{
+	"Version": "2012-10-17",
+	"Statement": [{
+		"Effect": "Allow",
+		"Principal": {"AWS": "arn:aws:iam::111122223333:role/team-a"},
+		"Action": ["s3:GetObject", "s3:ListBucket"],
+		"Resource": "arn:aws:s3:us-west-2:111122223333:accesspoint/team-a/object/*"
+	}]
+}`
+
+// opResult is a canned response for a single S3 Control operation. Exactly
+// one of output or err is used.
+type opResult struct {
+	output interface{}
+	err    error
+}
+
+// newMockedSDKClient builds a real *s3control.Client whose middleware stack
+// is short-circuited at the Finalize step, returning the canned result of
+// each operation and recording the name of the operations called in calls.
+// Operations without a result return an empty output.
+func newMockedSDKClient(
+	results map[string]opResult,
+	calls *[]string,
+) *svcsdk.Client {
+	defaults := map[string]opResult{
+		"CreateAccessPoint":       {output: &svcsdk.CreateAccessPointOutput{}},
+		"GetAccessPointPolicy":    {err: apiErr("NoSuchAccessPointPolicy")},
+		"GetAccessPointScope":     {output: &svcsdk.GetAccessPointScopeOutput{}},
+		"PutAccessPointPolicy":    {output: &svcsdk.PutAccessPointPolicyOutput{}},
+		"DeleteAccessPointPolicy": {output: &svcsdk.DeleteAccessPointPolicyOutput{}},
+		"PutAccessPointScope":     {output: &svcsdk.PutAccessPointScopeOutput{}},
+		"DeleteAccessPointScope":  {output: &svcsdk.DeleteAccessPointScopeOutput{}},
+	}
+
+	mockFinalize := smithymiddleware.FinalizeMiddlewareFunc(
+		"mockS3ControlFinalize",
+		func(
+			ctx context.Context,
+			in smithymiddleware.FinalizeInput,
+			_ smithymiddleware.FinalizeHandler,
+		) (smithymiddleware.FinalizeOutput, smithymiddleware.Metadata, error) {
+			opName := smithymiddleware.GetOperationName(ctx)
+			if calls != nil {
+				*calls = append(*calls, opName)
+			}
+			res, ok := results[opName]
+			if !ok {
+				res = defaults[opName]
+			}
+			return smithymiddleware.FinalizeOutput{Result: res.output}, smithymiddleware.Metadata{}, res.err
+		},
+	)
+
+	return svcsdk.New(svcsdk.Options{
+		Region: "us-west-2",
+		APIOptions: []func(*smithymiddleware.Stack) error{
+			func(stack *smithymiddleware.Stack) error {
+				return stack.Finalize.Add(mockFinalize, smithymiddleware.Before)
+			},
+		},
+	})
+}
+
+func apiErr(code string) error {
+	return &smithy.GenericAPIError{Code: code, Message: code}
+}
+
+func newTestResourceManager(sdkapi *svcsdk.Client) *resourceManager {
+	return &resourceManager{
+		sdkapi:       sdkapi,
+		metrics:      ackmetrics.NewMetrics("s3"),
+		awsAccountID: ackv1alpha1.AWSAccountID("111122223333"),
+		awsRegion:    ackv1alpha1.AWSRegion("us-west-2"),
+		awsPartition: ackv1alpha1.AWSPartition("aws"),
+	}
+}
+
+func newAccessPointResource(name string) *resource {
+	return &resource{&svcapitypes.AccessPoint{
+		Spec: svcapitypes.AccessPointSpec{
+			Bucket: aws.String("my-bucket"),
+			Name:   aws.String(name),
+		},
+	}}
+}
+
+// Test_sdkFind_NotFound verifies that a missing access point is reported as
+// not found.
+func Test_sdkFind_NotFound(t *testing.T) {
+	rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+		"GetAccessPoint": {err: apiErr("NoSuchAccessPoint")},
+	}, nil))
+
+	_, err := rm.sdkFind(context.Background(), newAccessPointResource("team-a"))
+	assert.Equal(t, ackerr.NotFound, err)
+}
+
+// Test_sdkFind verifies that the access point, its policy and, for access
+// points of directory buckets only, its scope are read into the resource.
+func Test_sdkFind(t *testing.T) {
+	getAccessPoint := opResult{output: &svcsdk.GetAccessPointOutput{
+		AccessPointArn:  aws.String("arn:aws:s3:us-west-2:111122223333:accesspoint/team-a"),
+		Alias:           aws.String("team-a-abcdefghijklmnopqrstuvwxyz-s3alias"),
+		Bucket:          aws.String("my-bucket"),
+		BucketAccountId: aws.String("111122223333"),
+		Endpoints:       map[string]string{"ipv4": "team-a-111122223333.s3-accesspoint.us-west-2.amazonaws.com"},
+		Name:            aws.String("team-a"),
+		NetworkOrigin:   svcsdktypes.NetworkOriginVpc,
+		PublicAccessBlockConfiguration: &svcsdktypes.PublicAccessBlockConfiguration{
+			BlockPublicAcls:       aws.Bool(true),
+			BlockPublicPolicy:     aws.Bool(true),
+			IgnorePublicAcls:      aws.Bool(true),
+			RestrictPublicBuckets: aws.Bool(true),
+		},
+		VpcConfiguration: &svcsdktypes.VpcConfiguration{VpcId: aws.String("vpc-1")},
+	}}
+
+	t.Run("general purpose bucket", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		var calls []string
+		rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+			"GetAccessPoint":       getAccessPoint,
+			"GetAccessPointPolicy": {output: &svcsdk.GetAccessPointPolicyOutput{Policy: aws.String(readPolicy)}},
+		}, &calls))
+
+		latest, err := rm.sdkFind(context.Background(), newAccessPointResource("team-a"))
+		require.NoError(err)
+		assert.Equal([]string{"GetAccessPoint", "GetAccessPointPolicy"}, calls)
+
+		ko := latest.ko
+		assert.Equal("arn:aws:s3:us-west-2:111122223333:accesspoint/team-a", string(*ko.Status.ACKResourceMetadata.ARN))
+		assert.Equal("team-a-abcdefghijklmnopqrstuvwxyz-s3alias", *ko.Status.Alias)
+		assert.Equal("VPC", *ko.Status.NetworkOrigin)
+		assert.Contains(ko.Status.Endpoints, "ipv4")
+		// The account of the access point is left out of the spec.
+		assert.Nil(ko.Spec.BucketAccountID)
+		assert.Equal("vpc-1", *ko.Spec.VPCConfiguration.VPCID)
+		assert.True(*ko.Spec.PublicAccessBlockConfiguration.BlockPublicPolicy)
+		assert.Equal(readPolicy, *ko.Spec.Policy)
+		assert.Nil(ko.Spec.Scope)
+	})
+
+	t.Run("directory bucket", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		getDirectoryAccessPoint := *getAccessPoint.output.(*svcsdk.GetAccessPointOutput)
+		getDirectoryAccessPoint.Name = aws.String("team-a--usw2-az1--xa-s3")
+		rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+			"GetAccessPoint": {output: &getDirectoryAccessPoint},
+			"GetAccessPointScope": {output: &svcsdk.GetAccessPointScopeOutput{Scope: &svcsdktypes.Scope{
+				Permissions: []svcsdktypes.ScopePermission{svcsdktypes.ScopePermissionGetObject},
+				Prefixes:    []string{"team-a/"},
+			}}},
+		}, nil))
+
+		latest, err := rm.sdkFind(context.Background(), newAccessPointResource("team-a--usw2-az1--xa-s3"))
+		require.NoError(err)
+		assert.Nil(latest.ko.Spec.Policy)
+		require.NotNil(latest.ko.Spec.Scope)
+		assert.Equal([]*string{aws.String("GetObject")}, latest.ko.Spec.Scope.Permissions)
+		assert.Equal([]*string{aws.String("team-a/")}, latest.ko.Spec.Scope.Prefixes)
+	})
+}
+
+// Test_sdkCreate verifies that the access point is created before its
+// policy is put.
+func Test_sdkCreate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var calls []string
+	rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+		"CreateAccessPoint": {output: &svcsdk.CreateAccessPointOutput{
+			Alias: aws.String("team-a-abcdefghijklmnopqrstuvwxyz-s3alias"),
+		}},
+	}, &calls))
+
+	desired := newAccessPointResource("team-a")
+	desired.ko.Spec.Policy = aws.String(readPolicy)
+	created, err := rm.sdkCreate(context.Background(), desired)
+	require.NoError(err)
+	assert.Equal([]string{"CreateAccessPoint", "PutAccessPointPolicy"}, calls)
+	assert.Equal("arn:aws:s3:us-west-2:111122223333:accesspoint/team-a", string(*created.ko.Status.ACKResourceMetadata.ARN))
+	assert.Equal("team-a-abcdefghijklmnopqrstuvwxyz-s3alias", *created.ko.Status.Alias)
+}
+
+// Test_sdkCreate_ScopeRequiresDirectoryBucket verifies that a scope is
+// rejected for access points of general purpose buckets.
+func Test_sdkCreate_ScopeRequiresDirectoryBucket(t *testing.T) {
+	var calls []string
+	rm := newTestResourceManager(newMockedSDKClient(nil, &calls))
+
+	desired := newAccessPointResource("team-a")
+	desired.ko.Spec.Scope = &svcapitypes.AccessPointScope{Prefixes: []*string{aws.String("team-a/")}}
+	_, err := rm.sdkCreate(context.Background(), desired)
+	var terminal *ackerr.TerminalError
+	assert.ErrorAs(t, err, &terminal)
+	assert.Empty(t, calls)
+}
+
+// Test_customUpdateAccessPoint verifies that only the properties that
+// differ are updated, and that removing them from the spec deletes them.
+func Test_customUpdateAccessPoint(t *testing.T) {
+	scope := &svcapitypes.AccessPointScope{Prefixes: []*string{aws.String("team-a/")}}
+	for name, tt := range map[string]struct {
+		desiredPolicy *string
+		latestPolicy  *string
+		desiredScope  *svcapitypes.AccessPointScope
+		latestScope   *svcapitypes.AccessPointScope
+		calls         []string
+	}{
+		"put policy": {
+			desiredPolicy: aws.String(readPolicy),
+			calls:         []string{"PutAccessPointPolicy"},
+		},
+		"delete policy": {
+			latestPolicy: aws.String(readPolicy),
+			calls:        []string{"DeleteAccessPointPolicy"},
+		},
+		"put scope": {
+			desiredScope: scope,
+			calls:        []string{"PutAccessPointScope"},
+		},
+		"delete scope": {
+			latestScope: scope,
+			calls:       []string{"DeleteAccessPointScope"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			rm := newTestResourceManager(newMockedSDKClient(nil, &calls))
+
+			desired := newAccessPointResource("team-a--usw2-az1--xa-s3")
+			desired.ko.Spec.Policy = tt.desiredPolicy
+			desired.ko.Spec.Scope = tt.desiredScope
+			latest := newAccessPointResource("team-a--usw2-az1--xa-s3")
+			latest.ko.Spec.Policy = tt.latestPolicy
+			latest.ko.Spec.Scope = tt.latestScope
+
+			_, err := rm.customUpdateAccessPoint(
+				context.Background(), desired, latest, newResourceDelta(desired, latest),
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.calls, calls)
+		})
+	}
+}
+
+// Test_newResourceDelta verifies that the policy and scope S3 returns in a
+// different but equivalent form are not reported as differences.
+func Test_newResourceDelta(t *testing.T) {
+	assert := assert.New(t)
+
+	desired := newAccessPointResource("team-a--usw2-az1--xa-s3")
+	desired.ko.Spec.Policy = aws.String(readPolicy)
+	desired.ko.Spec.Scope = &svcapitypes.AccessPointScope{
+		Permissions: []*string{aws.String("PutObject"), aws.String("GetObject")},
+	}
+	latest := newAccessPointResource("team-a--usw2-az1--xa-s3")
+	latest.ko.Spec.Policy = aws.String(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111122223333:role/team-a"},"Action":["s3:ListBucket","s3:GetObject"],"Resource":"arn:aws:s3:us-west-2:111122223333:accesspoint/team-a/object/*"}]}`)
+	latest.ko.Spec.Scope = &svcapitypes.AccessPointScope{
+		Permissions: []*string{aws.String("GetObject"), aws.String("PutObject")},
+	}
+	assert.Empty(newResourceDelta(desired, latest).Differences)
+
+	latest.ko.Spec.Policy = aws.String(`{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`)
+	latest.ko.Spec.Scope = &svcapitypes.AccessPointScope{
+		Permissions: []*string{aws.String("GetObject")},
+	}
+	delta := newResourceDelta(desired, latest)
+	assert.True(delta.DifferentAt("Spec.Policy"))
+	assert.True(delta.DifferentAt("Spec.Scope"))
+}
+
+// Test_accessPointARN_Partition verifies that access point ARNs are built in
+// the partition the controller runs in, falling back to the partition of the
+// region when the controller could not resolve one.
+func Test_accessPointARN_Partition(t *testing.T) {
+	tests := []struct {
+		name          string
+		partition     ackv1alpha1.AWSPartition
+		region        ackv1alpha1.AWSRegion
+		wantGeneral   string
+		wantDirectory string
+	}{
+		{
+			name:          "govcloud",
+			partition:     "aws-us-gov",
+			region:        "us-gov-west-1",
+			wantGeneral:   "arn:aws-us-gov:s3:us-gov-west-1:111122223333:accesspoint/team-a",
+			wantDirectory: "arn:aws-us-gov:s3express:us-gov-west-1:111122223333:accesspoint/team-a--usw2-az1--xa-s3",
+		},
+		{
+			name:          "china, partition derived from region",
+			region:        "cn-north-1",
+			wantGeneral:   "arn:aws-cn:s3:cn-north-1:111122223333:accesspoint/team-a",
+			wantDirectory: "arn:aws-cn:s3express:cn-north-1:111122223333:accesspoint/team-a--usw2-az1--xa-s3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := &resourceManager{
+				awsPartition: tt.partition,
+				awsRegion:    tt.region,
+				awsAccountID: "111122223333",
+			}
+			assert.Equal(t, tt.wantGeneral, rm.accessPointARN("team-a"))
+			assert.Equal(t, tt.wantDirectory, rm.accessPointARN("team-a--usw2-az1--xa-s3"))
+		})
+	}
+}
@@ -0,0 +1,410 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package access_point
+
+import (
+	"context"
+	"errors"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3control"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3control/types"
+	smithy "github.com/aws/smithy-go"
+	corev1 "k8s.io/api/core/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// sdkFind returns SDK-specific information about a supplied resource
+func (rm *resourceManager) sdkFind(
+	ctx context.Context,
+	r *resource,
+) (latest *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkFind")
+	defer func() {
+		exit(err)
+	}()
+	// If any required fields in the input shape are missing, AWS resource is
+	// not created yet. Return NotFound here to indicate to callers that the
+	// resource isn't yet created.
+	if rm.requiredFieldsMissingFromReadOneInput(r) {
+		return nil, ackerr.NotFound
+	}
+
+	input, err := rm.newDescribeRequestPayload(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *svcsdk.GetAccessPointOutput
+	resp, err = rm.sdkapi.GetAccessPoint(ctx, input)
+	rm.metrics.RecordAPICall("READ_ONE", "GetAccessPoint", err)
+	if err != nil {
+		var awsErr smithy.APIError
+		if errors.As(err, &awsErr) && awsErr.ErrorCode() == "NoSuchAccessPoint" {
+			return nil, ackerr.NotFound
+		}
+		return nil, err
+	}
+
+	// Merge in the information we read from the API call above to the copy of
+	// the original Kubernetes object we passed to the function
+	ko := r.ko.DeepCopy()
+
+	if resp.AccessPointArn != nil {
+		if ko.Status.ACKResourceMetadata == nil {
+			ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+		}
+		arn := ackv1alpha1.AWSResourceName(*resp.AccessPointArn)
+		ko.Status.ACKResourceMetadata.ARN = &arn
+	}
+	ko.Status.Alias = resp.Alias
+	if resp.Bucket != nil {
+		ko.Spec.Bucket = resp.Bucket
+	} else {
+		ko.Spec.Bucket = nil
+	}
+	// S3 returns the account of the bucket even when it is the account of
+	// the access point, which need not be set in the spec.
+	if resp.BucketAccountId != nil &&
+		(r.ko.Spec.BucketAccountID != nil || string(rm.awsAccountID) != *resp.BucketAccountId) {
+		ko.Spec.BucketAccountID = resp.BucketAccountId
+	} else {
+		ko.Spec.BucketAccountID = nil
+	}
+	if resp.Endpoints != nil {
+		ko.Status.Endpoints = aws.StringMap(resp.Endpoints)
+	} else {
+		ko.Status.Endpoints = nil
+	}
+	if resp.Name != nil {
+		ko.Spec.Name = resp.Name
+	} else {
+		ko.Spec.Name = nil
+	}
+	if resp.NetworkOrigin != "" {
+		ko.Status.NetworkOrigin = aws.String(string(resp.NetworkOrigin))
+	} else {
+		ko.Status.NetworkOrigin = nil
+	}
+	if resp.PublicAccessBlockConfiguration != nil {
+		ko.Spec.PublicAccessBlockConfiguration = &svcapitypes.PublicAccessBlockConfiguration{
+			BlockPublicACLs:       resp.PublicAccessBlockConfiguration.BlockPublicAcls,
+			BlockPublicPolicy:     resp.PublicAccessBlockConfiguration.BlockPublicPolicy,
+			IgnorePublicACLs:      resp.PublicAccessBlockConfiguration.IgnorePublicAcls,
+			RestrictPublicBuckets: resp.PublicAccessBlockConfiguration.RestrictPublicBuckets,
+		}
+	} else {
+		ko.Spec.PublicAccessBlockConfiguration = nil
+	}
+	if resp.VpcConfiguration != nil {
+		ko.Spec.VPCConfiguration = &svcapitypes.VPCConfiguration{
+			VPCID: resp.VpcConfiguration.VpcId,
+		}
+	} else {
+		ko.Spec.VPCConfiguration = nil
+	}
+
+	rm.setStatusDefaults(ko)
+	if err := rm.addPolicyAndScopeToSpec(ctx, ko); err != nil {
+		return nil, err
+	}
+
+	return &resource{ko}, nil
+}
+
+// requiredFieldsMissingFromReadOneInput returns true if there are any fields
+// for the ReadOne Input shape that are required but not present in the
+// resource's Spec or Status
+func (rm *resourceManager) requiredFieldsMissingFromReadOneInput(
+	r *resource,
+) bool {
+	return r.ko.Spec.Name == nil
+}
+
+// newDescribeRequestPayload returns SDK-specific struct for the HTTP request
+// payload of the Describe API call for the resource
+func (rm *resourceManager) newDescribeRequestPayload(
+	r *resource,
+) (*svcsdk.GetAccessPointInput, error) {
+	res := &svcsdk.GetAccessPointInput{}
+
+	res.AccountId = aws.String(string(rm.awsAccountID))
+	if r.ko.Spec.Name != nil {
+		res.Name = r.ko.Spec.Name
+	}
+
+	return res, nil
+}
+
+// sdkCreate creates the supplied resource in the backend AWS service API and
+// returns a copy of the resource with resource fields (in both Spec and
+// Status) filled in with values from the CREATE API operation's Output shape.
+func (rm *resourceManager) sdkCreate(
+	ctx context.Context,
+	desired *resource,
+) (created *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkCreate")
+	defer func() {
+		exit(err)
+	}()
+	if err := validateAccessPointSpec(desired.ko); err != nil {
+		return nil, err
+	}
+	input, err := rm.newCreateRequestPayload(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *svcsdk.CreateAccessPointOutput
+	resp, err = rm.sdkapi.CreateAccessPoint(ctx, input)
+	rm.metrics.RecordAPICall("CREATE", "CreateAccessPoint", err)
+	if err != nil {
+		return nil, err
+	}
+	// Merge in the information we read from the API call above to the copy of
+	// the original Kubernetes object we passed to the function
+	ko := desired.ko.DeepCopy()
+
+	if ko.Status.ACKResourceMetadata == nil {
+		ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+	}
+	arn := ackv1alpha1.AWSResourceName(rm.accessPointARN(*ko.Spec.Name))
+	if resp.AccessPointArn != nil {
+		arn = ackv1alpha1.AWSResourceName(*resp.AccessPointArn)
+	}
+	ko.Status.ACKResourceMetadata.ARN = &arn
+	ko.Status.Alias = resp.Alias
+
+	rm.setStatusDefaults(ko)
+
+	// The policy cannot be set by CreateAccessPoint. Should putting it fail,
+	// the next reconciliation finds the access point without it and puts it
+	// again.
+	if ko.Spec.Policy != nil {
+		if err := rm.putPolicy(ctx, ko); err != nil {
+			return &resource{ko}, err
+		}
+	}
+
+	return &resource{ko}, nil
+}
+
+// newCreateRequestPayload returns an SDK-specific struct for the HTTP request
+// payload of the Create API call for the resource
+func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
+	r *resource,
+) (*svcsdk.CreateAccessPointInput, error) {
+	res := &svcsdk.CreateAccessPointInput{}
+
+	res.AccountId = aws.String(string(rm.awsAccountID))
+	if r.ko.Spec.Bucket != nil {
+		res.Bucket = r.ko.Spec.Bucket
+	}
+	if r.ko.Spec.BucketAccountID != nil {
+		res.BucketAccountId = r.ko.Spec.BucketAccountID
+	}
+	if r.ko.Spec.Name != nil {
+		res.Name = r.ko.Spec.Name
+	}
+	if r.ko.Spec.PublicAccessBlockConfiguration != nil {
+		res.PublicAccessBlockConfiguration = &svcsdktypes.PublicAccessBlockConfiguration{
+			BlockPublicAcls:       r.ko.Spec.PublicAccessBlockConfiguration.BlockPublicACLs,
+			BlockPublicPolicy:     r.ko.Spec.PublicAccessBlockConfiguration.BlockPublicPolicy,
+			IgnorePublicAcls:      r.ko.Spec.PublicAccessBlockConfiguration.IgnorePublicACLs,
+			RestrictPublicBuckets: r.ko.Spec.PublicAccessBlockConfiguration.RestrictPublicBuckets,
+		}
+	}
+	if r.ko.Spec.Scope != nil {
+		res.Scope = newScope(r.ko.Spec.Scope)
+	}
+	if r.ko.Spec.VPCConfiguration != nil {
+		res.VpcConfiguration = &svcsdktypes.VpcConfiguration{
+			VpcId: r.ko.Spec.VPCConfiguration.VPCID,
+		}
+	}
+
+	return res, nil
+}
+
+// sdkUpdate patches the supplied resource in the backend AWS service API and
+// returns a new resource with updated fields.
+func (rm *resourceManager) sdkUpdate(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (*resource, error) {
+	return rm.customUpdateAccessPoint(ctx, desired, latest, delta)
+}
+
+// sdkDelete deletes the supplied resource in the backend AWS service API
+func (rm *resourceManager) sdkDelete(
+	ctx context.Context,
+	r *resource,
+) (latest *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkDelete")
+	defer func() {
+		exit(err)
+	}()
+	input, err := rm.newDeleteRequestPayload(r)
+	if err != nil {
+		return nil, err
+	}
+	_, err = rm.sdkapi.DeleteAccessPoint(ctx, input)
+	rm.metrics.RecordAPICall("DELETE", "DeleteAccessPoint", err)
+	return nil, err
+}
+
+// newDeleteRequestPayload returns an SDK-specific struct for the HTTP request
+// payload of the Delete API call for the resource
+func (rm *resourceManager) newDeleteRequestPayload(
+	r *resource,
+) (*svcsdk.DeleteAccessPointInput, error) {
+	res := &svcsdk.DeleteAccessPointInput{}
+
+	res.AccountId = aws.String(string(rm.awsAccountID))
+	if r.ko.Spec.Name != nil {
+		res.Name = r.ko.Spec.Name
+	}
+
+	return res, nil
+}
+
+// setStatusDefaults sets default properties into supplied custom resource
+func (rm *resourceManager) setStatusDefaults(
+	ko *svcapitypes.AccessPoint,
+) {
+	if ko.Status.ACKResourceMetadata == nil {
+		ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+	}
+	if ko.Status.ACKResourceMetadata.Region == nil {
+		ko.Status.ACKResourceMetadata.Region = &rm.awsRegion
+	}
+	if ko.Status.ACKResourceMetadata.Partition == nil {
+		ko.Status.ACKResourceMetadata.Partition = &rm.awsPartition
+	}
+	if ko.Status.ACKResourceMetadata.OwnerAccountID == nil {
+		ko.Status.ACKResourceMetadata.OwnerAccountID = &rm.awsAccountID
+	}
+	if ko.Status.Conditions == nil {
+		ko.Status.Conditions = []*ackv1alpha1.Condition{}
+	}
+}
+
+// updateConditions returns updated resource, true; if conditions were updated
+// else it returns nil, false
+func (rm *resourceManager) updateConditions(
+	r *resource,
+	onSuccess bool,
+	err error,
+) (*resource, bool) {
+	ko := r.ko.DeepCopy()
+	rm.setStatusDefaults(ko)
+
+	// Terminal condition
+	var terminalCondition *ackv1alpha1.Condition = nil
+	var recoverableCondition *ackv1alpha1.Condition = nil
+	var syncCondition *ackv1alpha1.Condition = nil
+	for _, condition := range ko.Status.Conditions {
+		if condition.Type == ackv1alpha1.ConditionTypeTerminal {
+			terminalCondition = condition
+		}
+		if condition.Type == ackv1alpha1.ConditionTypeRecoverable {
+			recoverableCondition = condition
+		}
+		if condition.Type == ackv1alpha1.ConditionTypeResourceSynced {
+			syncCondition = condition
+		}
+	}
+	var termError *ackerr.TerminalError
+	if rm.terminalAWSError(err) || err == ackerr.SecretTypeNotSupported || err == ackerr.SecretNotFound || errors.As(err, &termError) {
+		if terminalCondition == nil {
+			terminalCondition = &ackv1alpha1.Condition{
+				Type: ackv1alpha1.ConditionTypeTerminal,
+			}
+			ko.Status.Conditions = append(ko.Status.Conditions, terminalCondition)
+		}
+		var errorMessage = ""
+		if err == ackerr.SecretTypeNotSupported || err == ackerr.SecretNotFound || errors.As(err, &termError) {
+			errorMessage = err.Error()
+		} else {
+			awsErr, _ := ackerr.AWSError(err)
+			errorMessage = awsErr.Error()
+		}
+		terminalCondition.Status = corev1.ConditionTrue
+		terminalCondition.Message = &errorMessage
+	} else {
+		// Clear the terminal condition if no longer present
+		if terminalCondition != nil {
+			terminalCondition.Status = corev1.ConditionFalse
+			terminalCondition.Message = nil
+		}
+		// Handling Recoverable Conditions
+		if err != nil {
+			if recoverableCondition == nil {
+				// Add a new Condition containing a non-terminal error
+				recoverableCondition = &ackv1alpha1.Condition{
+					Type: ackv1alpha1.ConditionTypeRecoverable,
+				}
+				ko.Status.Conditions = append(ko.Status.Conditions, recoverableCondition)
+			}
+			recoverableCondition.Status = corev1.ConditionTrue
+			awsErr, _ := ackerr.AWSError(err)
+			errorMessage := err.Error()
+			if awsErr != nil {
+				errorMessage = awsErr.Error()
+			}
+			recoverableCondition.Message = &errorMessage
+		} else if recoverableCondition != nil {
+			recoverableCondition.Status = corev1.ConditionFalse
+			recoverableCondition.Message = nil
+		}
+	}
+	// Required to avoid the "declared but not used" error in the default case
+	_ = syncCondition
+	if terminalCondition != nil || recoverableCondition != nil || syncCondition != nil {
+		return &resource{ko}, true // updated
+	}
+	return nil, false // not updated
+}
+
+// terminalAWSError returns awserr, true; if the supplied error is an aws Error type
+// and if the exception indicates that it is a Terminal exception
+func (rm *resourceManager) terminalAWSError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var terminalErr smithy.APIError
+	if !errors.As(err, &terminalErr) {
+		return false
+	}
+	switch terminalErr.ErrorCode() {
+	case "InvalidArgument",
+		"InvalidRequest",
+		"MalformedPolicy":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlrtconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=s3.services.k8s.aws,resources=bucketclasses,verbs=get;list
+
+// A BucketClass holds defaults for the spec of Buckets, much like a
+// StorageClass does for volumes. The class of a Bucket is picked when the
+// bucket is created: the one named by spec.bucketClassName or else the
+// default class. It is recorded in status.bucketClass and only that class
+// applies afterwards, so that annotating another class as the default leaves
+// the existing Buckets alone.
+//
+// The class is merged under the spec of the desired resource when it is
+// compared with the bucket, see customPreCompare, and under a copy of it when
+// the bucket is read or updated. The spec of the Bucket is patched with the
+// desired resource as the base, so the fields set from the class are never
+// written back to it. They are listed in status.bucketClass.
+
+// bucketClassReader returns the client BucketClasses are read with.
+var bucketClassReader = sync.OnceValues(func() (client.Reader, error) {
+	cfg, err := ctrlrtconfig.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	scheme := runtime.NewScheme()
+	if err := svcapitypes.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+})
+
+// applyBucketClass merges the BucketClass of the Bucket, if any, under its
+// spec and records the class and the fields set from it in the status. The
+// class is the one named by spec.bucketClassName or else the one recorded in
+// the status. When pickDefault is true, as it is for the bucket being
+// created, a Bucket that has neither gets the default class.
+func applyBucketClass(
+	ctx context.Context,
+	ko *svcapitypes.Bucket,
+	pickDefault bool,
+) error {
+	name := ko.Spec.BucketClassName
+	if (name == nil || *name == "") && ko.Status.BucketClass != nil {
+		name = ko.Status.BucketClass.Name
+	}
+	if (name == nil || *name == "") && !pickDefault {
+		ko.Status.BucketClass = nil
+		return nil
+	}
+	apiReader, err := bucketClassReader()
+	if err != nil {
+		return err
+	}
+	class, err := getBucketClass(ctx, apiReader, name)
+	if err != nil || class == nil {
+		ko.Status.BucketClass = nil
+		return err
+	}
+
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&ko.Spec)
+	if err != nil {
+		return err
+	}
+	defaults, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&class.Spec)
+	if err != nil {
+		return err
+	}
+	fields := mergeBucketClassSpec(spec, defaults, "")
+	merged := svcapitypes.BucketSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(spec, &merged); err != nil {
+		return fmt.Errorf("merging BucketClass %q: %w", class.Name, err)
+	}
+	ko.Spec = merged
+	ko.Status.BucketClass = &svcapitypes.AppliedBucketClass{
+		Name:   aws.String(class.Name),
+		Fields: aws.StringSlice(fields),
+	}
+	return nil
+}
+
+// getBucketClass returns the BucketClass with the given name or, if no name
+// is given, the BucketClass annotated as the default class. It returns nil if
+// no name is given and there is no default class, and an error if there is
+// more than one.
+func getBucketClass(
+	ctx context.Context,
+	apiReader client.Reader,
+	name *string,
+) (*svcapitypes.BucketClass, error) {
+	if name != nil && *name != "" {
+		class := &svcapitypes.BucketClass{}
+		if err := apiReader.Get(ctx, types.NamespacedName{Name: *name}, class); err != nil {
+			return nil, fmt.Errorf("getting BucketClass %q: %w", *name, err)
+		}
+		return class, nil
+	}
+
+	classes := &svcapitypes.BucketClassList{}
+	if err := apiReader.List(ctx, classes); err != nil {
+		return nil, fmt.Errorf("listing BucketClasses: %w", err)
+	}
+	var defaultClass *svcapitypes.BucketClass
+	for i := range classes.Items {
+		class := &classes.Items[i]
+		if !strings.EqualFold(class.GetAnnotations()[svcapitypes.AnnotationDefaultBucketClass], "true") {
+			continue
+		}
+		if defaultClass != nil {
+			return nil, fmt.Errorf(
+				"BucketClasses %q and %q are both annotated as the default class, set spec.bucketClassName to pick one",
+				defaultClass.Name, class.Name,
+			)
+		}
+		defaultClass = class
+	}
+	return defaultClass, nil
+}
+
+// mergeBucketClassSpec sets the values of defaults missing from spec, both
+// being BucketSpec fields in their unstructured form, and returns the paths of
+// the fields it set. Maps are merged key by key and other values, lists
+// included, are set as a whole, except for the tag set, whose tags are merged
+// by key.
+func mergeBucketClassSpec(
+	spec map[string]interface{},
+	defaults map[string]interface{},
+	path string,
+) []string {
+	fields := []string{}
+	keys := make([]string, 0, len(defaults))
+	for key := range defaults {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		value, ok := spec[key]
+		if !ok || value == nil {
+			spec[key] = runtime.DeepCopyJSONValue(defaults[key])
+			fields = append(fields, fieldPath)
+			continue
+		}
+		if fieldPath == "tagging.tagSet" {
+			tags, tagFields := mergeBucketClassTags(value, defaults[key], fieldPath)
+			spec[key] = tags
+			fields = append(fields, tagFields...)
+			continue
+		}
+		valueMap, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if defaultMap, ok := defaults[key].(map[string]interface{}); ok {
+			fields = append(fields, mergeBucketClassSpec(valueMap, defaultMap, fieldPath)...)
+		}
+	}
+	return fields
+}
+
+// mergeBucketClassTags appends the tags of the class missing from the tags of
+// the Bucket, and returns the resulting tags along with the paths of the
+// tags it appended, e.g. tagging.tagSet[team].
+func mergeBucketClassTags(
+	value interface{},
+	defaults interface{},
+	path string,
+) ([]interface{}, []string) {
+	tags, _ := value.([]interface{})
+	defaultTags, _ := defaults.([]interface{})
+	fields := []string{}
+	keys := map[string]bool{}
+	for _, tag := range tags {
+		keys[tagKey(tag)] = true
+	}
+	for _, tag := range defaultTags {
+		key := tagKey(tag)
+		if keys[key] {
+			continue
+		}
+		keys[key] = true
+		tags = append(tags, runtime.DeepCopyJSONValue(tag))
+		fields = append(fields, fmt.Sprintf("%s[%s]", path, key))
+	}
+	return tags, fields
+}
+
+// tagKey returns the key of a tag in its unstructured form.
+func tagKey(tag interface{}) string {
+	if m, ok := tag.(map[string]interface{}); ok {
+		key, _ := m["key"].(string)
+		return key
+	}
+	return ""
+}
@@ -0,0 +1,230 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+func newBucketClass(name string, isDefault bool) *svcapitypes.BucketClass {
+	class := &svcapitypes.BucketClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: svcapitypes.BucketClassSpec{
+			Versioning: &svcapitypes.VersioningConfiguration{Status: aws.String("Enabled")},
+			PublicAccessBlock: &svcapitypes.PublicAccessBlockConfiguration{
+				BlockPublicACLs:   aws.Bool(true),
+				BlockPublicPolicy: aws.Bool(true),
+			},
+			Tagging: &svcapitypes.Tagging{
+				TagSet: []*svcapitypes.Tag{
+					{Key: aws.String("team"), Value: aws.String("storage")},
+					{Key: aws.String("app.kubernetes.io/part-of"), Value: aws.String(name)},
+				},
+			},
+		},
+	}
+	if isDefault {
+		class.Annotations = map[string]string{svcapitypes.AnnotationDefaultBucketClass: "true"}
+	}
+	return class
+}
+
+// withBucketClasses makes the given BucketClasses the ones read by the
+// controller for the duration of the test.
+func withBucketClasses(t *testing.T, classes ...client.Object) {
+	t.Helper()
+	apiReader := newReferencedBucketReader(t, classes...)
+	reader := bucketClassReader
+	bucketClassReader = func() (client.Reader, error) {
+		return apiReader, nil
+	}
+	t.Cleanup(func() { bucketClassReader = reader })
+}
+
+// Test_applyBucketClass verifies that the class named by the Bucket is merged
+// under its spec.
+func Test_applyBucketClass(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	withBucketClasses(t,
+		newBucketClass("standard", true),
+		newBucketClass("archive", false),
+	)
+	ko := newBucketResource("my-bucket").ko
+	ko.Spec.BucketClassName = aws.String("archive")
+	ko.Spec.PublicAccessBlock = &svcapitypes.PublicAccessBlockConfiguration{
+		BlockPublicACLs: aws.Bool(false),
+	}
+	ko.Spec.Tagging = &svcapitypes.Tagging{
+		TagSet: []*svcapitypes.Tag{{Key: aws.String("team"), Value: aws.String("analytics")}},
+	}
+
+	require.NoError(applyBucketClass(context.Background(), ko, false))
+	assert.Equal("Enabled", *ko.Spec.Versioning.Status)
+	assert.False(*ko.Spec.PublicAccessBlock.BlockPublicACLs)
+	assert.True(*ko.Spec.PublicAccessBlock.BlockPublicPolicy)
+	assert.Equal([]*svcapitypes.Tag{
+		{Key: aws.String("team"), Value: aws.String("analytics")},
+		{Key: aws.String("app.kubernetes.io/part-of"), Value: aws.String("archive")},
+	}, ko.Spec.Tagging.TagSet)
+	require.NotNil(ko.Status.BucketClass)
+	assert.Equal("archive", *ko.Status.BucketClass.Name)
+	assert.Equal([]string{
+		"publicAccessBlock.blockPublicPolicy",
+		"tagging.tagSet[app.kubernetes.io/part-of]",
+		"versioning",
+	}, aws.ToStringSlice(ko.Status.BucketClass.Fields))
+
+	// Ambiguous defaults and missing classes are errors
+	withBucketClasses(t,
+		newBucketClass("standard", true),
+		newBucketClass("other", true),
+	)
+	err := applyBucketClass(context.Background(), newBucketResource("my-bucket").ko, true)
+	assert.ErrorContains(err, "both annotated as the default class")
+
+	missing := newBucketResource("my-bucket").ko
+	missing.Spec.BucketClassName = aws.String("missing")
+	err = applyBucketClass(context.Background(), missing, false)
+	assert.ErrorContains(err, `getting BucketClass "missing"`)
+}
+
+// Test_sdkCreate_DefaultBucketClass verifies that a bucket created without
+// selecting a class gets the default class, which is recorded in its status.
+func Test_sdkCreate_DefaultBucketClass(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rm := newDestinationGrantsResourceManager(map[string]opResult{
+		"CreateBucket": {output: &svcsdk.CreateBucketOutput{}},
+	}, &[]*svcsdk.PutBucketPolicyInput{})
+
+	// Without any default class, the spec is left as is
+	withBucketClasses(t, newBucketClass("archive", false))
+	created, err := rm.sdkCreate(context.Background(), newBucketResource("my-bucket"))
+	assert.Error(err, "requeue for updates")
+	require.NotNil(created)
+	assert.Nil(created.ko.Spec.Versioning)
+	assert.Nil(created.ko.Status.BucketClass)
+
+	withBucketClasses(t,
+		newBucketClass("standard", true),
+		newBucketClass("archive", false),
+	)
+	created, err = rm.sdkCreate(context.Background(), newBucketResource("my-bucket"))
+	assert.Error(err, "requeue for updates")
+	require.NotNil(created)
+	assert.Equal("Enabled", *created.ko.Spec.Versioning.Status)
+	require.NotNil(created.ko.Status.BucketClass)
+	assert.Equal("standard", *created.ko.Status.BucketClass.Name)
+	assert.Equal([]string{"publicAccessBlock", "tagging", "versioning"},
+		aws.ToStringSlice(created.ko.Status.BucketClass.Fields))
+}
+
+// Test_customFindBucket_BucketClass verifies that the class recorded for a
+// Bucket is reported in the status of the bucket read, without being merged
+// under the spec of the desired resource, and that existing Buckets without a
+// class do not get the default one.
+func Test_customFindBucket_BucketClass(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	withBucketClasses(t,
+		newBucketClass("standard", true),
+		newBucketClass("archive", false),
+	)
+	rm := &resourceManager{
+		sdkapi:  newMockedSDKClient(map[string]opResult{}),
+		metrics: ackmetrics.NewMetrics("s3"),
+	}
+
+	desired := newBucketResource("my-bucket")
+	latest, err := rm.customFindBucket(context.Background(), desired)
+	require.NoError(err)
+	assert.Nil(latest.ko.Status.BucketClass)
+
+	desired = newBucketResource("my-bucket")
+	desired.ko.Status.BucketClass = &svcapitypes.AppliedBucketClass{Name: aws.String("archive")}
+	latest, err = rm.customFindBucket(context.Background(), desired)
+	require.NoError(err)
+	assert.Nil(desired.ko.Spec.Versioning)
+	assert.Nil(desired.ko.Spec.Tagging)
+	assert.Empty(desired.ko.Status.BucketClass.Fields)
+	require.NotNil(latest.ko.Status.BucketClass)
+	assert.Equal("archive", *latest.ko.Status.BucketClass.Name)
+	assert.Equal([]string{"publicAccessBlock", "tagging", "versioning"},
+		aws.ToStringSlice(latest.ko.Status.BucketClass.Fields))
+
+	// The class named in the spec wins over the recorded one
+	desired = newBucketResource("my-bucket")
+	desired.ko.Spec.BucketClassName = aws.String("standard")
+	desired.ko.Status.BucketClass = &svcapitypes.AppliedBucketClass{Name: aws.String("archive")}
+	latest, err = rm.customFindBucket(context.Background(), desired)
+	require.NoError(err)
+	assert.Equal("standard", *latest.ko.Status.BucketClass.Name)
+}
+
+// Test_BucketClass_Drift verifies that the class of the Bucket is compared
+// with the bucket, and that a field that drifted from the class, even to its
+// default value, is put back by the update.
+func Test_BucketClass_Drift(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	withBucketClasses(t, newBucketClass("archive", false))
+	rm := &resourceManager{
+		sdkapi: newMockedSDKClient(map[string]opResult{
+			"PutBucketVersioning":     {output: &svcsdk.PutBucketVersioningOutput{}},
+			"DeleteBucketReplication": {output: &svcsdk.DeleteBucketReplicationOutput{}},
+		}),
+		metrics: ackmetrics.NewMetrics("s3"),
+	}
+
+	newDesired := func() *resource {
+		desired := newBucketResource("my-bucket")
+		desired.ko.Spec.BucketClassName = aws.String("archive")
+		return desired
+	}
+	latest := newBucketResource("my-bucket")
+	latest.ko.Spec.BucketClassName = aws.String("archive")
+	latest.ko.Spec.Versioning = &svcapitypes.VersioningConfiguration{Status: aws.String("Enabled")}
+	delta := newResourceDelta(newDesired(), latest)
+	assert.False(delta.DifferentAt("Spec.Versioning"))
+
+	latest.ko.Spec.Versioning.Status = aws.String("Suspended")
+	delta = newResourceDelta(newDesired(), latest)
+	require.True(delta.DifferentAt("Spec.Versioning"))
+
+	delta = ackcompare.NewDelta()
+	delta.Add("Spec.Versioning", nil, latest.ko.Spec.Versioning)
+	updated, err := rm.customUpdateBucket(context.Background(), newDesired(), latest, delta)
+	require.NoError(err)
+	assert.Nil(updated.ko.Spec.Versioning)
+	c := subresourceCondition(updated.ko, "Versioning")
+	require.NotNil(c)
+	assert.Equal(svcapitypes.SubresourceConditionStatusSynced, c.Status)
+}
@@ -0,0 +1,93 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// inventoryBucketDestination returns the S3 bucket destination of the
+// inventory configuration, or nil if it has none.
+func inventoryBucketDestination(
+	config *svcapitypes.InventoryConfiguration,
+) *svcapitypes.InventoryS3BucketDestination {
+	if config == nil || config.Destination == nil {
+		return nil
+	}
+	return config.Destination.S3BucketDestination
+}
+
+// analyticsBucketDestination returns the S3 bucket destination of the
+// analytics configuration data export, or nil if it has none.
+func analyticsBucketDestination(
+	config *svcapitypes.AnalyticsConfiguration,
+) *svcapitypes.AnalyticsS3BucketDestination {
+	if config == nil || config.StorageClassAnalysis == nil ||
+		config.StorageClassAnalysis.DataExport == nil ||
+		config.StorageClassAnalysis.DataExport.Destination == nil {
+		return nil
+	}
+	return config.StorageClassAnalysis.DataExport.Destination.S3BucketDestination
+}
+
+// copyBucketReferences copies the bucket reference fields of the desired
+// logging, inventory, analytics and replication configurations, which S3
+// knows nothing about, to the latest ones. Inventory and analytics
+// configurations are matched by ID, since S3 lists them in its own order.
+func copyBucketReferences(
+	desired *svcapitypes.BucketSpec,
+	latest *svcapitypes.BucketSpec,
+) {
+	if desired.Logging != nil && desired.Logging.LoggingEnabled != nil &&
+		latest.Logging != nil && latest.Logging.LoggingEnabled != nil {
+		latest.Logging.LoggingEnabled.TargetBucketRef = desired.Logging.LoggingEnabled.TargetBucketRef
+	}
+	for _, config := range latest.Inventory {
+		dest := inventoryBucketDestination(config)
+		if dest == nil || config.ID == nil {
+			continue
+		}
+		for _, desiredConfig := range desired.Inventory {
+			desiredDest := inventoryBucketDestination(desiredConfig)
+			if desiredDest != nil && desiredConfig.ID != nil && *desiredConfig.ID == *config.ID {
+				dest.BucketRef = desiredDest.BucketRef
+			}
+		}
+	}
+	for _, config := range latest.Analytics {
+		dest := analyticsBucketDestination(config)
+		if dest == nil || config.ID == nil {
+			continue
+		}
+		for _, desiredConfig := range desired.Analytics {
+			desiredDest := analyticsBucketDestination(desiredConfig)
+			if desiredDest != nil && desiredConfig.ID != nil && *desiredConfig.ID == *config.ID {
+				dest.BucketRef = desiredDest.BucketRef
+			}
+		}
+	}
+	if desired.Replication != nil && latest.Replication != nil {
+		for i, rule := range latest.Replication.Rules {
+			if i >= len(desired.Replication.Rules) {
+				break
+			}
+			desiredRule := desired.Replication.Rules[i]
+			if rule == nil || rule.Destination == nil ||
+				desiredRule == nil || desiredRule.Destination == nil {
+				continue
+			}
+			rule.Destination.BucketRef = desiredRule.Destination.BucketRef
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// newReferencedBucket returns a Bucket managing the S3 bucket with the given
+// name and ResourceSynced condition.
+func newReferencedBucket(name, bucketName string, synced corev1.ConditionStatus) *svcapitypes.Bucket {
+	arn := ackv1alpha1.AWSResourceName("arn:aws:s3:::" + bucketName)
+	return &svcapitypes.Bucket{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       svcapitypes.BucketSpec{Name: aws.String(bucketName)},
+		Status: svcapitypes.BucketStatus{
+			ACKResourceMetadata: &ackv1alpha1.ResourceMetadata{ARN: &arn},
+			Conditions: []*ackv1alpha1.Condition{{
+				Type:   ackv1alpha1.ConditionTypeResourceSynced,
+				Status: synced,
+			}},
+		},
+	}
+}
+
+func newReferencedBucketReader(t *testing.T, objs ...client.Object) client.Reader {
+	scheme := runtime.NewScheme()
+	require.NoError(t, svcapitypes.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+// Test_ResolveReferences_DestinationBuckets verifies that the logging,
+// inventory, analytics and replication destinations are resolved to the name
+// or ARN of the referenced Buckets.
+func Test_ResolveReferences_DestinationBuckets(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	apiReader := newReferencedBucketReader(t,
+		newReferencedBucket("logs", "my-logs-bucket", corev1.ConditionTrue),
+		newReferencedBucket("reports", "my-reports-bucket", corev1.ConditionTrue),
+		newReferencedBucket("replica", "my-replica-bucket", corev1.ConditionTrue),
+		newReferencedBucket("pending", "my-pending-bucket", corev1.ConditionFalse),
+	)
+
+	rm := &resourceManager{}
+	desired := newBucketResource("my-bucket")
+	desired.ko.Namespace = "default"
+	desired.ko.Spec.Logging = &svcapitypes.BucketLoggingStatus{
+		LoggingEnabled: &svcapitypes.LoggingEnabled{
+			TargetBucketRef: newReference("logs"),
+			TargetPrefix:    aws.String("access/"),
+		},
+	}
+	desired.ko.Spec.Inventory = []*svcapitypes.InventoryConfiguration{{
+		ID: aws.String("weekly"),
+		Destination: &svcapitypes.InventoryDestination{
+			S3BucketDestination: &svcapitypes.InventoryS3BucketDestination{
+				BucketRef: newReference("reports"),
+				Format:    aws.String("CSV"),
+			},
+		},
+	}}
+	desired.ko.Spec.Analytics = []*svcapitypes.AnalyticsConfiguration{{
+		ID: aws.String("daily"),
+		StorageClassAnalysis: &svcapitypes.StorageClassAnalysis{
+			DataExport: &svcapitypes.StorageClassAnalysisDataExport{
+				Destination: &svcapitypes.AnalyticsExportDestination{
+					S3BucketDestination: &svcapitypes.AnalyticsS3BucketDestination{
+						BucketRef: newReference("reports"),
+						Format:    aws.String("CSV"),
+					},
+				},
+			},
+		},
+	}}
+	desired.ko.Spec.Replication = &svcapitypes.ReplicationConfiguration{
+		Role: aws.String("arn:aws:iam::111122223333:role/replication"),
+		Rules: []*svcapitypes.ReplicationRule{{
+			Destination: &svcapitypes.Destination{
+				BucketRef: newReference("replica"),
+			},
+		}},
+	}
+
+	resolved, hasReferences, err := rm.ResolveReferences(context.Background(), apiReader, desired.DeepCopy())
+	require.NoError(err)
+	assert.True(hasReferences)
+	spec := rm.concreteResource(resolved).ko.Spec
+	assert.Equal("my-logs-bucket", *spec.Logging.LoggingEnabled.TargetBucket)
+	assert.Equal("arn:aws:s3:::my-reports-bucket", *spec.Inventory[0].Destination.S3BucketDestination.Bucket)
+	assert.Equal("arn:aws:s3:::my-reports-bucket", *spec.Analytics[0].StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket)
+	assert.Equal("arn:aws:s3:::my-replica-bucket", *spec.Replication.Rules[0].Destination.Bucket)
+
+	// The resolved buckets are cleared before the spec is written back
+	cleared := rm.concreteResource(rm.ClearResolvedReferences(resolved)).ko.Spec
+	assert.Nil(cleared.Logging.LoggingEnabled.TargetBucket)
+	assert.Nil(cleared.Inventory[0].Destination.S3BucketDestination.Bucket)
+	assert.Nil(cleared.Analytics[0].StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket)
+	assert.Nil(cleared.Replication.Rules[0].Destination.Bucket)
+
+	// The references are not reported as a difference with the latest state
+	latest := newBucketResource("my-bucket")
+	latest.ko.Spec = *spec.DeepCopy()
+	latest.ko.Spec.Logging.LoggingEnabled.TargetBucketRef = nil
+	latest.ko.Spec.Inventory[0].Destination.S3BucketDestination.BucketRef = nil
+	latest.ko.Spec.Analytics[0].StorageClassAnalysis.DataExport.Destination.S3BucketDestination.BucketRef = nil
+	latest.ko.Spec.Replication.Rules[0].Destination.BucketRef = nil
+	delta := newResourceDelta(rm.concreteResource(resolved), latest)
+	assert.False(delta.DifferentAt("Spec.Logging"))
+	assert.False(delta.DifferentAt("Spec.Inventory"))
+	assert.False(delta.DifferentAt("Spec.Analytics"))
+	assert.False(delta.DifferentAt("Spec.Replication"))
+
+	// A destination which is not synced yet is waited for
+	desired.ko.Spec.Replication.Rules[0].Destination.BucketRef = newReference("pending")
+	_, _, err = rm.ResolveReferences(context.Background(), apiReader, desired.DeepCopy())
+	assert.ErrorContains(err, "pending")
+
+	// Either the bucket or the reference must be set
+	desired.ko.Spec.Replication.Rules[0].Destination.BucketRef = newReference("replica")
+	desired.ko.Spec.Logging.LoggingEnabled.TargetBucketRef = nil
+	_, _, err = rm.ResolveReferences(context.Background(), apiReader, desired.DeepCopy())
+	assert.ErrorContains(err, "TargetBucketRef")
+}
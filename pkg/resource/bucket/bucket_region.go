@@ -0,0 +1,120 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"fmt"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// General purpose buckets can be adopted regardless of the region the
+// controller (or the resource's region annotation) targets. When a bucket is
+// found in another region, calls for it are made through a copy of the
+// resource manager bound to that region, and the region is recorded in
+// Status.BucketRegion so that later calls go there directly.
+//
+// The region is not recorded in Status.ACKResourceMetadata.Region: the runtime
+// refuses to reconcile a resource whose region there differs from the one its
+// annotations or the controller configuration target.
+
+// forRegion returns rm itself if it already targets region, or a copy of rm
+// whose SDK client targets region otherwise. The copy shares the HTTP client
+// of rm, so connections are reused across the copies made for a region.
+func (rm *resourceManager) forRegion(
+	region ackv1alpha1.AWSRegion,
+) *resourceManager {
+	if region == "" || region == rm.awsRegion {
+		return rm
+	}
+	clientcfg := rm.clientcfg.Copy()
+	clientcfg.Region = string(region)
+	copied := *rm
+	copied.clientcfg = clientcfg
+	copied.awsRegion = region
+	copied.sdkapi = svcsdk.NewFromConfig(clientcfg, func(o *svcsdk.Options) {
+		o.UsePathStyle = rm.cfg.UsePathStyle
+	})
+	return &copied
+}
+
+// managerForResource returns the resource manager to use for the bucket of
+// r, based on the region recorded in its status by the read that returned
+// it. If no region is recorded, the region is looked up.
+func (rm *resourceManager) managerForResource(
+	ctx context.Context,
+	r *resource,
+) (*resourceManager, error) {
+	if r.ko.Status.BucketRegion != nil {
+		return rm.forRegion(ackv1alpha1.AWSRegion(*r.ko.Status.BucketRegion)), nil
+	}
+	return rm.managerForBucket(ctx, *r.ko.Spec.Name)
+}
+
+// managerForBucket checks that the named bucket exists and returns the
+// resource manager to use for it: rm itself if the bucket lives in rm's
+// region, or a copy of rm bound to the bucket's region otherwise.
+func (rm *resourceManager) managerForBucket(
+	ctx context.Context,
+	bucketName string,
+) (*resourceManager, error) {
+	redirect, err := rm.headBucket(ctx, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	if redirect == "" {
+		return rm, nil
+	}
+
+	target := rm.forRegion(redirect)
+	if redirect, err = target.headBucket(ctx, bucketName); err != nil {
+		return nil, err
+	}
+	if redirect != "" {
+		return nil, fmt.Errorf(
+			"bucket %q was redirected to region %q by its own region %q",
+			bucketName, redirect, target.awsRegion,
+		)
+	}
+	return target, nil
+}
+
+// getBucketLocation returns the region of the named bucket as reported by
+// GetBucketLocation, which, unlike the other bucket operations, can be called
+// from any region.
+func (rm *resourceManager) getBucketLocation(
+	ctx context.Context,
+	bucketName string,
+) (ackv1alpha1.AWSRegion, error) {
+	resp, err := rm.sdkapi.GetBucketLocation(ctx, &svcsdk.GetBucketLocationInput{
+		Bucket: aws.String(bucketName),
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetBucketLocation", err)
+	if err != nil {
+		return "", err
+	}
+	switch resp.LocationConstraint {
+	case "":
+		// Buckets in us-east-1 have no location constraint
+		return "us-east-1", nil
+	case svcsdktypes.BucketLocationConstraintEu:
+		return "eu-west-1", nil
+	default:
+		return ackv1alpha1.AWSRegion(resp.LocationConstraint), nil
+	}
+}
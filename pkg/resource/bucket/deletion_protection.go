@@ -0,0 +1,130 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	corev1 "k8s.io/api/core/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// Deletion protection guards buckets against the deletion of their Bucket,
+// for instance along with their namespace. Protected Buckets cannot be
+// deleted through the validating webhook, and the controller refuses to
+// delete a protected bucket, as well as any bucket which still holds objects
+// and is not force deleted, leaving it in place with the ACK.ResourceSynced
+// condition explaining why. The deletion is retried once the protection is
+// lifted or the bucket emptied.
+//
+// Buckets are protected through the deletion-protection annotation, which
+// defaults to the value of the --bucket-deletion-protection flag of the
+// controller. Like the services.k8s.aws/deletion-policy annotation, it is not
+// part of the spec, as it is about the Bucket rather than the bucket: it is
+// never sent to S3, and lifting it does not need the spec to be valid nor the
+// bucket to be reconciled.
+
+const (
+	// deletionBlockedRequeueDelay is how long to wait before checking again
+	// whether a bucket whose deletion was refused can be deleted.
+	deletionBlockedRequeueDelay = time.Minute
+
+	// Reasons of the ACK.ResourceSynced condition set when the deletion of a
+	// bucket is refused.
+	reasonDeletionProtected = "DeletionProtected"
+	reasonObjectLockEnabled = "ObjectLockEnabled"
+	reasonBucketNotEmpty    = "BucketNotEmpty"
+)
+
+// DefaultDeletionProtection is whether buckets without the
+// deletion-protection annotation are protected from deletion.
+var DefaultDeletionProtection bool
+
+// isDeletionProtected returns true if the supplied resource carries the
+// deletion-protection annotation set to "true", or does not carry it and
+// buckets are protected by default.
+func isDeletionProtected(r *resource) bool {
+	if r == nil || r.ko == nil {
+		return false
+	}
+	v, ok := r.ko.GetAnnotations()[svcapitypes.AnnotationDeletionProtection]
+	if !ok {
+		return DefaultDeletionProtection
+	}
+	return strings.EqualFold(v, "true")
+}
+
+// ensureDeletable checks whether the bucket may be deleted. The bucket is not
+// deleted if it is protected, or if it still holds objects and is not force
+// deleted, in which case the reason is set in the ACK.ResourceSynced
+// condition and the returned error requeues the deletion.
+func (rm *resourceManager) ensureDeletable(
+	ctx context.Context,
+	r *resource,
+) error {
+	bucketName := *r.ko.Spec.Name
+	if isDeletionProtected(r) {
+		return deletionBlocked(r, reasonDeletionProtected, fmt.Sprintf(
+			"bucket %q is protected from deletion, set the %s annotation to \"false\" to delete it",
+			bucketName, svcapitypes.AnnotationDeletionProtection,
+		))
+	}
+	// Force deleted buckets are emptied first, see emptyBucket
+	if isForceDeleteEnabled(r) {
+		return nil
+	}
+
+	if IsDirectoryBucketName(bucketName) {
+		holdsObjects, err := rm.holdsObjects(ctx, r)
+		if err != nil || !holdsObjects {
+			return err
+		}
+	} else {
+		versions, deleteMarkers, err := rm.holdsObjectVersions(ctx, r)
+		if err != nil || (!versions && !deleteMarkers) {
+			return err
+		}
+		locked, err := rm.isObjectLockEnabled(ctx, r)
+		if err != nil {
+			return err
+		}
+		if locked {
+			return deletionBlocked(r, reasonObjectLockEnabled, fmt.Sprintf(
+				"bucket %q has Object Lock enabled and still holds object versions, "+
+					"which must be removed before the bucket can be deleted",
+				bucketName,
+			))
+		}
+	}
+	return deletionBlocked(r, reasonBucketNotEmpty, fmt.Sprintf(
+		"bucket %q still holds objects, empty it or set the %s annotation to \"true\" to delete it",
+		bucketName, svcapitypes.AnnotationForceDelete,
+	))
+}
+
+// deletionBlocked sets the reason the bucket is not deleted in the
+// ACK.ResourceSynced condition, and returns an error requeueing the deletion.
+func deletionBlocked(r *resource, reason string, msg string) error {
+	ackcondition.SetSynced(r, corev1.ConditionFalse, &msg, &reason)
+	return ackrequeue.NeededAfter(
+		fmt.Errorf("refusing to delete bucket: %s", msg),
+		deletionBlockedRequeueDelay,
+	)
+}
@@ -0,0 +1,164 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// syncedConditionReason returns the reason of the ACK.ResourceSynced
+// condition of the resource, or "" if it has none.
+func syncedConditionReason(r *resource) string {
+	for _, cond := range r.ko.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeResourceSynced && cond.Reason != nil {
+			return *cond.Reason
+		}
+	}
+	return ""
+}
+
+func Test_isDeletionProtected(t *testing.T) {
+	assert := assert.New(t)
+	defer func(v bool) { DefaultDeletionProtection = v }(DefaultDeletionProtection)
+
+	r := newBucketResource("my-bucket")
+	DefaultDeletionProtection = false
+	assert.False(isDeletionProtected(r))
+	DefaultDeletionProtection = true
+	assert.True(isDeletionProtected(r))
+
+	// The annotation overrides the controller-wide default either way
+	r.ko.Annotations = map[string]string{svcapitypes.AnnotationDeletionProtection: "false"}
+	assert.False(isDeletionProtected(r))
+	DefaultDeletionProtection = false
+	r.ko.Annotations[svcapitypes.AnnotationDeletionProtection] = "true"
+	assert.True(isDeletionProtected(r))
+}
+
+// Test_sdkDelete_DeletionBlocked verifies that protected and non-empty
+// buckets are retained, with the reason set in the ACK.ResourceSynced
+// condition, while empty buckets are deleted.
+func Test_sdkDelete_DeletionBlocked(t *testing.T) {
+	versions := &svcsdk.ListObjectVersionsOutput{
+		Versions: []svcsdktypes.ObjectVersion{{Key: strPtr("a.txt"), VersionId: strPtr("v1")}},
+	}
+	objectLock := &svcsdk.GetObjectLockConfigurationOutput{
+		ObjectLockConfiguration: &svcsdktypes.ObjectLockConfiguration{
+			ObjectLockEnabled: svcsdktypes.ObjectLockEnabledEnabled,
+		},
+	}
+	tests := []struct {
+		name        string
+		bucketName  string
+		annotations map[string]string
+		results     map[string]opResult
+		wantReason  string
+	}{
+		{
+			name:        "protected",
+			bucketName:  "my-bucket",
+			annotations: map[string]string{svcapitypes.AnnotationDeletionProtection: "true"},
+			wantReason:  reasonDeletionProtected,
+		},
+		{
+			name:       "not empty",
+			bucketName: "my-bucket",
+			results: map[string]opResult{
+				"ListObjectVersions": {output: versions},
+			},
+			wantReason: reasonBucketNotEmpty,
+		},
+		{
+			name:       "object lock enabled and not empty",
+			bucketName: "my-bucket",
+			results: map[string]opResult{
+				"ListObjectVersions":         {output: versions},
+				"GetObjectLockConfiguration": {output: objectLock},
+			},
+			wantReason: reasonObjectLockEnabled,
+		},
+		{
+			name:       "directory bucket not empty",
+			bucketName: "my-bucket--usw2-az1--x-s3",
+			results: map[string]opResult{
+				"ListObjectsV2": {output: &svcsdk.ListObjectsV2Output{
+					Contents: []svcsdktypes.Object{{Key: strPtr("a.txt")}},
+				}},
+			},
+			wantReason: reasonBucketNotEmpty,
+		},
+		{
+			name:       "empty with object lock enabled",
+			bucketName: "my-bucket",
+			results: map[string]opResult{
+				"ListObjectVersions":         {output: &svcsdk.ListObjectVersionsOutput{}},
+				"GetObjectLockConfiguration": {output: objectLock},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := map[string]opResult{
+				"DeleteBucket": {err: errors.New("DeleteBucket called")},
+			}
+			for op, result := range tt.results {
+				results[op] = result
+			}
+			rm := &resourceManager{
+				sdkapi:  newMockedSDKClient(results),
+				metrics: ackmetrics.NewMetrics("s3"),
+			}
+			r := newBucketResource(tt.bucketName)
+			r.ko.Annotations = tt.annotations
+
+			latest, err := rm.sdkDelete(context.Background(), r)
+			if tt.wantReason == "" {
+				assert.ErrorContains(t, err, "DeleteBucket called")
+				return
+			}
+			var requeueNeededAfter *ackrequeue.RequeueNeededAfter
+			require.ErrorAs(t, err, &requeueNeededAfter)
+			require.NotNil(t, latest)
+			assert.Equal(t, tt.wantReason, syncedConditionReason(latest))
+		})
+	}
+}
+
+// Test_bucketValidator_ValidateDelete verifies that the deletion of protected
+// Buckets is rejected.
+func Test_bucketValidator_ValidateDelete(t *testing.T) {
+	ctx := context.Background()
+	v := &bucketValidator{}
+
+	ko := newBucketResource("my-bucket").ko
+	_, err := v.ValidateDelete(ctx, ko)
+	assert.NoError(t, err)
+
+	ko.Annotations = map[string]string{svcapitypes.AnnotationDeletionProtection: "true"}
+	_, err = v.ValidateDelete(ctx, ko)
+	assert.True(t, apierrors.IsForbidden(err), err)
+}
@@ -79,6 +79,13 @@ func newResourceDelta(
 			delta.Add("Spec.Analytics", a.ko.Spec.Analytics, b.ko.Spec.Analytics)
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.BucketClassName, b.ko.Spec.BucketClassName) {
+		delta.Add("Spec.BucketClassName", a.ko.Spec.BucketClassName, b.ko.Spec.BucketClassName)
+	} else if a.ko.Spec.BucketClassName != nil && b.ko.Spec.BucketClassName != nil {
+		if *a.ko.Spec.BucketClassName != *b.ko.Spec.BucketClassName {
+			delta.Add("Spec.BucketClassName", a.ko.Spec.BucketClassName, b.ko.Spec.BucketClassName)
+		}
+	}
 	if ackcompare.HasNilDifference(a.ko.Spec.CORS, b.ko.Spec.CORS) {
 		delta.Add("Spec.CORS", a.ko.Spec.CORS, b.ko.Spec.CORS)
 	} else if a.ko.Spec.CORS != nil && b.ko.Spec.CORS != nil {
@@ -237,6 +244,86 @@ func newResourceDelta(
 			}
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.ManagedFields, b.ko.Spec.ManagedFields) {
+		delta.Add("Spec.ManagedFields", a.ko.Spec.ManagedFields, b.ko.Spec.ManagedFields)
+	} else if a.ko.Spec.ManagedFields != nil && b.ko.Spec.ManagedFields != nil {
+		if *a.ko.Spec.ManagedFields != *b.ko.Spec.ManagedFields {
+			delta.Add("Spec.ManagedFields", a.ko.Spec.ManagedFields, b.ko.Spec.ManagedFields)
+		}
+	}
+	if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration, b.ko.Spec.MetadataConfiguration) {
+		delta.Add("Spec.MetadataConfiguration", a.ko.Spec.MetadataConfiguration, b.ko.Spec.MetadataConfiguration)
+	} else if a.ko.Spec.MetadataConfiguration != nil && b.ko.Spec.MetadataConfiguration != nil {
+		if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration) {
+			delta.Add("Spec.MetadataConfiguration.InventoryTableConfiguration", a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration)
+		} else if a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration != nil && b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration != nil {
+			if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState) {
+				delta.Add("Spec.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState", a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState)
+			} else if a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState != nil && b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState != nil {
+				if *a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState != *b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState {
+					delta.Add("Spec.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState", a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState)
+				}
+			}
+			if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration) {
+				delta.Add("Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration", a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration)
+			} else if a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration != nil && b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration != nil {
+				if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.KMSKeyARN, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.KMSKeyARN) {
+					delta.Add("Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.KMSKeyARN", a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.KMSKeyARN, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.KMSKeyARN)
+				} else if a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.KMSKeyARN != nil && b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.KMSKeyARN != nil {
+					if *a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.KMSKeyARN != *b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.KMSKeyARN {
+						delta.Add("Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.KMSKeyARN", a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.KMSKeyARN, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.KMSKeyARN)
+					}
+				}
+				if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.SSEAlgorithm, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.SSEAlgorithm) {
+					delta.Add("Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.SSEAlgorithm", a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.SSEAlgorithm, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.SSEAlgorithm)
+				} else if a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.SSEAlgorithm != nil && b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.SSEAlgorithm != nil {
+					if *a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.SSEAlgorithm != *b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.SSEAlgorithm {
+						delta.Add("Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.SSEAlgorithm", a.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.SSEAlgorithm, b.ko.Spec.MetadataConfiguration.InventoryTableConfiguration.EncryptionConfiguration.SSEAlgorithm)
+					}
+				}
+			}
+		}
+		if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration.JournalTableConfiguration, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration) {
+			delta.Add("Spec.MetadataConfiguration.JournalTableConfiguration", a.ko.Spec.MetadataConfiguration.JournalTableConfiguration, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration)
+		} else if a.ko.Spec.MetadataConfiguration.JournalTableConfiguration != nil && b.ko.Spec.MetadataConfiguration.JournalTableConfiguration != nil {
+			if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration) {
+				delta.Add("Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration", a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration)
+			} else if a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration != nil && b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration != nil {
+				if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.KMSKeyARN, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.KMSKeyARN) {
+					delta.Add("Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.KMSKeyARN", a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.KMSKeyARN, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.KMSKeyARN)
+				} else if a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.KMSKeyARN != nil && b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.KMSKeyARN != nil {
+					if *a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.KMSKeyARN != *b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.KMSKeyARN {
+						delta.Add("Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.KMSKeyARN", a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.KMSKeyARN, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.KMSKeyARN)
+					}
+				}
+				if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.SSEAlgorithm, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.SSEAlgorithm) {
+					delta.Add("Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.SSEAlgorithm", a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.SSEAlgorithm, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.SSEAlgorithm)
+				} else if a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.SSEAlgorithm != nil && b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.SSEAlgorithm != nil {
+					if *a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.SSEAlgorithm != *b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.SSEAlgorithm {
+						delta.Add("Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.SSEAlgorithm", a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.SSEAlgorithm, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration.SSEAlgorithm)
+					}
+				}
+			}
+			if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration) {
+				delta.Add("Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration", a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration)
+			} else if a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration != nil && b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration != nil {
+				if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days) {
+					delta.Add("Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days", a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days)
+				} else if a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days != nil && b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days != nil {
+					if *a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days != *b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days {
+						delta.Add("Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days", a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days)
+					}
+				}
+				if ackcompare.HasNilDifference(a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Expiration, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Expiration) {
+					delta.Add("Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Expiration", a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Expiration, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Expiration)
+				} else if a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Expiration != nil && b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Expiration != nil {
+					if *a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Expiration != *b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Expiration {
+						delta.Add("Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Expiration", a.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Expiration, b.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Expiration)
+					}
+				}
+			}
+		}
+	}
 	if len(a.ko.Spec.Metrics) != len(b.ko.Spec.Metrics) {
 		delta.Add("Spec.Metrics", a.ko.Spec.Metrics, b.ko.Spec.Metrics)
 	} else if len(a.ko.Spec.Metrics) > 0 {
@@ -348,6 +435,31 @@ func newResourceDelta(
 			delta.Add("Spec.Policy", a.ko.Spec.Policy, b.ko.Spec.Policy)
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.PolicyDocument, b.ko.Spec.PolicyDocument) {
+		delta.Add("Spec.PolicyDocument", a.ko.Spec.PolicyDocument, b.ko.Spec.PolicyDocument)
+	} else if a.ko.Spec.PolicyDocument != nil && b.ko.Spec.PolicyDocument != nil {
+		if ackcompare.HasNilDifference(a.ko.Spec.PolicyDocument.ID, b.ko.Spec.PolicyDocument.ID) {
+			delta.Add("Spec.PolicyDocument.ID", a.ko.Spec.PolicyDocument.ID, b.ko.Spec.PolicyDocument.ID)
+		} else if a.ko.Spec.PolicyDocument.ID != nil && b.ko.Spec.PolicyDocument.ID != nil {
+			if *a.ko.Spec.PolicyDocument.ID != *b.ko.Spec.PolicyDocument.ID {
+				delta.Add("Spec.PolicyDocument.ID", a.ko.Spec.PolicyDocument.ID, b.ko.Spec.PolicyDocument.ID)
+			}
+		}
+		if len(a.ko.Spec.PolicyDocument.Statements) != len(b.ko.Spec.PolicyDocument.Statements) {
+			delta.Add("Spec.PolicyDocument.Statements", a.ko.Spec.PolicyDocument.Statements, b.ko.Spec.PolicyDocument.Statements)
+		} else if len(a.ko.Spec.PolicyDocument.Statements) > 0 {
+			if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.PolicyDocument.Statements, b.ko.Spec.PolicyDocument.Statements) {
+				delta.Add("Spec.PolicyDocument.Statements", a.ko.Spec.PolicyDocument.Statements, b.ko.Spec.PolicyDocument.Statements)
+			}
+		}
+		if ackcompare.HasNilDifference(a.ko.Spec.PolicyDocument.Version, b.ko.Spec.PolicyDocument.Version) {
+			delta.Add("Spec.PolicyDocument.Version", a.ko.Spec.PolicyDocument.Version, b.ko.Spec.PolicyDocument.Version)
+		} else if a.ko.Spec.PolicyDocument.Version != nil && b.ko.Spec.PolicyDocument.Version != nil {
+			if *a.ko.Spec.PolicyDocument.Version != *b.ko.Spec.PolicyDocument.Version {
+				delta.Add("Spec.PolicyDocument.Version", a.ko.Spec.PolicyDocument.Version, b.ko.Spec.PolicyDocument.Version)
+			}
+		}
+	}
 	if ackcompare.HasNilDifference(a.ko.Spec.PublicAccessBlock, b.ko.Spec.PublicAccessBlock) {
 		delta.Add("Spec.PublicAccessBlock", a.ko.Spec.PublicAccessBlock, b.ko.Spec.PublicAccessBlock)
 	} else if a.ko.Spec.PublicAccessBlock != nil && b.ko.Spec.PublicAccessBlock != nil {
@@ -480,6 +592,25 @@ func newResourceDelta(
 			}
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.WriteConnectionDetailsTo, b.ko.Spec.WriteConnectionDetailsTo) {
+		delta.Add("Spec.WriteConnectionDetailsTo", a.ko.Spec.WriteConnectionDetailsTo, b.ko.Spec.WriteConnectionDetailsTo)
+	} else if a.ko.Spec.WriteConnectionDetailsTo != nil && b.ko.Spec.WriteConnectionDetailsTo != nil {
+		if ackcompare.HasNilDifference(a.ko.Spec.WriteConnectionDetailsTo.Kind, b.ko.Spec.WriteConnectionDetailsTo.Kind) {
+			delta.Add("Spec.WriteConnectionDetailsTo.Kind", a.ko.Spec.WriteConnectionDetailsTo.Kind, b.ko.Spec.WriteConnectionDetailsTo.Kind)
+		} else if a.ko.Spec.WriteConnectionDetailsTo.Kind != nil && b.ko.Spec.WriteConnectionDetailsTo.Kind != nil {
+			if *a.ko.Spec.WriteConnectionDetailsTo.Kind != *b.ko.Spec.WriteConnectionDetailsTo.Kind {
+				delta.Add("Spec.WriteConnectionDetailsTo.Kind", a.ko.Spec.WriteConnectionDetailsTo.Kind, b.ko.Spec.WriteConnectionDetailsTo.Kind)
+			}
+		}
+		if ackcompare.HasNilDifference(a.ko.Spec.WriteConnectionDetailsTo.Name, b.ko.Spec.WriteConnectionDetailsTo.Name) {
+			delta.Add("Spec.WriteConnectionDetailsTo.Name", a.ko.Spec.WriteConnectionDetailsTo.Name, b.ko.Spec.WriteConnectionDetailsTo.Name)
+		} else if a.ko.Spec.WriteConnectionDetailsTo.Name != nil && b.ko.Spec.WriteConnectionDetailsTo.Name != nil {
+			if *a.ko.Spec.WriteConnectionDetailsTo.Name != *b.ko.Spec.WriteConnectionDetailsTo.Name {
+				delta.Add("Spec.WriteConnectionDetailsTo.Name", a.ko.Spec.WriteConnectionDetailsTo.Name, b.ko.Spec.WriteConnectionDetailsTo.Name)
+			}
+		}
+	}
+	compareDestinationGrants(a, b, delta)
 
 	return delta
 }
@@ -0,0 +1,548 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
+)
+
+// Destination grants are the policy statements a destination bucket needs
+// for S3 to deliver the server access logs, inventory and analytics reports
+// of a source bucket, or for the replication role of the source bucket to
+// replicate objects into it. When the source bucket has the
+// AnnotationManageDestinationPolicies annotation, the controller merges these
+// statements into the policy of each destination set through a reference to
+// another Bucket, and removes them once the source no longer delivers to it,
+// loses the annotation or is deleted. The destinations holding the grants are
+// recorded in Status.DestinationGrants of the source.
+//
+// The statements are identified by their Sid, made of
+// destinationGrantSidPrefix, the kind of delivery and the name of the source
+// bucket. The destination bucket leaves these statements out of the policy
+// read back from S3 and keeps them when putting its own policy, so that its
+// spec does not have to know about its sources.
+
+// destinationGrantSidPrefix starts the Sid of every destination grant.
+const destinationGrantSidPrefix = "ACKDestinationGrant-"
+
+// destinationPolicyRequeueDelay is how long to wait before merging the
+// destination grants again into a policy that changed while they were.
+const destinationPolicyRequeueDelay = 5 * time.Second
+
+// The kinds of delivery destination grants are made for.
+const (
+	destinationGrantLogging               = "Logging"
+	destinationGrantInventoryAndAnalytics = "InventoryAndAnalytics"
+	destinationGrantReplication           = "Replication"
+)
+
+// destinationGrantSid returns the Sid of the destination grant of the given
+// kind made for the source bucket.
+func destinationGrantSid(kind, sourceBucket string) string {
+	return destinationGrantSidPrefix + kind + "-" + sourceBucket
+}
+
+// isDestinationGrant returns true if the statement is a destination grant.
+func isDestinationGrant(statement *iampolicy.Statement) bool {
+	return strings.HasPrefix(statement.Sid, destinationGrantSidPrefix)
+}
+
+// isExternalGrant returns true if the statement was put into the policy of
+// the bucket on behalf of another resource: a destination grant, or the grant
+// of a BucketAccess in the BucketPolicy mode.
+func isExternalGrant(statement *iampolicy.Statement) bool {
+	return isDestinationGrant(statement) ||
+		strings.HasPrefix(statement.Sid, iampolicy.BucketAccessSidPrefix)
+}
+
+// isDestinationPolicyManaged returns true if the controller manages the
+// destination grants of the given bucket.
+func isDestinationPolicyManaged(r *resource) bool {
+	if r == nil || r.ko == nil {
+		return false
+	}
+	v, ok := r.ko.GetAnnotations()[svcapitypes.AnnotationManageDestinationPolicies]
+	return ok && strings.EqualFold(v, "true")
+}
+
+// bucketNameFromARN returns the name of the bucket with the given ARN. Plain
+// bucket names are returned as is.
+func bucketNameFromARN(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
+
+// grantedDestination is a destination bucket set through a reference to
+// another Bucket, along with the kind of delivery to it.
+type grantedDestination struct {
+	kind   string
+	bucket string
+}
+
+// grantedDestinations returns the destinations the given source bucket makes
+// destination grants for.
+func grantedDestinations(r *resource) []grantedDestination {
+	var destinations []grantedDestination
+	if r.ko.Spec.Logging != nil && r.ko.Spec.Logging.LoggingEnabled != nil {
+		config := r.ko.Spec.Logging.LoggingEnabled
+		if config.TargetBucketRef != nil && config.TargetBucket != nil {
+			destinations = append(destinations, grantedDestination{destinationGrantLogging, *config.TargetBucket})
+		}
+	}
+
+	var reportDestinations []*string
+	for _, config := range r.ko.Spec.Inventory {
+		if dest := inventoryBucketDestination(config); dest != nil && dest.BucketRef != nil {
+			reportDestinations = append(reportDestinations, dest.Bucket)
+		}
+	}
+	for _, config := range r.ko.Spec.Analytics {
+		if dest := analyticsBucketDestination(config); dest != nil && dest.BucketRef != nil {
+			reportDestinations = append(reportDestinations, dest.Bucket)
+		}
+	}
+	for _, bucket := range reportDestinations {
+		if bucket != nil {
+			destinations = append(destinations, grantedDestination{destinationGrantInventoryAndAnalytics, bucketNameFromARN(*bucket)})
+		}
+	}
+
+	if r.ko.Spec.Replication != nil && r.ko.Spec.Replication.Role != nil {
+		for _, rule := range r.ko.Spec.Replication.Rules {
+			if rule == nil || rule.Destination == nil ||
+				rule.Destination.BucketRef == nil || rule.Destination.Bucket == nil {
+				continue
+			}
+			destinations = append(destinations, grantedDestination{destinationGrantReplication, bucketNameFromARN(*rule.Destination.Bucket)})
+		}
+	}
+	return destinations
+}
+
+// destinationGrants returns, for each destination bucket the given source
+// bucket references, the statements granting the delivery to it.
+func (rm *resourceManager) destinationGrants(
+	r *resource,
+) map[string][]*iampolicy.Statement {
+	source := aws.ToString(r.ko.Spec.Name)
+	sourceConditions := map[string]map[string][]string{
+		"ArnLike":      {"aws:SourceArn": {rm.bucketARN(source)}},
+		"StringEquals": {"aws:SourceAccount": {string(rm.awsAccountID)}},
+	}
+	grants := map[string][]*iampolicy.Statement{}
+	add := func(destination string, statement *iampolicy.Statement) {
+		for _, existing := range grants[destination] {
+			if existing.Sid == statement.Sid {
+				return
+			}
+		}
+		grants[destination] = append(grants[destination], statement)
+	}
+
+	for _, dest := range grantedDestinations(r) {
+		destination := dest.bucket
+		switch dest.kind {
+		case destinationGrantLogging:
+			add(destination, &iampolicy.Statement{
+				Sid:       destinationGrantSid(destinationGrantLogging, source),
+				Effect:    "Allow",
+				Principal: map[string][]string{"Service": {"logging.s3.amazonaws.com"}},
+				Action:    []string{"s3:PutObject"},
+				Resource:  []string{rm.bucketARN(destination) + "/" + aws.ToString(r.ko.Spec.Logging.LoggingEnabled.TargetPrefix) + "*"},
+				Condition: sourceConditions,
+			})
+		case destinationGrantInventoryAndAnalytics:
+			add(destination, &iampolicy.Statement{
+				Sid:       destinationGrantSid(destinationGrantInventoryAndAnalytics, source),
+				Effect:    "Allow",
+				Principal: map[string][]string{"Service": {"s3.amazonaws.com"}},
+				Action:    []string{"s3:PutObject"},
+				Resource:  []string{rm.bucketARN(destination) + "/*"},
+				Condition: map[string]map[string][]string{
+					"ArnLike": sourceConditions["ArnLike"],
+					"StringEquals": {
+						"aws:SourceAccount": {string(rm.awsAccountID)},
+						"s3:x-amz-acl":      {"bucket-owner-full-control"},
+					},
+				},
+			})
+		case destinationGrantReplication:
+			add(destination, &iampolicy.Statement{
+				Sid:       destinationGrantSid(destinationGrantReplication, source),
+				Effect:    "Allow",
+				Principal: map[string][]string{"AWS": {*r.ko.Spec.Replication.Role}},
+				Action: []string{
+					"s3:GetBucketVersioning",
+					"s3:PutBucketVersioning",
+					"s3:ReplicateDelete",
+					"s3:ReplicateObject",
+					"s3:ReplicateTags",
+				},
+				Resource: []string{rm.bucketARN(destination), rm.bucketARN(destination) + "/*"},
+			})
+		}
+	}
+	return grants
+}
+
+// destinationGrantBuckets returns the sorted names of the buckets the given
+// bucket makes destination grants in, or nil if its destination policies are
+// not managed.
+func destinationGrantBuckets(r *resource) []string {
+	if !isDestinationPolicyManaged(r) {
+		return nil
+	}
+	var names []string
+	for _, dest := range grantedDestinations(r) {
+		names = append(names, dest.bucket)
+	}
+	sort.Strings(names)
+	return slices.Compact(names)
+}
+
+// hasDestinationGrants returns true if the status of the given bucket records
+// buckets holding destination grants made for it.
+func hasDestinationGrants(r *resource) bool {
+	return r != nil && r.ko != nil && len(r.ko.Status.DestinationGrants) > 0
+}
+
+// destinationGrantsPath is the path of the difference between the buckets
+// the desired bucket makes destination grants in and the ones recorded in
+// the status of the latest bucket. It is under Spec so that the difference
+// alone gets the bucket updated, the grants living in other buckets.
+const destinationGrantsPath = "Spec.DestinationGrants"
+
+// compareDestinationGrants adds a difference to the delta when the buckets
+// the desired bucket makes destination grants in are not the ones recorded
+// in the status of the latest bucket, as happens once the destination
+// policies are no longer managed.
+func compareDestinationGrants(
+	a *resource,
+	b *resource,
+	delta *ackcompare.Delta,
+) {
+	desired := destinationGrantBuckets(a)
+	recorded := aws.ToStringSlice(b.ko.Status.DestinationGrants)
+	if !slices.Equal(desired, recorded) {
+		delta.Add(destinationGrantsPath, desired, recorded)
+	}
+}
+
+// destinationBuckets returns the names of all the buckets the given bucket
+// delivers to, whether set through references or not.
+func destinationBuckets(r *resource) []string {
+	var buckets []*string
+	if r.ko.Spec.Logging != nil && r.ko.Spec.Logging.LoggingEnabled != nil {
+		buckets = append(buckets, r.ko.Spec.Logging.LoggingEnabled.TargetBucket)
+	}
+	for _, config := range r.ko.Spec.Inventory {
+		if dest := inventoryBucketDestination(config); dest != nil {
+			buckets = append(buckets, dest.Bucket)
+		}
+	}
+	for _, config := range r.ko.Spec.Analytics {
+		if dest := analyticsBucketDestination(config); dest != nil {
+			buckets = append(buckets, dest.Bucket)
+		}
+	}
+	if r.ko.Spec.Replication != nil {
+		for _, rule := range r.ko.Spec.Replication.Rules {
+			if rule != nil && rule.Destination != nil {
+				buckets = append(buckets, rule.Destination.Bucket)
+			}
+		}
+	}
+	names := []string{}
+	for _, bucket := range buckets {
+		if bucket != nil && *bucket != "" {
+			names = append(names, bucketNameFromARN(*bucket))
+		}
+	}
+	return names
+}
+
+// syncDestinationPolicies puts the destination grants of the desired bucket
+// into the policies of its destinations, and removes them from the buckets it
+// delivered to in the latest state, or made grants in according to its
+// status, but no longer does. All of them are removed once the destination
+// policies of the bucket are no longer managed.
+func (rm *resourceManager) syncDestinationPolicies(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.syncDestinationPolicies")
+	defer exit(err)
+
+	grants := map[string][]*iampolicy.Statement{}
+	if isDestinationPolicyManaged(desired) {
+		grants = rm.destinationGrants(desired)
+	}
+	destinations := map[string]bool{}
+	for destination := range grants {
+		destinations[destination] = true
+	}
+	if latest != nil {
+		for _, destination := range destinationBuckets(latest) {
+			destinations[destination] = true
+		}
+		for _, destination := range latest.ko.Status.DestinationGrants {
+			destinations[aws.ToString(destination)] = true
+		}
+	}
+	names := make([]string, 0, len(destinations))
+	for destination := range destinations {
+		names = append(names, destination)
+	}
+	sort.Strings(names)
+
+	source := aws.ToString(desired.ko.Spec.Name)
+	for _, destination := range names {
+		if err := rm.putDestinationGrants(ctx, destination, source, grants[destination]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeDestinationGrants removes the destination grants of the given bucket
+// from the policies of all the buckets it delivers to or, according to its
+// status, made grants in.
+func (rm *resourceManager) removeDestinationGrants(
+	ctx context.Context,
+	r *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.removeDestinationGrants")
+	defer exit(err)
+
+	source := aws.ToString(r.ko.Spec.Name)
+	destinations := destinationBuckets(r)
+	destinations = append(destinations, aws.ToStringSlice(r.ko.Status.DestinationGrants)...)
+	sort.Strings(destinations)
+	for _, destination := range slices.Compact(destinations) {
+		if err := rm.putDestinationGrants(ctx, destination, source, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// putDestinationGrants replaces the destination grants made for the source
+// bucket in the policy of the destination bucket with the given statements.
+// The policy is only written if this changes it. When there is nothing to
+// grant, buckets that no longer exist or belong to another account are
+// skipped.
+//
+// The policy of the destination is read again right before it is written,
+// and the merge is retried on a later reconcile if it changed in between.
+// S3 has no conditional write for bucket policies, so this narrows the window
+// in which a concurrent change to the policy can be overwritten, without
+// closing it.
+func (rm *resourceManager) putDestinationGrants(
+	ctx context.Context,
+	destination string,
+	source string,
+	statements []*iampolicy.Statement,
+) error {
+	target, err := rm.managerForBucket(ctx, destination)
+	if err != nil {
+		if len(statements) == 0 {
+			return nil
+		}
+		return fmt.Errorf("cannot find destination bucket %q: %w", destination, err)
+	}
+	current, err := target.getPolicy(ctx, destination)
+	if err != nil {
+		return err
+	}
+
+	owned := map[string]bool{}
+	for _, kind := range []string{
+		destinationGrantLogging,
+		destinationGrantInventoryAndAnalytics,
+		destinationGrantReplication,
+	} {
+		owned[destinationGrantSid(kind, source)] = true
+	}
+
+	doc := &iampolicy.Document{Version: defaultPolicyDocumentVersion}
+	if current != nil {
+		if doc, err = iampolicy.Parse(*current); err != nil {
+			return fmt.Errorf("cannot merge destination grants into the policy of bucket %q: %w", destination, err)
+		}
+	}
+	merged := make([]*iampolicy.Statement, 0, len(doc.Statement)+len(statements))
+	for _, statement := range doc.Statement {
+		if !owned[statement.Sid] {
+			merged = append(merged, statement)
+		}
+	}
+	doc.Statement = append(merged, statements...)
+
+	r := &resource{ko: &svcapitypes.Bucket{}}
+	r.ko.Spec.Name = aws.String(destination)
+	if len(doc.Statement) == 0 {
+		if current == nil {
+			return nil
+		}
+		return target.deletePolicy(ctx, r)
+	}
+	policy, err := doc.Render()
+	if err != nil {
+		return err
+	}
+	if current != nil && iampolicy.Equal(*current, policy) {
+		return nil
+	}
+	if err := target.ensurePolicyUnchanged(ctx, destination, current); err != nil {
+		return err
+	}
+	return target.putPolicy(ctx, r, &policy, false)
+}
+
+// ensurePolicyUnchanged returns an error requeueing the reconcile if the
+// policy of the named bucket is no longer the given one.
+func (rm *resourceManager) ensurePolicyUnchanged(
+	ctx context.Context,
+	bucketName string,
+	policy *string,
+) error {
+	current, err := rm.getPolicy(ctx, bucketName)
+	if err != nil {
+		return err
+	}
+	if (current == nil) == (policy == nil) &&
+		(current == nil || iampolicy.Equal(*current, *policy)) {
+		return nil
+	}
+	return ackrequeue.NeededAfter(
+		fmt.Errorf("policy of bucket %q changed while merging destination grants into it", bucketName),
+		destinationPolicyRequeueDelay,
+	)
+}
+
+// getPolicy returns the policy of the named bucket, or nil if it has none.
+func (rm *resourceManager) getPolicy(
+	ctx context.Context,
+	bucketName string,
+) (*string, error) {
+	resp, err := rm.sdkapi.GetBucketPolicy(ctx, &svcsdk.GetBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetBucketPolicy", err)
+	if err != nil {
+		if awsErr, ok := ackerr.AWSError(err); ok && awsErr.ErrorCode() == "NoSuchBucketPolicy" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.Policy, nil
+}
+
+// withDestinationGrants returns the given bucket policy with the destination
+// grants, and the other external grants, currently in the policy of the
+// bucket merged in, so that putting the policy of a destination bucket does
+// not remove them. The policy may be nil, in which case only the grants are
+// returned, or nil if there are none.
+func (rm *resourceManager) withDestinationGrants(
+	ctx context.Context,
+	r *resource,
+	policy *string,
+) (*string, error) {
+	current, err := rm.getPolicy(ctx, aws.ToString(r.ko.Spec.Name))
+	if err != nil || current == nil {
+		return policy, err
+	}
+	currentDoc, err := iampolicy.Parse(*current)
+	if err != nil {
+		// The policy is replaced as a whole anyway
+		return policy, nil
+	}
+	var grants []*iampolicy.Statement
+	for _, statement := range currentDoc.Statement {
+		if isExternalGrant(statement) {
+			grants = append(grants, statement)
+		}
+	}
+	if len(grants) == 0 {
+		return policy, nil
+	}
+
+	doc := &iampolicy.Document{Version: defaultPolicyDocumentVersion}
+	if policy != nil {
+		if doc, err = iampolicy.Parse(*policy); err != nil {
+			return nil, fmt.Errorf("cannot merge destination grants into bucket policy: %w", err)
+		}
+	}
+	merged := make([]*iampolicy.Statement, 0, len(doc.Statement)+len(grants))
+	for _, statement := range doc.Statement {
+		if !isExternalGrant(statement) {
+			merged = append(merged, statement)
+		}
+	}
+	doc.Statement = append(merged, grants...)
+
+	rendered, err := doc.Render()
+	if err != nil {
+		return nil, err
+	}
+	return &rendered, nil
+}
+
+// withoutDestinationGrants returns the given bucket policy, as read from S3,
+// without the destination grants and the other external grants, or nil if
+// no other statement is left.
+func withoutDestinationGrants(policy *string) *string {
+	if policy == nil {
+		return nil
+	}
+	doc, err := iampolicy.Parse(*policy)
+	if err != nil {
+		return policy
+	}
+	remaining := make([]*iampolicy.Statement, 0, len(doc.Statement))
+	for _, statement := range doc.Statement {
+		if !isExternalGrant(statement) {
+			remaining = append(remaining, statement)
+		}
+	}
+	if len(remaining) == len(doc.Statement) {
+		return policy
+	}
+	if len(remaining) == 0 {
+		return nil
+	}
+	doc.Statement = remaining
+	rendered, err := doc.Render()
+	if err != nil {
+		return policy
+	}
+	return &rendered
+}
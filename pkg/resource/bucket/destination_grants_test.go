@@ -0,0 +1,372 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	smithymiddleware "github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
+)
+
+// withPutBucketPolicyCapture records the input of every PutBucketPolicy call
+// of a mocked client into inputs.
+func withPutBucketPolicyCapture(inputs *[]*svcsdk.PutBucketPolicyInput) func(*svcsdk.Options) {
+	return func(o *svcsdk.Options) {
+		o.APIOptions = append(o.APIOptions, func(stack *smithymiddleware.Stack) error {
+			return stack.Initialize.Add(smithymiddleware.InitializeMiddlewareFunc(
+				"capturePutBucketPolicy",
+				func(
+					ctx context.Context,
+					in smithymiddleware.InitializeInput,
+					next smithymiddleware.InitializeHandler,
+				) (smithymiddleware.InitializeOutput, smithymiddleware.Metadata, error) {
+					if input, ok := in.Parameters.(*svcsdk.PutBucketPolicyInput); ok {
+						*inputs = append(*inputs, input)
+					}
+					return next.HandleInitialize(ctx, in)
+				},
+			), smithymiddleware.Before)
+		})
+	}
+}
+
+// withGetBucketPolicySequence answers the GetBucketPolicy calls of a mocked
+// client with the given policies, in turn.
+func withGetBucketPolicySequence(policies ...string) func(*svcsdk.Options) {
+	return func(o *svcsdk.Options) {
+		o.APIOptions = append(o.APIOptions, func(stack *smithymiddleware.Stack) error {
+			return stack.Initialize.Add(smithymiddleware.InitializeMiddlewareFunc(
+				"getBucketPolicySequence",
+				func(
+					ctx context.Context,
+					in smithymiddleware.InitializeInput,
+					next smithymiddleware.InitializeHandler,
+				) (smithymiddleware.InitializeOutput, smithymiddleware.Metadata, error) {
+					if _, ok := in.Parameters.(*svcsdk.GetBucketPolicyInput); ok && len(policies) > 0 {
+						policy := policies[0]
+						policies = policies[1:]
+						return smithymiddleware.InitializeOutput{
+							Result: &svcsdk.GetBucketPolicyOutput{Policy: aws.String(policy)},
+						}, smithymiddleware.Metadata{}, nil
+					}
+					return next.HandleInitialize(ctx, in)
+				},
+			), smithymiddleware.Before)
+		})
+	}
+}
+
+func newDestinationGrantsResourceManager(
+	results map[string]opResult,
+	inputs *[]*svcsdk.PutBucketPolicyInput,
+) *resourceManager {
+	if results == nil {
+		results = map[string]opResult{}
+	}
+	results["PutBucketPolicy"] = opResult{output: &svcsdk.PutBucketPolicyOutput{}}
+	results["DeleteBucketPolicy"] = opResult{output: &svcsdk.DeleteBucketPolicyOutput{}}
+	return &resourceManager{
+		sdkapi:       newMockedSDKClient(results, withPutBucketPolicyCapture(inputs)),
+		metrics:      ackmetrics.NewMetrics("s3"),
+		awsAccountID: "111122223333",
+		awsRegion:    "us-west-2",
+		awsPartition: "aws",
+	}
+}
+
+// policySids returns the Sids of the statements of the given policy.
+func policySids(t *testing.T, policy *string) []string {
+	require.NotNil(t, policy)
+	doc, err := iampolicy.Parse(*policy)
+	require.NoError(t, err)
+	sids := []string{}
+	for _, statement := range doc.Statement {
+		sids = append(sids, statement.Sid)
+	}
+	return sids
+}
+
+// Test_destinationGrants verifies that grants are only computed for the
+// destinations set through references, one per kind of delivery.
+func Test_destinationGrants(t *testing.T) {
+	assert := assert.New(t)
+
+	rm := newDestinationGrantsResourceManager(nil, nil)
+	source := newBucketResource("source")
+	source.ko.Spec.Logging = &svcapitypes.BucketLoggingStatus{
+		LoggingEnabled: &svcapitypes.LoggingEnabled{
+			TargetBucket:    aws.String("logs"),
+			TargetBucketRef: newReference("logs"),
+			TargetPrefix:    aws.String("source/"),
+		},
+	}
+	source.ko.Spec.Inventory = []*svcapitypes.InventoryConfiguration{{
+		ID: aws.String("weekly"),
+		Destination: &svcapitypes.InventoryDestination{
+			S3BucketDestination: &svcapitypes.InventoryS3BucketDestination{
+				Bucket:    aws.String("arn:aws:s3:::reports"),
+				BucketRef: newReference("reports"),
+			},
+		},
+	}}
+	source.ko.Spec.Analytics = []*svcapitypes.AnalyticsConfiguration{{
+		ID: aws.String("daily"),
+		StorageClassAnalysis: &svcapitypes.StorageClassAnalysis{
+			DataExport: &svcapitypes.StorageClassAnalysisDataExport{
+				Destination: &svcapitypes.AnalyticsExportDestination{
+					S3BucketDestination: &svcapitypes.AnalyticsS3BucketDestination{
+						Bucket:    aws.String("arn:aws:s3:::reports"),
+						BucketRef: newReference("reports"),
+					},
+				},
+			},
+		},
+	}}
+	source.ko.Spec.Replication = &svcapitypes.ReplicationConfiguration{
+		Role: aws.String("arn:aws:iam::111122223333:role/replication"),
+		Rules: []*svcapitypes.ReplicationRule{
+			{Destination: &svcapitypes.Destination{
+				Bucket:    aws.String("arn:aws:s3:::replica"),
+				BucketRef: newReference("replica"),
+			}},
+			// Destinations set without a reference are left alone
+			{Destination: &svcapitypes.Destination{
+				Bucket: aws.String("arn:aws:s3:::external"),
+			}},
+		},
+	}
+
+	grants := rm.destinationGrants(source)
+	assert.Len(grants, 3)
+
+	require.Len(t, grants["logs"], 1)
+	logging := grants["logs"][0]
+	assert.Equal("ACKDestinationGrant-Logging-source", logging.Sid)
+	assert.Equal([]string{"logging.s3.amazonaws.com"}, logging.Principal["Service"])
+	assert.Equal([]string{"arn:aws:s3:::logs/source/*"}, logging.Resource)
+	assert.Equal([]string{"arn:aws:s3:::source"}, logging.Condition["ArnLike"]["aws:SourceArn"])
+
+	// Inventory and analytics share a single grant
+	require.Len(t, grants["reports"], 1)
+	assert.Equal("ACKDestinationGrant-InventoryAndAnalytics-source", grants["reports"][0].Sid)
+	assert.Equal([]string{"s3.amazonaws.com"}, grants["reports"][0].Principal["Service"])
+
+	require.Len(t, grants["replica"], 1)
+	assert.Equal("ACKDestinationGrant-Replication-source", grants["replica"][0].Sid)
+	assert.Equal([]string{"arn:aws:iam::111122223333:role/replication"}, grants["replica"][0].Principal["AWS"])
+	assert.Equal([]string{"arn:aws:s3:::replica", "arn:aws:s3:::replica/*"}, grants["replica"][0].Resource)
+}
+
+// Test_syncDestinationPolicies verifies that the grants are merged into the
+// policy of the new destination and removed from the former one, leaving the
+// other statements of their policies in place.
+func Test_syncDestinationPolicies(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	current := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Sid": "Owner", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::111122223333:root"}, "Action": "s3:*", "Resource": "*"},
+			{"Sid": "ACKDestinationGrant-Logging-source", "Effect": "Allow", "Principal": {"Service": "logging.s3.amazonaws.com"}, "Action": "s3:PutObject", "Resource": "arn:aws:s3:::old-logs/*"}
+		]
+	}`
+	var inputs []*svcsdk.PutBucketPolicyInput
+	rm := newDestinationGrantsResourceManager(map[string]opResult{
+		"GetBucketPolicy": {output: &svcsdk.GetBucketPolicyOutput{Policy: aws.String(current)}},
+	}, &inputs)
+
+	desired := newBucketResource("source")
+	desired.ko.Annotations = map[string]string{svcapitypes.AnnotationManageDestinationPolicies: "true"}
+	desired.ko.Spec.Logging = &svcapitypes.BucketLoggingStatus{
+		LoggingEnabled: &svcapitypes.LoggingEnabled{
+			TargetBucket:    aws.String("logs"),
+			TargetBucketRef: newReference("logs"),
+		},
+	}
+	latest := newBucketResource("source")
+	latest.ko.Spec.Logging = &svcapitypes.BucketLoggingStatus{
+		LoggingEnabled: &svcapitypes.LoggingEnabled{
+			TargetBucket: aws.String("old-logs"),
+		},
+	}
+
+	require.NoError(rm.syncDestinationPolicies(context.Background(), desired, latest))
+	require.Len(inputs, 2)
+
+	assert.Equal("logs", *inputs[0].Bucket)
+	assert.Equal([]string{"Owner", "ACKDestinationGrant-Logging-source"}, policySids(t, inputs[0].Policy))
+	assert.Contains(*inputs[0].Policy, "arn:aws:s3:::logs/*")
+
+	assert.Equal("old-logs", *inputs[1].Bucket)
+	assert.Equal([]string{"Owner"}, policySids(t, inputs[1].Policy))
+
+	// A destination set without a reference gets no grant, so the one made
+	// while it was referenced is removed
+	inputs = nil
+	rm = newDestinationGrantsResourceManager(map[string]opResult{
+		"GetBucketPolicy": {output: &svcsdk.GetBucketPolicyOutput{Policy: aws.String(current)}},
+	}, &inputs)
+	desired.ko.Spec.Logging.LoggingEnabled.TargetBucket = aws.String("old-logs")
+	desired.ko.Spec.Logging.LoggingEnabled.TargetBucketRef = nil
+	require.NoError(rm.syncDestinationPolicies(context.Background(), desired, latest))
+	require.Len(inputs, 1)
+	assert.Equal("old-logs", *inputs[0].Bucket)
+	assert.Equal([]string{"Owner"}, policySids(t, inputs[0].Policy))
+}
+
+// Test_syncDestinationPolicies_Unmanaged verifies that the grants recorded in
+// the status of the bucket are revoked once its destination policies are no
+// longer managed, even though it still delivers to the same bucket.
+func Test_syncDestinationPolicies_Unmanaged(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	current := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Sid": "Owner", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::111122223333:root"}, "Action": "s3:*", "Resource": "*"},
+			{"Sid": "ACKDestinationGrant-Logging-source", "Effect": "Allow", "Principal": {"Service": "logging.s3.amazonaws.com"}, "Action": "s3:PutObject", "Resource": "arn:aws:s3:::logs/*"}
+		]
+	}`
+	var inputs []*svcsdk.PutBucketPolicyInput
+	rm := newDestinationGrantsResourceManager(map[string]opResult{
+		"GetBucketPolicy": {output: &svcsdk.GetBucketPolicyOutput{Policy: aws.String(current)}},
+	}, &inputs)
+
+	desired := newBucketResource("source")
+	desired.ko.Spec.Logging = &svcapitypes.BucketLoggingStatus{
+		LoggingEnabled: &svcapitypes.LoggingEnabled{
+			TargetBucket:    aws.String("logs"),
+			TargetBucketRef: newReference("logs"),
+		},
+	}
+	assert.Nil(destinationGrantBuckets(desired))
+	latest := newBucketResource("source")
+	latest.ko.Spec.Logging = desired.ko.Spec.Logging.DeepCopy()
+	latest.ko.Status.DestinationGrants = []*string{aws.String("logs")}
+
+	require.NoError(rm.syncDestinationPolicies(context.Background(), desired, latest))
+	require.Len(inputs, 1)
+	assert.Equal("logs", *inputs[0].Bucket)
+	assert.Equal([]string{"Owner"}, policySids(t, inputs[0].Policy))
+
+	// The update stops recording the grants once they are revoked
+	updated, err := rm.customUpdateBucket(context.Background(), desired, latest, deltaAt(destinationGrantsPath))
+	require.NoError(err)
+	assert.Empty(updated.ko.Status.DestinationGrants)
+}
+
+// Test_putDestinationGrants_PolicyChanged verifies that the policy of the
+// destination is not written when it changed since it was read, and that the
+// merge is retried instead.
+func Test_putDestinationGrants_PolicyChanged(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	read := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Sid": "Owner", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::111122223333:root"}, "Action": "s3:*", "Resource": "*"}
+		]
+	}`
+	changed := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Sid": "Owner", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::111122223333:root"}, "Action": "s3:*", "Resource": "*"},
+			{"Sid": "ReadOnly", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::444455556666:root"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::logs/*"}
+		]
+	}`
+	grant := &iampolicy.Statement{
+		Sid:       destinationGrantSid(destinationGrantLogging, "source"),
+		Effect:    "Allow",
+		Principal: map[string][]string{"Service": {"logging.s3.amazonaws.com"}},
+		Action:    []string{"s3:PutObject"},
+		Resource:  []string{"arn:aws:s3:::logs/*"},
+	}
+
+	var inputs []*svcsdk.PutBucketPolicyInput
+	rm := newDestinationGrantsResourceManager(nil, &inputs)
+	rm.sdkapi = newMockedSDKClient(map[string]opResult{
+		"PutBucketPolicy": {output: &svcsdk.PutBucketPolicyOutput{}},
+	}, withPutBucketPolicyCapture(&inputs), withGetBucketPolicySequence(read, changed))
+	err := rm.putDestinationGrants(context.Background(), "logs", "source", []*iampolicy.Statement{grant})
+	var requeueErr *ackrequeue.RequeueNeededAfter
+	require.ErrorAs(err, &requeueErr)
+	assert.Contains(err.Error(), `policy of bucket "logs" changed`)
+	assert.Empty(inputs)
+
+	rm.sdkapi = newMockedSDKClient(map[string]opResult{
+		"PutBucketPolicy": {output: &svcsdk.PutBucketPolicyOutput{}},
+	}, withPutBucketPolicyCapture(&inputs), withGetBucketPolicySequence(read, read))
+	require.NoError(rm.putDestinationGrants(context.Background(), "logs", "source", []*iampolicy.Statement{grant}))
+	require.Len(inputs, 1)
+	assert.Equal([]string{"Owner", "ACKDestinationGrant-Logging-source"}, policySids(t, inputs[0].Policy))
+}
+
+// Test_DestinationGrants_DestinationPolicy verifies that the destination
+// bucket leaves the grants made by its sources, and by BucketAccesses, out of
+// its observed policy, and keeps them when putting its own policy.
+func Test_DestinationGrants_DestinationPolicy(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	current := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Sid": "ReadOnly", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::111122223333:root"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::logs/*"},
+			{"Sid": "ACKDestinationGrant-Logging-source", "Effect": "Allow", "Principal": {"Service": "logging.s3.amazonaws.com"}, "Action": "s3:PutObject", "Resource": "arn:aws:s3:::logs/*"},
+			{"Sid": "ACKBucketAccess-team-a.reader.0", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::111122223333:role/app"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::logs/*"}
+		]
+	}`
+	var inputs []*svcsdk.PutBucketPolicyInput
+	rm := newDestinationGrantsResourceManager(map[string]opResult{
+		"GetBucketPolicy": {output: &svcsdk.GetBucketPolicyOutput{Policy: aws.String(current)}},
+	}, &inputs)
+
+	destination := newBucketResource("logs")
+	destination.ko.Spec.Policy = aws.String(`{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Sid": "ReadOnly", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::111122223333:root"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::logs/*"}
+		]
+	}`)
+
+	observed := destination.ko.DeepCopy()
+	rm.setResourcePolicy(destination, observed, aws.String(current))
+	assert.Equal([]string{"ReadOnly"}, policySids(t, observed.Spec.Policy))
+
+	destination.ko.Spec.Policy = aws.String(`{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Sid": "ReadWrite", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::111122223333:root"}, "Action": ["s3:GetObject", "s3:PutObject"], "Resource": "arn:aws:s3:::logs/*"}
+		]
+	}`)
+	require.NoError(rm.syncPolicy(context.Background(), destination, false))
+	require.Len(inputs, 1)
+	assert.Equal(
+		[]string{"ReadWrite", "ACKDestinationGrant-Logging-source", "ACKBucketAccess-team-a.reader.0"},
+		policySids(t, inputs[0].Policy),
+	)
+}
@@ -0,0 +1,347 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	corev1 "k8s.io/api/core/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+const (
+	// forceDeleteMaxPagesPerReconcile bounds the number of list pages (each
+	// holding up to 1000 keys) that are processed in a single reconcile, so
+	// that emptying a very large bucket does not hold a worker indefinitely.
+	// Any remaining objects are picked up on the next reconcile.
+	forceDeleteMaxPagesPerReconcile = 10
+	// forceDeleteRequeueDelay is how long to wait before resuming emptying a
+	// bucket that still holds objects.
+	forceDeleteRequeueDelay = 5 * time.Second
+)
+
+// isForceDeleteEnabled returns true if the supplied resource carries the
+// force-delete annotation set to "true".
+func isForceDeleteEnabled(r *resource) bool {
+	if r == nil || r.ko == nil {
+		return false
+	}
+	v, ok := r.ko.GetAnnotations()[svcapitypes.AnnotationForceDelete]
+	return ok && strings.EqualFold(v, "true")
+}
+
+// requeueWaitWhileEmptying is returned by sdkDelete while the bucket still
+// holds objects that have to be removed before DeleteBucket can succeed.
+var requeueWaitWhileEmptying = ackrequeue.NeededAfter(
+	fmt.Errorf("bucket is not empty, emptying before deletion"),
+	forceDeleteRequeueDelay,
+)
+
+// emptyBucket removes incomplete multipart uploads, object versions and
+// delete markers from the bucket. It processes at most
+// forceDeleteMaxPagesPerReconcile pages of each listing and returns true once
+// the bucket holds nothing else. Because every page that is listed is deleted,
+// the next call simply restarts the listing, which keeps the operation
+// resumable across reconciles and controller restarts.
+//
+// A terminal error is returned when Object Lock is enabled on a bucket that
+// still holds object versions, since retention settings or legal holds would
+// make the deletion fail part-way through.
+func (rm *resourceManager) emptyBucket(
+	ctx context.Context,
+	r *resource,
+) (emptied bool, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.emptyBucket")
+	defer func() {
+		exit(err)
+	}()
+
+	isDirectoryBucket := IsDirectoryBucketName(*r.ko.Spec.Name)
+
+	if !isDirectoryBucket {
+		if err := rm.ensureNotObjectLocked(ctx, r); err != nil {
+			return false, err
+		}
+	}
+
+	var aborted, deleted int64
+	defer func() {
+		recordForceDeleteProgress(r, aborted, deleted)
+	}()
+
+	aborted, moreUploads, err := rm.abortMultipartUploads(ctx, r)
+	if err != nil {
+		return false, err
+	}
+	var moreObjects bool
+	if isDirectoryBucket {
+		deleted, moreObjects, err = rm.deleteObjects(ctx, r)
+	} else {
+		deleted, moreObjects, err = rm.deleteObjectVersions(ctx, r)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return !moreUploads && !moreObjects, nil
+}
+
+// ensureNotObjectLocked returns a terminal error if Object Lock is enabled on
+// the bucket and the bucket still holds at least one object version.
+func (rm *resourceManager) ensureNotObjectLocked(
+	ctx context.Context,
+	r *resource,
+) error {
+	locked, err := rm.isObjectLockEnabled(ctx, r)
+	if err != nil || !locked {
+		return err
+	}
+	// Delete markers are not subject to retention, so they do not count
+	holdsVersions, _, err := rm.holdsObjectVersions(ctx, r)
+	if err != nil || !holdsVersions {
+		return err
+	}
+	return ackerr.NewTerminalError(fmt.Errorf(
+		"refusing to force delete bucket %q: Object Lock is enabled and the "+
+			"bucket still holds object versions that may be protected by a "+
+			"retention period or legal hold. Remove the %s annotation and "+
+			"empty the bucket manually",
+		*r.ko.Spec.Name, svcapitypes.AnnotationForceDelete,
+	))
+}
+
+// isObjectLockEnabled returns whether Object Lock is enabled on the bucket.
+func (rm *resourceManager) isObjectLockEnabled(
+	ctx context.Context,
+	r *resource,
+) (bool, error) {
+	lockResp, err := rm.sdkapi.GetObjectLockConfiguration(ctx, rm.newGetBucketObjectLockConfigurationPayload(r))
+	rm.metrics.RecordAPICall("READ_ONE", "GetObjectLockConfiguration", err)
+	if err != nil {
+		if awsErr, ok := ackerr.AWSError(err); ok && awsErr.ErrorCode() == "ObjectLockConfigurationNotFoundError" {
+			return false, nil
+		}
+		return false, err
+	}
+	return lockResp.ObjectLockConfiguration != nil &&
+		lockResp.ObjectLockConfiguration.ObjectLockEnabled == svcsdktypes.ObjectLockEnabledEnabled, nil
+}
+
+// holdsObjectVersions returns whether the general purpose bucket holds at
+// least one object version, and at least one delete marker.
+func (rm *resourceManager) holdsObjectVersions(
+	ctx context.Context,
+	r *resource,
+) (versions bool, deleteMarkers bool, err error) {
+	listResp, err := rm.sdkapi.ListObjectVersions(ctx, &svcsdk.ListObjectVersionsInput{
+		Bucket:  r.ko.Spec.Name,
+		MaxKeys: aws.Int32(1),
+	})
+	rm.metrics.RecordAPICall("READ_MANY", "ListObjectVersions", err)
+	if err != nil {
+		return false, false, err
+	}
+	return len(listResp.Versions) > 0, len(listResp.DeleteMarkers) > 0, nil
+}
+
+// holdsObjects returns whether the directory bucket holds at least one
+// object.
+func (rm *resourceManager) holdsObjects(
+	ctx context.Context,
+	r *resource,
+) (bool, error) {
+	listResp, err := rm.sdkapi.ListObjectsV2(ctx, &svcsdk.ListObjectsV2Input{
+		Bucket:  r.ko.Spec.Name,
+		MaxKeys: aws.Int32(1),
+	})
+	rm.metrics.RecordAPICall("READ_MANY", "ListObjectsV2", err)
+	if err != nil {
+		return false, err
+	}
+	return len(listResp.Contents) > 0, nil
+}
+
+// abortMultipartUploads aborts incomplete multipart uploads in the bucket.
+// It returns the number of uploads aborted and whether more uploads remain.
+func (rm *resourceManager) abortMultipartUploads(
+	ctx context.Context,
+	r *resource,
+) (aborted int64, more bool, err error) {
+	input := &svcsdk.ListMultipartUploadsInput{
+		Bucket: r.ko.Spec.Name,
+	}
+	for page := 0; page < forceDeleteMaxPagesPerReconcile; page++ {
+		resp, err := rm.sdkapi.ListMultipartUploads(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "ListMultipartUploads", err)
+		if err != nil {
+			return aborted, false, err
+		}
+		for _, upload := range resp.Uploads {
+			_, err = rm.sdkapi.AbortMultipartUpload(ctx, &svcsdk.AbortMultipartUploadInput{
+				Bucket:   r.ko.Spec.Name,
+				Key:      upload.Key,
+				UploadId: upload.UploadId,
+			})
+			rm.metrics.RecordAPICall("DELETE", "AbortMultipartUpload", err)
+			if err != nil {
+				// The upload may have been completed or aborted concurrently
+				if awsErr, ok := ackerr.AWSError(err); ok && awsErr.ErrorCode() == "NoSuchUpload" {
+					continue
+				}
+				return aborted, false, err
+			}
+			aborted++
+		}
+		if !aws.ToBool(resp.IsTruncated) {
+			return aborted, false, nil
+		}
+		input.KeyMarker = resp.NextKeyMarker
+		input.UploadIdMarker = resp.NextUploadIdMarker
+	}
+	return aborted, true, nil
+}
+
+// deleteObjectVersions batch-deletes every object version and delete marker
+// in a general purpose bucket. It returns the number of entries deleted and
+// whether more entries remain.
+func (rm *resourceManager) deleteObjectVersions(
+	ctx context.Context,
+	r *resource,
+) (deleted int64, more bool, err error) {
+	input := &svcsdk.ListObjectVersionsInput{
+		Bucket: r.ko.Spec.Name,
+	}
+	for page := 0; page < forceDeleteMaxPagesPerReconcile; page++ {
+		resp, err := rm.sdkapi.ListObjectVersions(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "ListObjectVersions", err)
+		if err != nil {
+			return deleted, false, err
+		}
+		objects := make([]svcsdktypes.ObjectIdentifier, 0, len(resp.Versions)+len(resp.DeleteMarkers))
+		for _, v := range resp.Versions {
+			objects = append(objects, svcsdktypes.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
+		}
+		for _, m := range resp.DeleteMarkers {
+			objects = append(objects, svcsdktypes.ObjectIdentifier{Key: m.Key, VersionId: m.VersionId})
+		}
+		n, err := rm.deleteObjectBatch(ctx, r, objects)
+		deleted += n
+		if err != nil {
+			return deleted, false, err
+		}
+		if !aws.ToBool(resp.IsTruncated) {
+			return deleted, false, nil
+		}
+		input.KeyMarker = resp.NextKeyMarker
+		input.VersionIdMarker = resp.NextVersionIdMarker
+	}
+	return deleted, true, nil
+}
+
+// deleteObjects batch-deletes every object in a directory bucket. Directory
+// buckets do not support versioning, so ListObjectsV2 is used instead of
+// ListObjectVersions. It returns the number of objects deleted and whether
+// more objects remain.
+func (rm *resourceManager) deleteObjects(
+	ctx context.Context,
+	r *resource,
+) (deleted int64, more bool, err error) {
+	input := &svcsdk.ListObjectsV2Input{
+		Bucket: r.ko.Spec.Name,
+	}
+	for page := 0; page < forceDeleteMaxPagesPerReconcile; page++ {
+		resp, err := rm.sdkapi.ListObjectsV2(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "ListObjectsV2", err)
+		if err != nil {
+			return deleted, false, err
+		}
+		objects := make([]svcsdktypes.ObjectIdentifier, 0, len(resp.Contents))
+		for _, o := range resp.Contents {
+			objects = append(objects, svcsdktypes.ObjectIdentifier{Key: o.Key})
+		}
+		n, err := rm.deleteObjectBatch(ctx, r, objects)
+		deleted += n
+		if err != nil {
+			return deleted, false, err
+		}
+		if !aws.ToBool(resp.IsTruncated) {
+			return deleted, false, nil
+		}
+		input.ContinuationToken = resp.NextContinuationToken
+	}
+	return deleted, true, nil
+}
+
+// deleteObjectBatch deletes up to 1000 objects with a single DeleteObjects
+// call. Per-key failures are reported in the response rather than as an API
+// error, so they are collected and returned as a single error.
+func (rm *resourceManager) deleteObjectBatch(
+	ctx context.Context,
+	r *resource,
+	objects []svcsdktypes.ObjectIdentifier,
+) (int64, error) {
+	if len(objects) == 0 {
+		return 0, nil
+	}
+	resp, err := rm.sdkapi.DeleteObjects(ctx, &svcsdk.DeleteObjectsInput{
+		Bucket: r.ko.Spec.Name,
+		Delete: &svcsdktypes.Delete{
+			Objects: objects,
+			Quiet:   aws.Bool(true),
+		},
+	})
+	rm.metrics.RecordAPICall("DELETE", "DeleteObjects", err)
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Errors) == 0 {
+		return int64(len(objects)), nil
+	}
+	first := resp.Errors[0]
+	return int64(len(objects) - len(resp.Errors)), fmt.Errorf(
+		"failed to delete %d of %d objects, first error on key %q: %s: %s",
+		len(resp.Errors), len(objects),
+		aws.ToString(first.Key), aws.ToString(first.Code), aws.ToString(first.Message),
+	)
+}
+
+// recordForceDeleteProgress adds the supplied counts to the cumulative
+// force-delete progress fields in the resource's Status and reflects them in
+// the ACK.ResourceSynced condition.
+func recordForceDeleteProgress(
+	r *resource,
+	aborted int64,
+	deleted int64,
+) {
+	ko := r.ko
+	ko.Status.ForceDeleteUploadsAborted = aws.Int64(aws.ToInt64(ko.Status.ForceDeleteUploadsAborted) + aborted)
+	ko.Status.ForceDeleteObjectsDeleted = aws.Int64(aws.ToInt64(ko.Status.ForceDeleteObjectsDeleted) + deleted)
+	msg := fmt.Sprintf(
+		"emptying bucket before deletion: %d object versions and delete markers removed, %d multipart uploads aborted",
+		*ko.Status.ForceDeleteObjectsDeleted, *ko.Status.ForceDeleteUploadsAborted,
+	)
+	ackcondition.SetSynced(r, corev1.ConditionFalse, &msg, nil)
+}
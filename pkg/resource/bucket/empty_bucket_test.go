@@ -0,0 +1,204 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// emptyBucketResults returns canned responses for a bucket holding two object
+// versions, one delete marker and one incomplete multipart upload, all on a
+// single page.
+func emptyBucketResults() map[string]opResult {
+	return map[string]opResult{
+		"ListMultipartUploads": {output: &svcsdk.ListMultipartUploadsOutput{
+			Uploads: []svcsdktypes.MultipartUpload{
+				{Key: strPtr("big.bin"), UploadId: strPtr("upload-1")},
+			},
+		}},
+		"AbortMultipartUpload": {output: &svcsdk.AbortMultipartUploadOutput{}},
+		"ListObjectVersions": {output: &svcsdk.ListObjectVersionsOutput{
+			Versions: []svcsdktypes.ObjectVersion{
+				{Key: strPtr("a.txt"), VersionId: strPtr("v1")},
+				{Key: strPtr("a.txt"), VersionId: strPtr("v2")},
+			},
+			DeleteMarkers: []svcsdktypes.DeleteMarkerEntry{
+				{Key: strPtr("b.txt"), VersionId: strPtr("v3")},
+			},
+		}},
+		"DeleteObjects": {output: &svcsdk.DeleteObjectsOutput{}},
+	}
+}
+
+func newForceDeleteBucketResource(name string) *resource {
+	r := newBucketResource(name)
+	r.ko.ObjectMeta = metav1.ObjectMeta{
+		Annotations: map[string]string{
+			svcapitypes.AnnotationForceDelete: "true",
+		},
+	}
+	return r
+}
+
+func Test_isForceDeleteEnabled(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(isForceDeleteEnabled(newBucketResource("my-bucket")))
+	assert.True(isForceDeleteEnabled(newForceDeleteBucketResource("my-bucket")))
+
+	r := newForceDeleteBucketResource("my-bucket")
+	r.ko.Annotations[svcapitypes.AnnotationForceDelete] = "false"
+	assert.False(isForceDeleteEnabled(r))
+}
+
+// Test_emptyBucket_RemovesEverything verifies that uploads, versions and
+// delete markers are all removed on a single-page bucket, and that the
+// progress counters are recorded in Status.
+func Test_emptyBucket_RemovesEverything(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rm := &resourceManager{
+		sdkapi:  newMockedSDKClient(emptyBucketResults()),
+		metrics: ackmetrics.NewMetrics("s3"),
+	}
+
+	r := newForceDeleteBucketResource("my-bucket")
+	emptied, err := rm.emptyBucket(context.Background(), r)
+	require.NoError(err)
+	assert.True(emptied)
+
+	require.NotNil(r.ko.Status.ForceDeleteObjectsDeleted)
+	assert.Equal(int64(3), *r.ko.Status.ForceDeleteObjectsDeleted)
+	require.NotNil(r.ko.Status.ForceDeleteUploadsAborted)
+	assert.Equal(int64(1), *r.ko.Status.ForceDeleteUploadsAborted)
+
+	// Counters accumulate across reconciles
+	_, err = rm.emptyBucket(context.Background(), r)
+	require.NoError(err)
+	assert.Equal(int64(6), *r.ko.Status.ForceDeleteObjectsDeleted)
+}
+
+// Test_emptyBucket_UploadAlreadyGone verifies that uploads completed or
+// aborted concurrently are not counted as aborted.
+func Test_emptyBucket_UploadAlreadyGone(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	results := emptyBucketResults()
+	results["AbortMultipartUpload"] = opResult{err: apiErr("NoSuchUpload")}
+	rm := &resourceManager{
+		sdkapi:  newMockedSDKClient(results),
+		metrics: ackmetrics.NewMetrics("s3"),
+	}
+
+	r := newForceDeleteBucketResource("my-bucket")
+	emptied, err := rm.emptyBucket(context.Background(), r)
+	require.NoError(err)
+	assert.True(emptied)
+	require.NotNil(r.ko.Status.ForceDeleteUploadsAborted)
+	assert.Equal(int64(0), *r.ko.Status.ForceDeleteUploadsAborted)
+}
+
+// Test_emptyBucket_ResumesWhenTruncated verifies that a bucket with more
+// pages than can be processed in a single reconcile is reported as not yet
+// emptied so the deletion is requeued.
+func Test_emptyBucket_ResumesWhenTruncated(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	results := emptyBucketResults()
+	results["ListObjectVersions"] = opResult{output: &svcsdk.ListObjectVersionsOutput{
+		Versions: []svcsdktypes.ObjectVersion{
+			{Key: strPtr("a.txt"), VersionId: strPtr("v1")},
+		},
+		IsTruncated:   boolPtr(true),
+		NextKeyMarker: strPtr("a.txt"),
+	}}
+	rm := &resourceManager{
+		sdkapi:  newMockedSDKClient(results),
+		metrics: ackmetrics.NewMetrics("s3"),
+	}
+
+	r := newForceDeleteBucketResource("my-bucket")
+	emptied, err := rm.emptyBucket(context.Background(), r)
+	require.NoError(err)
+	assert.False(emptied)
+	assert.Equal(int64(forceDeleteMaxPagesPerReconcile), *r.ko.Status.ForceDeleteObjectsDeleted)
+}
+
+// Test_emptyBucket_ObjectLockRefused verifies that a bucket with Object Lock
+// enabled that still holds object versions is never emptied.
+func Test_emptyBucket_ObjectLockRefused(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	results := emptyBucketResults()
+	results["GetObjectLockConfiguration"] = opResult{output: &svcsdk.GetObjectLockConfigurationOutput{
+		ObjectLockConfiguration: &svcsdktypes.ObjectLockConfiguration{
+			ObjectLockEnabled: svcsdktypes.ObjectLockEnabledEnabled,
+		},
+	}}
+	// Fail loudly if anything is deleted.
+	results["DeleteObjects"] = opResult{err: apiErr("ShouldNotBeCalled")}
+	results["AbortMultipartUpload"] = opResult{err: apiErr("ShouldNotBeCalled")}
+	rm := &resourceManager{
+		sdkapi:  newMockedSDKClient(results),
+		metrics: ackmetrics.NewMetrics("s3"),
+	}
+
+	r := newForceDeleteBucketResource("my-locked-bucket")
+	emptied, err := rm.emptyBucket(context.Background(), r)
+	require.Error(err)
+	assert.False(emptied)
+	var termErr *ackerr.TerminalError
+	assert.True(errors.As(err, &termErr))
+}
+
+// Test_emptyBucket_DeleteObjectsPartialFailure verifies that per-key errors
+// reported in the DeleteObjects response fail the pass.
+func Test_emptyBucket_DeleteObjectsPartialFailure(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	results := emptyBucketResults()
+	results["DeleteObjects"] = opResult{output: &svcsdk.DeleteObjectsOutput{
+		Errors: []svcsdktypes.Error{
+			{Key: strPtr("a.txt"), Code: strPtr("AccessDenied"), Message: strPtr("Access Denied")},
+		},
+	}}
+	rm := &resourceManager{
+		sdkapi:  newMockedSDKClient(results),
+		metrics: ackmetrics.NewMetrics("s3"),
+	}
+
+	r := newForceDeleteBucketResource("my-bucket")
+	emptied, err := rm.emptyBucket(context.Background(), r)
+	require.Error(err)
+	assert.False(emptied)
+	assert.Contains(err.Error(), "AccessDenied")
+	assert.Equal(int64(2), *r.ko.Status.ForceDeleteObjectsDeleted)
+}
@@ -25,12 +25,15 @@ import (
 	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
 	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
 	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3control"
 	s3controltypes "github.com/aws/aws-sdk-go-v2/service/s3control/types"
-	"github.com/aws/smithy-go"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"golang.org/x/sync/errgroup"
 )
 
 // IsDirectoryBucketName checks if a bucket name follows the directory bucket naming pattern.
@@ -45,23 +48,32 @@ func IsAccountRegionalBucketName(bucketName string) bool {
 	return strings.HasSuffix(bucketName, "-an")
 }
 
-// bucketARN returns the ARN of the S3 bucket with the given name.
-func bucketARN(bucketName string) string {
-	// TODO(a-hilaly): I know there could be other partitions, but I'm
-	// not sure how to determine at this level of abstraction. Probably
-	// something the SDK/runtime should handle. For now, we'll just use
-	// the `aws` partition.
-	//
-	// NOTE(a-hilaly): Other parts of ACK also use this default partition
-	// e.g the generated function `ARNFromName` also uses `aws` as the
-	// default partition.
-	return fmt.Sprintf("arn:aws:s3:::%s", bucketName)
+// partition returns the AWS partition of the buckets managed by rm. It
+// prefers the partition the controller resolved from its own identity and
+// falls back to the partition that contains rm's region, so that ARNs are
+// correct in the aws-cn and aws-us-gov partitions even when the former is
+// unset.
+func (rm *resourceManager) partition() string {
+	if rm.awsPartition != "" {
+		return string(rm.awsPartition)
+	}
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), string(rm.awsRegion)); ok {
+		return p.ID()
+	}
+	return endpoints.AwsPartitionID
+}
+
+// bucketARN returns the ARN of the general purpose S3 bucket with the given
+// name. Bucket ARNs carry neither region nor account ID.
+func (rm *resourceManager) bucketARN(bucketName string) string {
+	return fmt.Sprintf("arn:%s:s3:::%s", rm.partition(), bucketName)
 }
 
 // directoryBucketARN returns the fully-qualified ARN for a directory bucket.
 // S3 Control API requires region and account ID in the ARN for directory buckets.
 func (rm *resourceManager) directoryBucketARN(bucketName string) string {
-	return fmt.Sprintf("arn:aws:s3express:%s:%s:bucket/%s",
+	return fmt.Sprintf("arn:%s:s3express:%s:%s:bucket/%s",
+		rm.partition(),
 		string(rm.awsRegion),
 		string(rm.awsAccountID),
 		bucketName)
@@ -95,6 +107,54 @@ const (
 
 const ErrSyncingPutProperty = "Error syncing property '%s'"
 
+// unsupportedDirectoryBucketField names a field of the bucket spec which
+// directory buckets do not support, along with its JSON path in the spec.
+type unsupportedDirectoryBucketField struct {
+	name string
+	path string
+}
+
+// unsupportedDirectoryBucketFields returns the fields set in the spec of the
+// given bucket which directory buckets do not support.
+func unsupportedDirectoryBucketFields(ko *svcapitypes.Bucket) []unsupportedDirectoryBucketField {
+	unsupportedChecks := []struct {
+		unsupportedDirectoryBucketField
+		isSet func() bool
+	}{
+		{unsupportedDirectoryBucketField{"ABAC", "abac"}, func() bool { return ko.Spec.Abac != nil }},
+		{unsupportedDirectoryBucketField{"Accelerate", "accelerate"}, func() bool { return ko.Spec.Accelerate != nil }},
+		{unsupportedDirectoryBucketField{"Analytics", "analytics"}, func() bool { return len(ko.Spec.Analytics) > 0 }},
+		{unsupportedDirectoryBucketField{"ACL", "acl"}, func() bool { return ko.Spec.ACL != nil }},
+		{unsupportedDirectoryBucketField{"GrantFullControl", "grantFullControl"}, func() bool { return ko.Spec.GrantFullControl != nil }},
+		{unsupportedDirectoryBucketField{"GrantRead", "grantRead"}, func() bool { return ko.Spec.GrantRead != nil }},
+		{unsupportedDirectoryBucketField{"GrantReadACP", "grantReadACP"}, func() bool { return ko.Spec.GrantReadACP != nil }},
+		{unsupportedDirectoryBucketField{"GrantWrite", "grantWrite"}, func() bool { return ko.Spec.GrantWrite != nil }},
+		{unsupportedDirectoryBucketField{"GrantWriteACP", "grantWriteACP"}, func() bool { return ko.Spec.GrantWriteACP != nil }},
+		{unsupportedDirectoryBucketField{"CORS", "cors"}, func() bool { return ko.Spec.CORS != nil }},
+		{unsupportedDirectoryBucketField{"IntelligentTiering", "intelligentTiering"}, func() bool { return len(ko.Spec.IntelligentTiering) > 0 }},
+		{unsupportedDirectoryBucketField{"Inventory", "inventory"}, func() bool { return len(ko.Spec.Inventory) > 0 }},
+		{unsupportedDirectoryBucketField{"Logging", "logging"}, func() bool { return ko.Spec.Logging != nil }},
+		{unsupportedDirectoryBucketField{"MetadataConfiguration", "metadataConfiguration"}, func() bool { return ko.Spec.MetadataConfiguration != nil }},
+		{unsupportedDirectoryBucketField{"Metrics", "metrics"}, func() bool { return len(ko.Spec.Metrics) > 0 }},
+		{unsupportedDirectoryBucketField{"Notification", "notification"}, func() bool { return ko.Spec.Notification != nil }},
+		{unsupportedDirectoryBucketField{"OwnershipControls", "ownershipControls"}, func() bool { return ko.Spec.OwnershipControls != nil }},
+		{unsupportedDirectoryBucketField{"PublicAccessBlock", "publicAccessBlock"}, func() bool { return ko.Spec.PublicAccessBlock != nil }},
+		{unsupportedDirectoryBucketField{"Replication", "replication"}, func() bool { return ko.Spec.Replication != nil }},
+		{unsupportedDirectoryBucketField{"RequestPayment", "requestPayment"}, func() bool { return ko.Spec.RequestPayment != nil }},
+		{unsupportedDirectoryBucketField{"Versioning", "versioning"}, func() bool { return ko.Spec.Versioning != nil }},
+		{unsupportedDirectoryBucketField{"Website", "website"}, func() bool { return ko.Spec.Website != nil }},
+		{unsupportedDirectoryBucketField{"ObjectLockConfiguration", "objectLockConfiguration"}, func() bool { return ko.Spec.ObjectLockConfiguration != nil }},
+	}
+
+	var unsupportedFields []unsupportedDirectoryBucketField
+	for _, check := range unsupportedChecks {
+		if check.isSet() {
+			unsupportedFields = append(unsupportedFields, check.unsupportedDirectoryBucketField)
+		}
+	}
+	return unsupportedFields
+}
+
 // validateDirectoryBucketSpec validates that no unsupported fields are set for directory buckets.
 // Returns a terminal error if unsupported fields are specified.
 func validateDirectoryBucketSpec(ko *svcapitypes.Bucket) error {
@@ -102,39 +162,9 @@ func validateDirectoryBucketSpec(ko *svcapitypes.Bucket) error {
 		return nil
 	}
 
-	unsupportedChecks := []struct {
-		name  string
-		isSet func() bool
-	}{
-		{"ABAC", func() bool { return ko.Spec.Abac != nil }},
-		{"Accelerate", func() bool { return ko.Spec.Accelerate != nil }},
-		{"Analytics", func() bool { return len(ko.Spec.Analytics) > 0 }},
-		{"ACL", func() bool { return ko.Spec.ACL != nil }},
-		{"GrantFullControl", func() bool { return ko.Spec.GrantFullControl != nil }},
-		{"GrantRead", func() bool { return ko.Spec.GrantRead != nil }},
-		{"GrantReadACP", func() bool { return ko.Spec.GrantReadACP != nil }},
-		{"GrantWrite", func() bool { return ko.Spec.GrantWrite != nil }},
-		{"GrantWriteACP", func() bool { return ko.Spec.GrantWriteACP != nil }},
-		{"CORS", func() bool { return ko.Spec.CORS != nil }},
-		{"IntelligentTiering", func() bool { return len(ko.Spec.IntelligentTiering) > 0 }},
-		{"Inventory", func() bool { return len(ko.Spec.Inventory) > 0 }},
-		{"Logging", func() bool { return ko.Spec.Logging != nil }},
-		{"Metrics", func() bool { return len(ko.Spec.Metrics) > 0 }},
-		{"Notification", func() bool { return ko.Spec.Notification != nil }},
-		{"OwnershipControls", func() bool { return ko.Spec.OwnershipControls != nil }},
-		{"PublicAccessBlock", func() bool { return ko.Spec.PublicAccessBlock != nil }},
-		{"Replication", func() bool { return ko.Spec.Replication != nil }},
-		{"RequestPayment", func() bool { return ko.Spec.RequestPayment != nil }},
-		{"Versioning", func() bool { return ko.Spec.Versioning != nil }},
-		{"Website", func() bool { return ko.Spec.Website != nil }},
-		{"ObjectLockConfiguration", func() bool { return ko.Spec.ObjectLockConfiguration != nil }},
-	}
-
 	var unsupportedFields []string
-	for _, check := range unsupportedChecks {
-		if check.isSet() {
-			unsupportedFields = append(unsupportedFields, check.name)
-		}
+	for _, field := range unsupportedDirectoryBucketFields(ko) {
+		unsupportedFields = append(unsupportedFields, field.name)
 	}
 
 	if len(unsupportedFields) > 0 {
@@ -148,8 +178,11 @@ func validateDirectoryBucketSpec(ko *svcapitypes.Bucket) error {
 	return nil
 }
 
-// customFindBucket is a custom implementation of sdkFind that handles both
-// general-purpose and directory buckets by using the appropriate List API.
+// customFindBucket is a custom implementation of sdkFind that checks the
+// bucket exists with HeadBucket before describing each of its Put* fields.
+// HeadBucket works for both general-purpose and directory buckets and, unlike
+// the List APIs, costs a single call regardless of how many buckets the
+// account owns.
 func (rm *resourceManager) customFindBucket(
 	ctx context.Context,
 	r *resource,
@@ -165,65 +198,33 @@ func (rm *resourceManager) customFindBucket(
 	}
 
 	bucketName := *r.ko.Spec.Name
-	ko := r.ko.DeepCopy()
-
-	if IsDirectoryBucketName(bucketName) {
-		// Use ListDirectoryBuckets for directory buckets
-		input := &svcsdk.ListDirectoryBucketsInput{}
-		found := false
 
-		paginator := svcsdk.NewListDirectoryBucketsPaginator(rm.sdkapi, input)
-		for paginator.HasMorePages() {
-			resp, err := paginator.NextPage(ctx)
-			rm.metrics.RecordAPICall("READ_MANY", "ListDirectoryBuckets", err)
-			if err != nil {
-				var awsErr smithy.APIError
-				if errors.As(err, &awsErr) && awsErr.ErrorCode() == "NoSuchBucket" {
-					return nil, ackerr.NotFound
-				}
-				return nil, err
-			}
-			for _, bucket := range resp.Buckets {
-				if bucket.Name != nil && *bucket.Name == bucketName {
-					found = true
-					break
-				}
-			}
-			if found {
-				break
-			}
-		}
+	ko := r.ko.DeepCopy()
 
-		if !found {
-			return nil, ackerr.NotFound
-		}
-	} else {
-		// Use ListBuckets for general-purpose buckets
-		input := &svcsdk.ListBucketsInput{}
-		resp, err := rm.sdkapi.ListBuckets(ctx, input)
-		rm.metrics.RecordAPICall("READ_MANY", "ListBuckets", err)
-		if err != nil {
-			var awsErr smithy.APIError
-			if errors.As(err, &awsErr) && awsErr.ErrorCode() == "NoSuchBucket" {
-				return nil, ackerr.NotFound
-			}
+	// The class of the Bucket is merged under a copy of the desired spec only
+	// to record it, along with the fields set from it, in the status.
+	if r.ko.DeletionTimestamp == nil {
+		classed := r.ko.DeepCopy()
+		if err := applyBucketClass(ctx, classed, false); err != nil {
 			return nil, err
 		}
+		ko.Status.BucketClass = classed.Status.BucketClass
+	}
 
-		found := false
-		for _, bucket := range resp.Buckets {
-			if bucket.Name != nil && *bucket.Name == bucketName {
-				found = true
-				break
-			}
-		}
+	rm.setStatusDefaults(ko)
 
-		if !found {
-			return nil, ackerr.NotFound
-		}
+	// The bucket may live in another region than rm targets, in which case
+	// the rest of the read goes through a resource manager for that region.
+	// The bucket is first looked for in the region it was last found in.
+	if ko.Status.BucketRegion != nil {
+		rm = rm.forRegion(ackv1alpha1.AWSRegion(*ko.Status.BucketRegion))
+	}
+	rm, err = rm.managerForBucket(ctx, bucketName)
+	if err != nil {
+		return nil, err
 	}
+	ko.Status.BucketRegion = aws.String(string(rm.awsRegion))
 
-	rm.setStatusDefaults(ko)
 	if err := rm.addPutFieldsToSpec(ctx, r, ko); err != nil {
 		return nil, err
 	}
@@ -240,13 +241,76 @@ func (rm *resourceManager) customFindBucket(
 	if IsDirectoryBucketName(*ko.Spec.Name) {
 		arnStr = ackv1alpha1.AWSResourceName(rm.directoryBucketARN(*ko.Spec.Name))
 	} else {
-		arnStr = ackv1alpha1.AWSResourceName(bucketARN(*ko.Spec.Name))
+		arnStr = ackv1alpha1.AWSResourceName(rm.bucketARN(*ko.Spec.Name))
 	}
 	ko.Status.ACKResourceMetadata.ARN = &arnStr
 
 	return &resource{ko}, nil
 }
 
+// headBucket checks that the bucket exists and is owned by the controller's
+// account. If the bucket lives in another region than rm targets, that region
+// is returned with a nil error. HeadBucket responses have no body, so the SDK
+// derives the error code from the HTTP status code:
+//
+//   - 404 means the bucket does not exist and is returned as NotFound.
+//   - 301 means the bucket exists in another region, reported in the
+//     x-amz-bucket-region header or, failing that, by GetBucketLocation.
+//   - 403 means either that the bucket exists but belongs to another
+//     account, or that the controller is not allowed to see it. Neither
+//     must be mistaken for NotFound, or the controller would attempt to
+//     create the bucket.
+func (rm *resourceManager) headBucket(
+	ctx context.Context,
+	bucketName string,
+) (redirect ackv1alpha1.AWSRegion, err error) {
+	input := &svcsdk.HeadBucketInput{
+		Bucket: aws.String(bucketName),
+	}
+	if rm.awsAccountID != "" {
+		input.ExpectedBucketOwner = aws.String(string(rm.awsAccountID))
+	}
+	_, err = rm.sdkapi.HeadBucket(ctx, input)
+	rm.metrics.RecordAPICall("READ_ONE", "HeadBucket", err)
+	if err == nil {
+		return "", nil
+	}
+
+	awsErr, ok := ackerr.AWSError(err)
+	if !ok {
+		return "", err
+	}
+	switch awsErr.ErrorCode() {
+	case "NotFound", "NoSuchBucket":
+		return "", ackerr.NotFound
+	case "MovedPermanently", "PermanentRedirect":
+		if region := bucketRegionFromError(err); region != "" {
+			return ackv1alpha1.AWSRegion(region), nil
+		}
+		return rm.getBucketLocation(ctx, bucketName)
+	case "Forbidden", "AccessDenied":
+		return "", ackerr.NewTerminalError(fmt.Errorf(
+			"cannot access bucket %q: HeadBucket returned 403 Forbidden for "+
+				"expected bucket owner %q, either because the bucket is owned "+
+				"by another account or because the controller's IAM identity "+
+				"is not allowed s3:ListBucket on it",
+			bucketName, string(rm.awsAccountID),
+		))
+	}
+	return "", err
+}
+
+// bucketRegionFromError returns the region S3 reported for a bucket in the
+// x-amz-bucket-region header of a failed response, or an empty string if the
+// header is absent.
+func bucketRegionFromError(err error) string {
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) && respErr.Response != nil {
+		return respErr.Response.Header.Get("x-amz-bucket-region")
+	}
+	return ""
+}
+
 // customUpdateBucket patches each of the resource properties in the backend AWS
 // service API and returns a new resource with updated fields.
 func (rm *resourceManager) customUpdateBucket(
@@ -259,446 +323,536 @@ func (rm *resourceManager) customUpdateBucket(
 	exit := rlog.Trace("rm.customUpdateBucket")
 	defer exit(err)
 
-	// Validate directory bucket spec before updating
-	if err := validateDirectoryBucketSpec(desired.ko); err != nil {
-		return nil, err
-	}
-
-	isDirectoryBucket := desired.ko.Spec.Name != nil && IsDirectoryBucketName(*desired.ko.Spec.Name)
-
 	// Merge in the information we read from the API call above to the copy of
 	// the original Kubernetes object we passed to the function
 	ko := desired.ko.DeepCopy()
 	ko.Status = *latest.ko.Status.DeepCopy()
 
-	// Skip unsupported operations for directory buckets
-	if !isDirectoryBucket && delta.DifferentAt("Spec.Abac") {
-		if err := rm.syncABAC(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "ABAC")
-		}
-	}
-	if !isDirectoryBucket && delta.DifferentAt("Spec.Accelerate") {
-		if err := rm.syncAccelerate(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Accelerate")
-		}
-	}
-	if !isDirectoryBucket && delta.DifferentAt("Spec.Analytics") {
-		if err := rm.syncAnalytics(ctx, desired, latest); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Analytics")
-		}
-	}
-	if !isDirectoryBucket && (delta.DifferentAt("Spec.ACL") ||
-		delta.DifferentAt("Spec.GrantFullControl") ||
-		delta.DifferentAt("Spec.GrantRead") ||
-		delta.DifferentAt("Spec.GrantReadACP") ||
-		delta.DifferentAt("Spec.GrantWrite") ||
-		delta.DifferentAt("Spec.GrantWriteACP")) {
-		if err := rm.syncACL(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "ACLs or Grant Headers")
-		}
-	}
-	if !isDirectoryBucket && delta.DifferentAt("Spec.CORS") {
-		if err := rm.syncCORS(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "CORS")
-		}
-	}
-	// Encryption is supported for both bucket types
-	if delta.DifferentAt("Spec.Encryption") {
-		if err := rm.syncEncryption(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Encryption")
-		}
-	}
-	if !isDirectoryBucket && delta.DifferentAt("Spec.IntelligentTiering") {
-		if err := rm.syncIntelligentTiering(ctx, desired, latest); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "IntelligentTiering")
-		}
-	}
-	if !isDirectoryBucket && delta.DifferentAt("Spec.Inventory") {
-		if err := rm.syncInventory(ctx, desired, latest); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Inventory")
-		}
-	}
-	// Lifecycle is supported for both bucket types
-	if delta.DifferentAt("Spec.Lifecycle") {
-		if err := rm.syncLifecycle(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Lifecycle")
-		}
-	}
-	if !isDirectoryBucket && delta.DifferentAt("Spec.Logging") {
-		if err := rm.syncLogging(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Logging")
-		}
-	}
-	if !isDirectoryBucket && delta.DifferentAt("Spec.Metrics") {
-		if err := rm.syncMetrics(ctx, desired, latest); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Metrics")
-		}
-	}
-	if !isDirectoryBucket && delta.DifferentAt("Spec.Notification") {
-		if err := rm.syncNotification(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Notification")
-		}
-	}
-	if !isDirectoryBucket && delta.DifferentAt("Spec.OwnershipControls") {
-		if err := rm.syncOwnershipControls(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "OwnershipControls")
-		}
-	}
-	// PublicAccessBlock may need to be set in order to use Policy, so sync it
-	// first (not supported for directory buckets)
-	if !isDirectoryBucket && delta.DifferentAt("Spec.PublicAccessBlock") {
-		if err := rm.syncPublicAccessBlock(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "PublicAccessBlock")
-		}
+	// The properties are put from a copy of the desired spec with the class
+	// of the Bucket merged under it. The spec returned is the one declared.
+	classed := desired.ko.DeepCopy()
+	if err := applyBucketClass(ctx, classed, false); err != nil {
+		return nil, err
 	}
-	// Policy is supported for both bucket types
-	if delta.DifferentAt("Spec.Policy") {
-		if err := rm.syncPolicy(ctx, desired, isDirectoryBucket); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Policy")
-		}
+	desired = &resource{classed}
+
+	// Validate directory bucket spec before updating
+	if err := validateDirectoryBucketSpec(desired.ko); err != nil {
+		return nil, err
 	}
-	if !isDirectoryBucket && delta.DifferentAt("Spec.RequestPayment") {
-		if err := rm.syncRequestPayment(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "RequestPayment")
-		}
+	if err := validatePolicySpec(desired.ko); err != nil {
+		return nil, err
 	}
-	// Tagging is supported for both bucket types
-	if delta.DifferentAt("Spec.Tagging") {
-		if err := rm.syncTagging(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Tagging")
-		}
+
+	// Use the region the bucket was found in by the preceding read
+	rm, err = rm.managerForResource(ctx, latest)
+	if err != nil {
+		return nil, err
 	}
-	if !isDirectoryBucket && delta.DifferentAt("Spec.Website") {
-		if err := rm.syncWebsite(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Website")
-		}
+
+	isDirectoryBucket := desired.ko.Spec.Name != nil && IsDirectoryBucketName(*desired.ko.Spec.Name)
+
+	// Order the properties that changed according to their requirements on
+	// one another, for both enable and disable transitions.
+	plan, err := planBucketSync(bucketSyncSteps, desired, latest, delta, isDirectoryBucket)
+	if err != nil {
+		return nil, err
 	}
 
-	// Replication requires versioning be enabled. We check that if we are
-	// disabling versioning, that we disable replication first. If we are
-	// enabling replication, that we enable versioning first.
-	// (Not supported for directory buckets)
-	if !isDirectoryBucket && (delta.DifferentAt("Spec.Replication") || delta.DifferentAt("Spec.Versioning")) {
-		if desired.ko.Spec.Replication == nil || desired.ko.Spec.Replication.Rules == nil {
-			if err := rm.syncReplication(ctx, desired); err != nil {
-				return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Replication")
-			}
-			if err := rm.syncVersioning(ctx, desired); err != nil {
-				return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Versioning")
-			}
-		} else {
-			if err := rm.syncVersioning(ctx, desired); err != nil {
-				return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Versioning")
-			}
-			if err := rm.syncReplication(ctx, desired); err != nil {
-				return nil, errors.Wrapf(err, ErrSyncingPutProperty, "Replication")
+	// Each property is attempted even if another one fails, and the outcome
+	// of each is recorded in Status.SubresourceConditions.
+	syncer := newSubresourceSyncer(ko)
+	for _, step := range plan {
+		syncer.sync(step.property, func() error {
+			if step.precondition != nil {
+				if err := step.precondition(desired, latest); err != nil {
+					return err
+				}
 			}
-		}
-	}
-	if !isDirectoryBucket && (delta.DifferentAt("Spec.ObjectLockConfiguration") || delta.DifferentAt("Spec.ObjectLockEnabledForBucket")) {
-		if err := rm.syncObjectLockConfiguration(ctx, desired); err != nil {
-			return nil, errors.Wrapf(err, ErrSyncingPutProperty, "ObjectLockConfiguration")
+			return step.sync(ctx, rm, desired, latest, isDirectoryBucket)
+		}, step.dependsOn...)
+		if step.property == "DestinationPolicies" && !syncer.failed[step.property] {
+			ko.Status.DestinationGrants = aws.StringSlice(destinationGrantBuckets(desired))
 		}
 	}
 
+	if err := syncer.err(); err != nil {
+		// Return the resource along with the error so that the conditions
+		// recorded above are saved in its status.
+		return &resource{ko}, err
+	}
 	return &resource{ko}, nil
 }
 
+// addPutFieldsToSpecConcurrency bounds the number of Get* calls that
+// addPutFieldsToSpec keeps in flight at once for a single bucket.
+const addPutFieldsToSpecConcurrency = 6
+
+// putFieldReader reads a single Put* property of a bucket from S3. It returns
+// a setter that copies the observed value into the Bucket spec. Readers run
+// concurrently and must not touch the Bucket themselves; the setters are
+// applied serially, in declaration order, once every read has succeeded.
+type putFieldReader func(ctx context.Context) (func(ko *svcapitypes.Bucket), error)
+
 // addPutFieldsToSpec will describe each of the Put* fields and add their
 // returned values to the Bucket spec.
+//
+// The Get* calls are independent of one another, so they are fanned out over
+// a bounded pool of goroutines. Every call runs to completion and the error of
+// the first failed call, in declaration order, is returned, so that the same
+// failures always report the same error whatever order the calls finish in;
+// ko is only modified when every call succeeds.
 func (rm *resourceManager) addPutFieldsToSpec(
 	ctx context.Context,
 	r *resource,
 	ko *svcapitypes.Bucket,
 ) (err error) {
-	isDirectoryBucket := r.ko.Spec.Name != nil && IsDirectoryBucketName(*r.ko.Spec.Name)
-
-	// Skip unsupported API calls for directory buckets
-	if !isDirectoryBucket {
-		getAbacResponse, err := rm.sdkapi.GetBucketAbac(ctx, rm.newGetBucketAbacPayload(r))
-		if err != nil {
-			// This method is not supported in every region, ignore any errors if
-			// we attempt to describe this property in a region in which it's not
-			// supported.
-			if awsErr, ok := ackerr.AWSError(err); !ok || (awsErr.ErrorCode() != "MethodNotAllowed" && awsErr.ErrorCode() != "UnsupportedArgument") {
-				return err
-			}
-		}
-		if getAbacResponse == nil || getAbacResponse.AbacStatus == nil || getAbacResponse.AbacStatus.Status == "" {
-			ko.Spec.Abac = nil
-		} else {
-			ko.Spec.Abac = rm.setResourceAbac(r, getAbacResponse)
-		}
-
-		getAccelerateResponse, err := rm.sdkapi.GetBucketAccelerateConfiguration(ctx, rm.newGetBucketAcceleratePayload(r))
-		if err != nil {
-			// This method is not supported in every region, ignore any errors if
-			// we attempt to describe this property in a region in which it's not
-			// supported.
-			if awsErr, ok := ackerr.AWSError(err); !ok || (awsErr.ErrorCode() != "MethodNotAllowed" && awsErr.ErrorCode() != "UnsupportedArgument") {
-				return err
-			}
-		}
-		if getAccelerateResponse == nil || getAccelerateResponse.Status == "" {
-			ko.Spec.Accelerate = nil
-		} else {
-			ko.Spec.Accelerate = rm.setResourceAccelerate(r, getAccelerateResponse)
-		}
-
-		listAnalyticsResponse, err := rm.sdkapi.ListBucketAnalyticsConfigurations(ctx, rm.newListBucketAnalyticsPayload(r))
-		if err != nil {
-			return err
-		}
-		if listAnalyticsResponse != nil && len(listAnalyticsResponse.AnalyticsConfigurationList) > 0 {
-			ko.Spec.Analytics = make([]*svcapitypes.AnalyticsConfiguration, len(listAnalyticsResponse.AnalyticsConfigurationList))
-			for i, analyticsConfiguration := range listAnalyticsResponse.AnalyticsConfigurationList {
-				ko.Spec.Analytics[i] = rm.setResourceAnalyticsConfiguration(r, analyticsConfiguration)
-			}
-		} else {
-			ko.Spec.Analytics = nil
-		}
-
-		getACLResponse, err := rm.sdkapi.GetBucketAcl(ctx, rm.newGetBucketACLPayload(r))
+	readers := rm.putFieldReaders(r)
+	setters := make([]func(ko *svcapitypes.Bucket), len(readers))
+	errs := make([]error, len(readers))
+
+	var g errgroup.Group
+	g.SetLimit(addPutFieldsToSpecConcurrency)
+	for i, read := range readers {
+		g.Go(func() error {
+			setters[i], errs[i] = read(ctx)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	for _, err := range errs {
 		if err != nil {
 			return err
 		}
-		rm.setResourceACL(ko, getACLResponse)
-
-		getCORSResponse, err := rm.sdkapi.GetBucketCors(ctx, rm.newGetBucketCORSPayload(r))
-		if err != nil {
-			if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "NoSuchCORSConfiguration" {
-				return err
-			}
-		}
-		if getCORSResponse != nil {
-			ko.Spec.CORS = rm.setResourceCORS(r, getCORSResponse)
-		} else {
-			ko.Spec.CORS = nil
-		}
 	}
 
-	// Encryption is supported for both bucket types
-	getEncryptionResponse, err := rm.sdkapi.GetBucketEncryption(ctx, rm.newGetBucketEncryptionPayload(r))
-	if err != nil {
-		if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "ServerSideEncryptionConfigurationNotFoundError" {
-			return err
-		}
-	}
-	if getEncryptionResponse.ServerSideEncryptionConfiguration.Rules != nil {
-		ko.Spec.Encryption = rm.setResourceEncryption(r, getEncryptionResponse)
-	} else {
-		ko.Spec.Encryption = nil
+	for _, set := range setters {
+		set(ko)
 	}
+	return nil
+}
 
-	if !isDirectoryBucket {
-		listIntelligentTieringResponse, err := rm.sdkapi.ListBucketIntelligentTieringConfigurations(ctx, rm.newListBucketIntelligentTieringPayload(r))
-		if err != nil {
-			return err
-		}
-		if len(listIntelligentTieringResponse.IntelligentTieringConfigurationList) > 0 {
-			ko.Spec.IntelligentTiering = make([]*svcapitypes.IntelligentTieringConfiguration, len(listIntelligentTieringResponse.IntelligentTieringConfigurationList))
-			for i, intelligentTieringConfiguration := range listIntelligentTieringResponse.IntelligentTieringConfigurationList {
-				ko.Spec.IntelligentTiering[i] = rm.setResourceIntelligentTieringConfiguration(r, intelligentTieringConfiguration)
-			}
-		} else {
-			ko.Spec.IntelligentTiering = nil
-		}
+// putFieldReaders returns the readers for every Put* property supported by
+// the bucket's type. Each reader keeps its own classification of the API
+// errors that merely mean the property is unset.
+func (rm *resourceManager) putFieldReaders(
+	r *resource,
+) []putFieldReader {
+	isDirectoryBucket := r.ko.Spec.Name != nil && IsDirectoryBucketName(*r.ko.Spec.Name)
 
-		listInventoryResponse, err := rm.sdkapi.ListBucketInventoryConfigurations(ctx, rm.newListBucketInventoryPayload(r))
-		if err != nil {
-			return err
-		}
+	readers := []putFieldReader{}
 
-		ko.Spec.Inventory = make([]*svcapitypes.InventoryConfiguration, len(listInventoryResponse.InventoryConfigurationList))
-		for i, inventoryConfiguration := range listInventoryResponse.InventoryConfigurationList {
-			ko.Spec.Inventory[i] = rm.setResourceInventoryConfiguration(r, inventoryConfiguration)
-		}
+	// Skip unsupported API calls for directory buckets
+	if !isDirectoryBucket {
+		readers = append(readers,
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getAbacResponse, err := rm.sdkapi.GetBucketAbac(ctx, rm.newGetBucketAbacPayload(r))
+				if err != nil {
+					// This method is not supported in every region, ignore any errors if
+					// we attempt to describe this property in a region in which it's not
+					// supported.
+					if awsErr, ok := ackerr.AWSError(err); !ok || (awsErr.ErrorCode() != "MethodNotAllowed" && awsErr.ErrorCode() != "UnsupportedArgument") {
+						return nil, err
+					}
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getAbacResponse == nil || getAbacResponse.AbacStatus == nil || getAbacResponse.AbacStatus.Status == "" {
+						ko.Spec.Abac = nil
+					} else {
+						ko.Spec.Abac = rm.setResourceAbac(r, getAbacResponse)
+					}
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getAccelerateResponse, err := rm.sdkapi.GetBucketAccelerateConfiguration(ctx, rm.newGetBucketAcceleratePayload(r))
+				if err != nil {
+					// This method is not supported in every region, ignore any errors if
+					// we attempt to describe this property in a region in which it's not
+					// supported.
+					if awsErr, ok := ackerr.AWSError(err); !ok || (awsErr.ErrorCode() != "MethodNotAllowed" && awsErr.ErrorCode() != "UnsupportedArgument") {
+						return nil, err
+					}
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getAccelerateResponse == nil || getAccelerateResponse.Status == "" {
+						ko.Spec.Accelerate = nil
+					} else {
+						ko.Spec.Accelerate = rm.setResourceAccelerate(r, getAccelerateResponse)
+					}
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				listAnalyticsResponse, err := rm.sdkapi.ListBucketAnalyticsConfigurations(ctx, rm.newListBucketAnalyticsPayload(r))
+				if err != nil {
+					return nil, err
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if listAnalyticsResponse != nil && len(listAnalyticsResponse.AnalyticsConfigurationList) > 0 {
+						ko.Spec.Analytics = make([]*svcapitypes.AnalyticsConfiguration, len(listAnalyticsResponse.AnalyticsConfigurationList))
+						for i, analyticsConfiguration := range listAnalyticsResponse.AnalyticsConfigurationList {
+							ko.Spec.Analytics[i] = rm.setResourceAnalyticsConfiguration(r, analyticsConfiguration)
+						}
+					} else {
+						ko.Spec.Analytics = nil
+					}
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getACLResponse, err := rm.sdkapi.GetBucketAcl(ctx, rm.newGetBucketACLPayload(r))
+				if err != nil {
+					return nil, err
+				}
+				return func(ko *svcapitypes.Bucket) {
+					rm.setResourceACL(ko, getACLResponse)
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getCORSResponse, err := rm.sdkapi.GetBucketCors(ctx, rm.newGetBucketCORSPayload(r))
+				if err != nil {
+					if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "NoSuchCORSConfiguration" {
+						return nil, err
+					}
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getCORSResponse != nil {
+						ko.Spec.CORS = rm.setResourceCORS(r, getCORSResponse)
+					} else {
+						ko.Spec.CORS = nil
+					}
+				}, nil
+			},
+		)
 	}
 
-	// Lifecycle is supported for both bucket types
-	getLifecycleResponse, err := rm.sdkapi.GetBucketLifecycleConfiguration(ctx, rm.newGetBucketLifecyclePayload(r))
-	if err != nil {
-		if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "NoSuchLifecycleConfiguration" {
-			return err
-		}
-	}
-	if getLifecycleResponse != nil {
-		ko.Spec.Lifecycle = rm.setResourceLifecycle(r, getLifecycleResponse)
-	} else {
-		ko.Spec.Lifecycle = nil
-	}
+	// Encryption is supported for both bucket types
+	readers = append(readers,
+		func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+			getEncryptionResponse, err := rm.sdkapi.GetBucketEncryption(ctx, rm.newGetBucketEncryptionPayload(r))
+			if err != nil {
+				if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "ServerSideEncryptionConfigurationNotFoundError" {
+					return nil, err
+				}
+			}
+			return func(ko *svcapitypes.Bucket) {
+				if getEncryptionResponse != nil &&
+					getEncryptionResponse.ServerSideEncryptionConfiguration != nil &&
+					getEncryptionResponse.ServerSideEncryptionConfiguration.Rules != nil {
+					ko.Spec.Encryption = rm.setResourceEncryption(r, getEncryptionResponse)
+				} else {
+					ko.Spec.Encryption = nil
+				}
+			}, nil
+		},
+	)
 
 	if !isDirectoryBucket {
-		getLoggingResponse, err := rm.sdkapi.GetBucketLogging(ctx, rm.newGetBucketLoggingPayload(r))
-		if err != nil {
-			return err
-		}
-		if getLoggingResponse.LoggingEnabled != nil {
-			ko.Spec.Logging = rm.setResourceLogging(r, getLoggingResponse)
-		} else {
-			ko.Spec.Logging = nil
-		}
+		readers = append(readers,
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				listIntelligentTieringResponse, err := rm.sdkapi.ListBucketIntelligentTieringConfigurations(ctx, rm.newListBucketIntelligentTieringPayload(r))
+				if err != nil {
+					return nil, err
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if len(listIntelligentTieringResponse.IntelligentTieringConfigurationList) > 0 {
+						ko.Spec.IntelligentTiering = make([]*svcapitypes.IntelligentTieringConfiguration, len(listIntelligentTieringResponse.IntelligentTieringConfigurationList))
+						for i, intelligentTieringConfiguration := range listIntelligentTieringResponse.IntelligentTieringConfigurationList {
+							ko.Spec.IntelligentTiering[i] = rm.setResourceIntelligentTieringConfiguration(r, intelligentTieringConfiguration)
+						}
+					} else {
+						ko.Spec.IntelligentTiering = nil
+					}
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				listInventoryResponse, err := rm.sdkapi.ListBucketInventoryConfigurations(ctx, rm.newListBucketInventoryPayload(r))
+				if err != nil {
+					return nil, err
+				}
+				return func(ko *svcapitypes.Bucket) {
+					ko.Spec.Inventory = make([]*svcapitypes.InventoryConfiguration, len(listInventoryResponse.InventoryConfigurationList))
+					for i, inventoryConfiguration := range listInventoryResponse.InventoryConfigurationList {
+						ko.Spec.Inventory[i] = rm.setResourceInventoryConfiguration(r, inventoryConfiguration)
+					}
+				}, nil
+			},
+		)
+	}
 
-		listMetricsResponse, err := rm.sdkapi.ListBucketMetricsConfigurations(ctx, rm.newListBucketMetricsPayload(r))
-		if err != nil {
-			return err
-		}
-		if len(listMetricsResponse.MetricsConfigurationList) > 0 {
-			ko.Spec.Metrics = make([]*svcapitypes.MetricsConfiguration, len(listMetricsResponse.MetricsConfigurationList))
-			for i, metricsConfiguration := range listMetricsResponse.MetricsConfigurationList {
-				ko.Spec.Metrics[i] = rm.setResourceMetricsConfiguration(r, &metricsConfiguration)
+	// Lifecycle is supported for both bucket types
+	readers = append(readers,
+		func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+			getLifecycleResponse, err := rm.sdkapi.GetBucketLifecycleConfiguration(ctx, rm.newGetBucketLifecyclePayload(r))
+			if err != nil {
+				if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "NoSuchLifecycleConfiguration" {
+					return nil, err
+				}
 			}
-		} else {
-			ko.Spec.Metrics = nil
-		}
-
-		getNotificationResponse, err := rm.sdkapi.GetBucketNotificationConfiguration(ctx, rm.newGetBucketNotificationPayload(r))
-		if err != nil {
-			return err
-		}
-		if getNotificationResponse.LambdaFunctionConfigurations != nil ||
-			getNotificationResponse.QueueConfigurations != nil ||
-			getNotificationResponse.TopicConfigurations != nil {
-
-			ko.Spec.Notification = rm.setResourceNotification(r, getNotificationResponse)
-		} else {
-			ko.Spec.Notification = nil
-		}
+			return func(ko *svcapitypes.Bucket) {
+				if getLifecycleResponse != nil {
+					ko.Spec.Lifecycle = rm.setResourceLifecycle(r, getLifecycleResponse)
+				} else {
+					ko.Spec.Lifecycle = nil
+				}
+			}, nil
+		},
+	)
 
-		getOwnershipControlsResponse, err := rm.sdkapi.GetBucketOwnershipControls(ctx, rm.newGetBucketOwnershipControlsPayload(r))
-		if err != nil {
-			if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "OwnershipControlsNotFoundError" {
-				return err
-			}
-		}
-		if getOwnershipControlsResponse != nil {
-			ko.Spec.OwnershipControls = rm.setResourceOwnershipControls(r, getOwnershipControlsResponse)
-		} else {
-			ko.Spec.OwnershipControls = nil
-		}
+	if !isDirectoryBucket {
+		readers = append(readers,
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getLoggingResponse, err := rm.sdkapi.GetBucketLogging(ctx, rm.newGetBucketLoggingPayload(r))
+				if err != nil {
+					return nil, err
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getLoggingResponse.LoggingEnabled != nil {
+						ko.Spec.Logging = rm.setResourceLogging(r, getLoggingResponse)
+					} else {
+						ko.Spec.Logging = nil
+					}
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				metadataConfiguration, err := rm.getMetadataConfiguration(ctx, r)
+				if err != nil {
+					return nil, err
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if metadataConfiguration != nil {
+						ko.Spec.MetadataConfiguration = rm.setResourceMetadataConfiguration(r, metadataConfiguration)
+						ko.Status.MetadataConfigurationResult = newMetadataConfigurationResult(metadataConfiguration)
+					} else {
+						ko.Spec.MetadataConfiguration = nil
+						ko.Status.MetadataConfigurationResult = nil
+					}
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				listMetricsResponse, err := rm.sdkapi.ListBucketMetricsConfigurations(ctx, rm.newListBucketMetricsPayload(r))
+				if err != nil {
+					return nil, err
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if len(listMetricsResponse.MetricsConfigurationList) > 0 {
+						ko.Spec.Metrics = make([]*svcapitypes.MetricsConfiguration, len(listMetricsResponse.MetricsConfigurationList))
+						for i, metricsConfiguration := range listMetricsResponse.MetricsConfigurationList {
+							ko.Spec.Metrics[i] = rm.setResourceMetricsConfiguration(r, &metricsConfiguration)
+						}
+					} else {
+						ko.Spec.Metrics = nil
+					}
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getNotificationResponse, err := rm.sdkapi.GetBucketNotificationConfiguration(ctx, rm.newGetBucketNotificationPayload(r))
+				if err != nil {
+					return nil, err
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getNotificationResponse.LambdaFunctionConfigurations != nil ||
+						getNotificationResponse.QueueConfigurations != nil ||
+						getNotificationResponse.TopicConfigurations != nil {
+
+						ko.Spec.Notification = rm.setResourceNotification(r, getNotificationResponse)
+					} else {
+						ko.Spec.Notification = nil
+					}
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getOwnershipControlsResponse, err := rm.sdkapi.GetBucketOwnershipControls(ctx, rm.newGetBucketOwnershipControlsPayload(r))
+				if err != nil {
+					if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "OwnershipControlsNotFoundError" {
+						return nil, err
+					}
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getOwnershipControlsResponse != nil {
+						ko.Spec.OwnershipControls = rm.setResourceOwnershipControls(r, getOwnershipControlsResponse)
+					} else {
+						ko.Spec.OwnershipControls = nil
+					}
+				}, nil
+			},
+		)
 	}
 
 	// Policy is supported for both bucket types
-	getPolicyResponse, err := rm.sdkapi.GetBucketPolicy(ctx, rm.newGetBucketPolicyPayload(r))
-	if err != nil {
-		if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "NoSuchBucketPolicy" {
-			return err
-		}
-	}
-	if getPolicyResponse != nil {
-		ko.Spec.Policy = getPolicyResponse.Policy
-	} else {
-		ko.Spec.Policy = nil
-	}
-
-	if !isDirectoryBucket {
-		getPublicAccessBlockResponse, err := rm.sdkapi.GetPublicAccessBlock(ctx, rm.newGetPublicAccessBlockPayload(r))
-		if err != nil {
-			if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "NoSuchPublicAccessBlockConfiguration" {
-				return err
-			}
-		}
-		if getPublicAccessBlockResponse != nil {
-			ko.Spec.PublicAccessBlock = rm.setResourcePublicAccessBlock(r, getPublicAccessBlockResponse)
-		} else {
-			ko.Spec.PublicAccessBlock = nil
-		}
-
-		getReplicationResponse, err := rm.sdkapi.GetBucketReplication(ctx, rm.newGetBucketReplicationPayload(r))
-		if err != nil {
-			if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "ReplicationConfigurationNotFoundError" {
-				return err
+	readers = append(readers,
+		func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+			getPolicyResponse, err := rm.sdkapi.GetBucketPolicy(ctx, rm.newGetBucketPolicyPayload(r))
+			if err != nil {
+				if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "NoSuchBucketPolicy" {
+					return nil, err
+				}
 			}
-		}
-		if getReplicationResponse != nil {
-			ko.Spec.Replication = rm.setResourceReplication(r, getReplicationResponse)
-		} else {
-			ko.Spec.Replication = nil
-		}
+			return func(ko *svcapitypes.Bucket) {
+				if getPolicyResponse != nil {
+					rm.setResourcePolicy(r, ko, getPolicyResponse.Policy)
+				} else {
+					rm.setResourcePolicy(r, ko, nil)
+				}
+			}, nil
+		},
+	)
 
-		getRequestPaymentResponse, err := rm.sdkapi.GetBucketRequestPayment(ctx, rm.newGetBucketRequestPaymentPayload(r))
-		if err != nil {
-			return err
-		}
-		if getRequestPaymentResponse.Payer != "" {
-			ko.Spec.RequestPayment = rm.setResourceRequestPayment(r, getRequestPaymentResponse)
-		} else {
-			ko.Spec.RequestPayment = nil
-		}
+	if !isDirectoryBucket {
+		readers = append(readers,
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getPublicAccessBlockResponse, err := rm.sdkapi.GetPublicAccessBlock(ctx, rm.newGetPublicAccessBlockPayload(r))
+				if err != nil {
+					if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "NoSuchPublicAccessBlockConfiguration" {
+						return nil, err
+					}
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getPublicAccessBlockResponse != nil {
+						ko.Spec.PublicAccessBlock = rm.setResourcePublicAccessBlock(r, getPublicAccessBlockResponse)
+					} else {
+						ko.Spec.PublicAccessBlock = nil
+					}
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getReplicationResponse, err := rm.sdkapi.GetBucketReplication(ctx, rm.newGetBucketReplicationPayload(r))
+				if err != nil {
+					if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "ReplicationConfigurationNotFoundError" {
+						return nil, err
+					}
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getReplicationResponse != nil {
+						ko.Spec.Replication = rm.setResourceReplication(r, getReplicationResponse)
+					} else {
+						ko.Spec.Replication = nil
+					}
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getRequestPaymentResponse, err := rm.sdkapi.GetBucketRequestPayment(ctx, rm.newGetBucketRequestPaymentPayload(r))
+				if err != nil {
+					return nil, err
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getRequestPaymentResponse.Payer != "" {
+						ko.Spec.RequestPayment = rm.setResourceRequestPayment(r, getRequestPaymentResponse)
+					} else {
+						ko.Spec.RequestPayment = nil
+					}
+				}, nil
+			},
+		)
 	}
 
 	// Tagging - directory buckets use S3 Control API
 	if isDirectoryBucket {
-		if err := rm.getDirectoryBucketTagging(ctx, r, ko); err != nil {
-			return err
-		}
+		readers = append(readers,
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				observed := &svcapitypes.Bucket{}
+				if err := rm.getDirectoryBucketTagging(ctx, r, observed); err != nil {
+					return nil, err
+				}
+				return func(ko *svcapitypes.Bucket) {
+					ko.Spec.Tagging = observed.Spec.Tagging
+				}, nil
+			},
+		)
 	} else {
-		getTaggingResponse, err := rm.sdkapi.GetBucketTagging(ctx, rm.newGetBucketTaggingPayload(r))
-		if err != nil {
-			if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "NoSuchTagSet" {
-				return err
-			}
-		}
-		if getTaggingResponse != nil && getTaggingResponse.TagSet != nil {
-			ko.Spec.Tagging = rm.setResourceTagging(r, getTaggingResponse)
-		} else {
-			ko.Spec.Tagging = nil
-		}
+		readers = append(readers,
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getTaggingResponse, err := rm.sdkapi.GetBucketTagging(ctx, rm.newGetBucketTaggingPayload(r))
+				if err != nil {
+					if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "NoSuchTagSet" {
+						return nil, err
+					}
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getTaggingResponse != nil && getTaggingResponse.TagSet != nil {
+						ko.Spec.Tagging = rm.setResourceTagging(r, getTaggingResponse)
+					} else {
+						ko.Spec.Tagging = nil
+					}
+				}, nil
+			},
+		)
 	}
 
 	if !isDirectoryBucket {
-		getVersioningResponse, err := rm.sdkapi.GetBucketVersioning(ctx, rm.newGetBucketVersioningPayload(r))
-		if err != nil {
-			return err
-		}
-		if getVersioningResponse.Status != "" {
-			ko.Spec.Versioning = rm.setResourceVersioning(r, getVersioningResponse)
-		} else {
-			ko.Spec.Versioning = nil
-		}
-
-		getWebsiteResponse, err := rm.sdkapi.GetBucketWebsite(ctx, rm.newGetBucketWebsitePayload(r))
-		if err != nil {
-			if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "NoSuchWebsiteConfiguration" {
-				return err
-			}
-		}
-		if getWebsiteResponse != nil {
-			ko.Spec.Website = rm.setResourceWebsite(r, getWebsiteResponse)
-		} else {
-			ko.Spec.Website = nil
-		}
-
-		getObjectLockConfigResponse, err := rm.sdkapi.GetObjectLockConfiguration(ctx, rm.newGetBucketObjectLockConfigurationPayload(r))
-		if err != nil {
-			if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "ObjectLockConfigurationNotFoundError" {
-				return err
-			}
-		}
-		if getObjectLockConfigResponse != nil && getObjectLockConfigResponse.ObjectLockConfiguration != nil {
-			objectLockEnabled := getObjectLockConfigResponse.ObjectLockConfiguration.ObjectLockEnabled == svcsdktypes.ObjectLockEnabledEnabled
-			ko.Spec.ObjectLockEnabledForBucket = aws.Bool(objectLockEnabled)
-			ko.Spec.ObjectLockConfiguration = rm.setResourceObjectLockConfiguration(getObjectLockConfigResponse)
-		} else {
-			ko.Spec.ObjectLockConfiguration = nil
-			// Reflect that Object Lock is disabled, otherwise ko keeps the
-			// desired value and adopted buckets never get a delta.
-			if ko.Spec.ObjectLockEnabledForBucket != nil {
-				ko.Spec.ObjectLockEnabledForBucket = aws.Bool(false)
-			}
-		}
-	}
-
-	return nil
+		readers = append(readers,
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getVersioningResponse, err := rm.sdkapi.GetBucketVersioning(ctx, rm.newGetBucketVersioningPayload(r))
+				if err != nil {
+					return nil, err
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getVersioningResponse.Status != "" {
+						ko.Spec.Versioning = rm.setResourceVersioning(r, getVersioningResponse)
+					} else {
+						ko.Spec.Versioning = nil
+					}
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getWebsiteResponse, err := rm.sdkapi.GetBucketWebsite(ctx, rm.newGetBucketWebsitePayload(r))
+				if err != nil {
+					if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "NoSuchWebsiteConfiguration" {
+						return nil, err
+					}
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getWebsiteResponse != nil {
+						ko.Spec.Website = rm.setResourceWebsite(r, getWebsiteResponse)
+					} else {
+						ko.Spec.Website = nil
+					}
+				}, nil
+			},
+			func(ctx context.Context) (func(ko *svcapitypes.Bucket), error) {
+				getObjectLockConfigResponse, err := rm.sdkapi.GetObjectLockConfiguration(ctx, rm.newGetBucketObjectLockConfigurationPayload(r))
+				if err != nil {
+					if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.ErrorCode() != "ObjectLockConfigurationNotFoundError" {
+						return nil, err
+					}
+				}
+				return func(ko *svcapitypes.Bucket) {
+					if getObjectLockConfigResponse != nil && getObjectLockConfigResponse.ObjectLockConfiguration != nil {
+						objectLockEnabled := getObjectLockConfigResponse.ObjectLockConfiguration.ObjectLockEnabled == svcsdktypes.ObjectLockEnabledEnabled
+						ko.Spec.ObjectLockEnabledForBucket = aws.Bool(objectLockEnabled)
+						ko.Spec.ObjectLockConfiguration = rm.setResourceObjectLockConfiguration(getObjectLockConfigResponse)
+					} else {
+						ko.Spec.ObjectLockConfiguration = nil
+						// Reflect that Object Lock is disabled, otherwise ko keeps the
+						// desired value and adopted buckets never get a delta.
+						if ko.Spec.ObjectLockEnabledForBucket != nil {
+							ko.Spec.ObjectLockEnabledForBucket = aws.Bool(false)
+						}
+					}
+				}, nil
+			},
+		)
+	}
+
+	return readers
 }
 
 // customPreCompare ensures that default values of nil-able types are
 // appropriately replaced with empty maps or structs depending on the default
-// output of the SDK.
+// output of the SDK. Properties left out of an explicitly managed spec are
+// ignored instead. The BucketClass of the Bucket is merged under the desired
+// spec first, so that the delta takes it into account.
 func customPreCompare(
 	a *resource,
 	b *resource,
 ) {
+	// The delta has no context to read the BucketClass with. Failing to read
+	// it leaves the spec as declared: the update merges the class again
+	// before putting anything, and fails with the same error.
+	if a.ko.DeletionTimestamp == nil {
+		_ = applyBucketClass(context.TODO(), a.ko, false)
+	}
+	if isExplicitlyManaged(a) {
+		ignoreUndeclaredProperties(a, b)
+	}
 	if a.ko.Spec.Accelerate == nil && b.ko.Spec.Accelerate != nil {
 		a.ko.Spec.Accelerate = &svcapitypes.AccelerateConfiguration{}
 
@@ -776,6 +930,8 @@ func customPreCompare(
 	if a.ko.Spec.Encryption == nil && b.ko.Spec.Encryption != nil {
 		a.ko.Spec.Encryption = &svcapitypes.ServerSideEncryptionConfiguration{}
 	}
+	copyKMSKeyReferences(&a.ko.Spec, &b.ko.Spec)
+	copyBucketReferences(&a.ko.Spec, &b.ko.Spec)
 	if a.ko.Spec.IntelligentTiering == nil && b.ko.Spec.IntelligentTiering != nil {
 		a.ko.Spec.IntelligentTiering = make([]*svcapitypes.IntelligentTieringConfiguration, 0)
 	}
@@ -794,9 +950,16 @@ func customPreCompare(
 	if a.ko.Spec.Notification == nil && b.ko.Spec.Notification != nil {
 		a.ko.Spec.Notification = &svcapitypes.NotificationConfiguration{}
 	}
+	copyNotificationReferences(a.ko.Spec.Notification, b.ko.Spec.Notification)
 	if a.ko.Spec.OwnershipControls == nil && b.ko.Spec.OwnershipControls != nil {
 		a.ko.Spec.OwnershipControls = &svcapitypes.OwnershipControls{}
 	}
+	// S3 rewrites parts of the policy before returning it from GetBucketPolicy,
+	// so only diff the policy when the permissions it grants differ.
+	if a.ko.Spec.Policy != nil && b.ko.Spec.Policy != nil &&
+		iampolicy.Equal(*a.ko.Spec.Policy, *b.ko.Spec.Policy) {
+		b.ko.Spec.Policy = a.ko.Spec.Policy
+	}
 	if a.ko.Spec.PublicAccessBlock == nil && b.ko.Spec.PublicAccessBlock != nil {
 		a.ko.Spec.PublicAccessBlock = &DefaultPublicBlockAccess
 	}
@@ -1449,11 +1612,12 @@ func (rm *resourceManager) newGetBucketPolicyPayload(
 
 func (rm *resourceManager) newPutBucketPolicyPayload(
 	r *resource,
+	policy *string,
 	isDirectoryBucket bool,
 ) *svcsdk.PutBucketPolicyInput {
 	res := &svcsdk.PutBucketPolicyInput{}
 	res.Bucket = r.ko.Spec.Name
-	res.Policy = r.ko.Spec.Policy
+	res.Policy = policy
 
 	// ConfirmRemoveSelfBucketAccess is not supported by directory
 	if !isDirectoryBucket {
@@ -1474,12 +1638,13 @@ func (rm *resourceManager) newDeleteBucketPolicyPayload(
 func (rm *resourceManager) putPolicy(
 	ctx context.Context,
 	r *resource,
+	policy *string,
 	isDirectoryBucket bool,
 ) (err error) {
 	rlog := ackrtlog.FromContext(ctx)
 	exit := rlog.Trace("rm.putPolicy")
 	defer exit(err)
-	input := rm.newPutBucketPolicyPayload(r, isDirectoryBucket)
+	input := rm.newPutBucketPolicyPayload(r, policy, isDirectoryBucket)
 
 	_, err = rm.sdkapi.PutBucketPolicy(ctx, input)
 	rm.metrics.RecordAPICall("UPDATE", "PutBucketPolicy", err)
@@ -1513,10 +1678,20 @@ func (rm *resourceManager) syncPolicy(
 	r *resource,
 	isDirectoryBucket bool,
 ) (err error) {
-	if r.ko.Spec.Policy == nil || *r.ko.Spec.Policy == "" {
+	policy, err := rm.desiredPolicy(r)
+	if err != nil {
+		return err
+	}
+	if !isDirectoryBucket {
+		// Keep the grants other buckets made for delivering to this one
+		if policy, err = rm.withDestinationGrants(ctx, r, policy); err != nil {
+			return err
+		}
+	}
+	if policy == nil {
 		return rm.deletePolicy(ctx, r)
 	}
-	return rm.putPolicy(ctx, r, isDirectoryBucket)
+	return rm.putPolicy(ctx, r, policy, isDirectoryBucket)
 }
 
 //endregion
@@ -1866,13 +2041,9 @@ func (rm *resourceManager) putDirectoryBucketTagging(
 		}
 	}
 
-	// Status ARN may not be set yet during create
-	var bucketARN string
-	if r.ko.Status.ACKResourceMetadata != nil && r.ko.Status.ACKResourceMetadata.ARN != nil {
-		bucketARN = string(*r.ko.Status.ACKResourceMetadata.ARN)
-	} else {
-		bucketARN = rm.directoryBucketARN(*r.ko.Spec.Name)
-	}
+	// Always derive the ARN rather than trusting Status, which may not be set
+	// yet during create or may have been recorded with the wrong partition.
+	bucketARN := rm.directoryBucketARN(*r.ko.Spec.Name)
 	accountID := aws.String(string(rm.awsAccountID))
 
 	// S3Control TagResource only adds/updates tags. To reconcile fully,
@@ -1941,13 +2112,9 @@ func (rm *resourceManager) getDirectoryBucketTagging(
 
 	s3controlClient := s3control.NewFromConfig(rm.clientcfg)
 
-	// Status ARN may not be set yet during create
-	var bucketARN string
-	if r.ko.Status.ACKResourceMetadata != nil && r.ko.Status.ACKResourceMetadata.ARN != nil {
-		bucketARN = string(*r.ko.Status.ACKResourceMetadata.ARN)
-	} else {
-		bucketARN = rm.directoryBucketARN(*r.ko.Spec.Name)
-	}
+	// Always derive the ARN rather than trusting Status, which may not be set
+	// yet during create or may have been recorded with the wrong partition.
+	bucketARN := rm.directoryBucketARN(*r.ko.Spec.Name)
 	input := &s3control.ListTagsForResourceInput{
 		ResourceArn: aws.String(bucketARN),
 		AccountId:   aws.String(string(rm.awsAccountID)),
@@ -16,16 +16,25 @@ package bucket
 import (
 	"context"
 	"errors"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	smithy "github.com/aws/smithy-go"
 	smithymiddleware "github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
 	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3/types"
 
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
 
 	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
@@ -54,9 +63,53 @@ func apiErr(code string) error {
 // The defaults model a freshly-created bucket with no optional configuration:
 // the "Get*" operations whose property is unset return the not-found-style API
 // error that addPutFieldsToSpec is written to ignore, and the rest return an
-// empty output. Tests override individual operations via results.
-func newMockedSDKClient(results map[string]opResult) *svcsdk.Client {
+// empty output. Tests override individual operations via results, and may
+// further adjust the client options via optFns.
+func newMockedSDKClient(
+	results map[string]opResult,
+	optFns ...func(*svcsdk.Options),
+) *svcsdk.Client {
+	return svcsdk.New(svcsdk.Options{
+		Region: "us-west-2",
+		APIOptions: []func(*smithymiddleware.Stack) error{
+			func(stack *smithymiddleware.Stack) error {
+				return stack.Finalize.Add(mockFinalize(results), smithymiddleware.Before)
+			},
+		},
+	}, optFns...)
+}
+
+// newMockedClientConfig builds the client configuration of a resource manager
+// whose regional copies, see forRegion, answer like newMockedSDKClient with the
+// results given for their region.
+func newMockedClientConfig(
+	results map[ackv1alpha1.AWSRegion]map[string]opResult,
+) aws.Config {
+	return aws.Config{
+		Region: "us-west-2",
+		APIOptions: []func(*smithymiddleware.Stack) error{
+			func(stack *smithymiddleware.Stack) error {
+				return stack.Finalize.Add(smithymiddleware.FinalizeMiddlewareFunc(
+					"mockS3RegionalFinalize",
+					func(
+						ctx context.Context,
+						in smithymiddleware.FinalizeInput,
+						next smithymiddleware.FinalizeHandler,
+					) (smithymiddleware.FinalizeOutput, smithymiddleware.Metadata, error) {
+						region := ackv1alpha1.AWSRegion(awsmiddleware.GetRegion(ctx))
+						return mockFinalize(results[region]).HandleFinalize(ctx, in, next)
+					},
+				), smithymiddleware.Before)
+			},
+		},
+	}
+}
+
+// mockFinalize returns the Finalize middleware answering each operation with
+// its entry in results, or with its default.
+func mockFinalize(results map[string]opResult) smithymiddleware.FinalizeMiddleware {
 	defaults := map[string]opResult{
+		"HeadBucket":                                 {output: &svcsdk.HeadBucketOutput{}},
 		"GetBucketAbac":                              {output: &svcsdk.GetBucketAbacOutput{}},
 		"GetBucketAccelerateConfiguration":           {output: &svcsdk.GetBucketAccelerateConfigurationOutput{}},
 		"ListBucketAnalyticsConfigurations":          {output: &svcsdk.ListBucketAnalyticsConfigurationsOutput{}},
@@ -67,6 +120,7 @@ func newMockedSDKClient(results map[string]opResult) *svcsdk.Client {
 		"ListBucketInventoryConfigurations":          {output: &svcsdk.ListBucketInventoryConfigurationsOutput{}},
 		"GetBucketLifecycleConfiguration":            {err: apiErr("NoSuchLifecycleConfiguration")},
 		"GetBucketLogging":                           {output: &svcsdk.GetBucketLoggingOutput{}},
+		"GetBucketMetadataConfiguration":             {err: apiErr("MetadataConfigurationNotFound")},
 		"ListBucketMetricsConfigurations":            {output: &svcsdk.ListBucketMetricsConfigurationsOutput{}},
 		"GetBucketNotificationConfiguration":         {output: &svcsdk.GetBucketNotificationConfigurationOutput{}},
 		"GetBucketOwnershipControls":                 {err: apiErr("OwnershipControlsNotFoundError")},
@@ -80,7 +134,7 @@ func newMockedSDKClient(results map[string]opResult) *svcsdk.Client {
 		"GetObjectLockConfiguration":                 {err: apiErr("ObjectLockConfigurationNotFoundError")},
 	}
 
-	mockFinalize := smithymiddleware.FinalizeMiddlewareFunc(
+	return smithymiddleware.FinalizeMiddlewareFunc(
 		"mockS3Finalize",
 		func(
 			ctx context.Context,
@@ -95,15 +149,30 @@ func newMockedSDKClient(results map[string]opResult) *svcsdk.Client {
 			return smithymiddleware.FinalizeOutput{Result: res.output}, smithymiddleware.Metadata{}, res.err
 		},
 	)
+}
 
-	return svcsdk.New(svcsdk.Options{
-		Region: "us-west-2",
-		APIOptions: []func(*smithymiddleware.Stack) error{
-			func(stack *smithymiddleware.Stack) error {
-				return stack.Finalize.Add(mockFinalize, smithymiddleware.Before)
-			},
-		},
-	})
+// withLatency delays every operation of a mocked client by d, simulating the
+// round trip to S3.
+func withLatency(d time.Duration) func(*svcsdk.Options) {
+	return func(o *svcsdk.Options) {
+		o.APIOptions = append(o.APIOptions, func(stack *smithymiddleware.Stack) error {
+			return stack.Initialize.Add(smithymiddleware.InitializeMiddlewareFunc(
+				"mockS3Latency",
+				func(
+					ctx context.Context,
+					in smithymiddleware.InitializeInput,
+					next smithymiddleware.InitializeHandler,
+				) (smithymiddleware.InitializeOutput, smithymiddleware.Metadata, error) {
+					select {
+					case <-time.After(d):
+					case <-ctx.Done():
+						return smithymiddleware.InitializeOutput{}, smithymiddleware.Metadata{}, ctx.Err()
+					}
+					return next.HandleInitialize(ctx, in)
+				},
+			), smithymiddleware.Before)
+		})
+	}
 }
 
 func newBucketResource(name string) *resource {
@@ -132,8 +201,8 @@ func Test_addPutFieldsToSpec_RequestPaymentError(t *testing.T) {
 	rm := &resourceManager{
 		sdkapi: newMockedSDKClient(map[string]opResult{
 			"GetBucketRequestPayment": {err: requestPaymentErr},
-			// These succeed in AWS but must never be reached on the error path,
-			// and must never be reached *before* the error surfaces either.
+			// These succeed in AWS, but their values must never be applied
+			// to the spec when another read has failed.
 			"GetBucketTagging": {output: &svcsdk.GetBucketTaggingOutput{
 				TagSet: []svcsdktypes.Tag{{Key: strPtr("k"), Value: strPtr("v")}},
 			}},
@@ -192,6 +261,93 @@ func Test_addPutFieldsToSpec_ReadsAllFields(t *testing.T) {
 	assert.Equal("ack", *ko.Spec.Tagging.TagSet[0].Value)
 }
 
+// Test_addPutFieldsToSpec_ErrorLeavesSpecUntouched verifies that a failed read
+// does not leave the observed spec half-populated by the reads that completed
+// alongside it.
+func Test_addPutFieldsToSpec_ErrorLeavesSpecUntouched(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rm := &resourceManager{
+		sdkapi: newMockedSDKClient(map[string]opResult{
+			"GetBucketLogging": {err: apiErr("AccessDenied")},
+			"GetBucketVersioning": {output: &svcsdk.GetBucketVersioningOutput{
+				Status: svcsdktypes.BucketVersioningStatusEnabled,
+			}},
+		}),
+	}
+
+	desired := newBucketResource("my-test-bucket")
+	ko := desired.ko.DeepCopy()
+
+	err := rm.addPutFieldsToSpec(context.Background(), desired, ko)
+	require.Error(err)
+	assert.Nil(ko.Spec.Versioning)
+}
+
+// Test_addPutFieldsToSpec_FirstErrorInOrder verifies that when several reads
+// fail, the error of the first of them in declaration order is returned,
+// whatever order they complete in.
+func Test_addPutFieldsToSpec_FirstErrorInOrder(t *testing.T) {
+	rm := &resourceManager{
+		sdkapi: newMockedSDKClient(map[string]opResult{
+			"GetBucketCors":       {err: apiErr("AccessDenied")},
+			"GetBucketLogging":    {err: apiErr("InternalError")},
+			"GetBucketVersioning": {err: apiErr("SlowDown")},
+		}),
+	}
+
+	desired := newBucketResource("my-test-bucket")
+	for i := 0; i < 20; i++ {
+		err := rm.addPutFieldsToSpec(context.Background(), desired, desired.ko.DeepCopy())
+		awsErr, ok := ackerr.AWSError(err)
+		require.True(t, ok)
+		assert.Equal(t, "AccessDenied", awsErr.ErrorCode())
+	}
+}
+
+// Test_addPutFieldsToSpec_EncryptionNotFound verifies that a bucket reporting
+// no default encryption configuration leaves Spec.Encryption nil instead of
+// dereferencing the missing response.
+func Test_addPutFieldsToSpec_EncryptionNotFound(t *testing.T) {
+	require := require.New(t)
+
+	rm := &resourceManager{
+		sdkapi: newMockedSDKClient(map[string]opResult{
+			"GetBucketEncryption": {err: apiErr("ServerSideEncryptionConfigurationNotFoundError")},
+		}),
+	}
+
+	desired := newBucketResource("my-test-bucket")
+	ko := desired.ko.DeepCopy()
+
+	err := rm.addPutFieldsToSpec(context.Background(), desired, ko)
+	require.NoError(err)
+	require.Nil(ko.Spec.Encryption)
+}
+
+// BenchmarkAddPutFieldsToSpec measures reading every Put* property of a
+// general purpose bucket, with and without a simulated round trip to S3 on
+// each Get* call.
+func BenchmarkAddPutFieldsToSpec(b *testing.B) {
+	for _, latency := range []time.Duration{0, 10 * time.Millisecond} {
+		b.Run(latency.String(), func(b *testing.B) {
+			rm := &resourceManager{
+				sdkapi: newMockedSDKClient(nil, withLatency(latency)),
+			}
+			desired := newBucketResource("my-bench-bucket")
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ko := desired.ko.DeepCopy()
+				if err := rm.addPutFieldsToSpec(context.Background(), desired, ko); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 // Test_addPutFieldsToSpec_ObjectLockNotEnabledOnBucket is a regression test
 // for aws-controllers-k8s/community#2965. When the bucket has no Object Lock
 // configuration, the observed spec must report ObjectLockEnabledForBucket as
@@ -438,3 +594,239 @@ func Test_addCreateBucketTags(t *testing.T) {
 	assert.Equal(svcsdktypes.BucketLocationConstraint("eu-west-1"), input2.CreateBucketConfiguration.LocationConstraint)
 	assert.Len(input2.CreateBucketConfiguration.Tags, 2)
 }
+
+// headBucketErr builds the error the SDK returns for a HeadBucket response
+// with the given status code. HeadBucket responses have no body, so the code
+// is derived from the status text and any region hint is only available in
+// the response headers.
+func headBucketErr(statusCode int, header http.Header) error {
+	return &awshttp.ResponseError{
+		ResponseError: &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{
+				StatusCode: statusCode,
+				Header:     header,
+			}},
+			Err: apiErr(
+				strings.ReplaceAll(http.StatusText(statusCode), " ", ""),
+			),
+		},
+	}
+}
+
+// Test_customFindBucket_Exists verifies that an existing bucket is described
+// and gets its ARN set without listing the buckets in the account.
+func Test_customFindBucket_Exists(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rm := &resourceManager{
+		sdkapi: newMockedSDKClient(map[string]opResult{
+			// Fail loudly if the List APIs are still used for existence checks.
+			"ListBuckets":          {err: apiErr("ShouldNotBeCalled")},
+			"ListDirectoryBuckets": {err: apiErr("ShouldNotBeCalled")},
+		}),
+		metrics: ackmetrics.NewMetrics("s3"),
+	}
+
+	desired := newBucketResource("my-test-bucket")
+	desired.ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+
+	latest, err := rm.customFindBucket(context.Background(), desired)
+	require.NoError(err)
+	require.NotNil(latest.ko.Status.ACKResourceMetadata.ARN)
+	assert.Equal("arn:aws:s3:::my-test-bucket", string(*latest.ko.Status.ACKResourceMetadata.ARN))
+}
+
+// Test_customFindBucket_NotFound verifies that a 404 from HeadBucket is
+// reported as NotFound so the bucket gets created.
+func Test_customFindBucket_NotFound(t *testing.T) {
+	require := require.New(t)
+
+	rm := &resourceManager{
+		sdkapi: newMockedSDKClient(map[string]opResult{
+			"HeadBucket": {err: headBucketErr(http.StatusNotFound, nil)},
+		}),
+		metrics: ackmetrics.NewMetrics("s3"),
+	}
+
+	_, err := rm.customFindBucket(context.Background(), newBucketResource("my-test-bucket"))
+	require.ErrorIs(err, ackerr.NotFound)
+}
+
+// Test_customFindBucket_OtherRegion verifies that a bucket living in another
+// region than the resource manager targets is read through a resource manager
+// for that region, and that the region is recorded in the status only.
+func Test_customFindBucket_OtherRegion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	header := http.Header{}
+	header.Set("x-amz-bucket-region", "eu-west-1")
+	rm := &resourceManager{
+		sdkapi: newMockedSDKClient(map[string]opResult{
+			"HeadBucket": {err: headBucketErr(http.StatusMovedPermanently, header)},
+			// Everything but HeadBucket must go to the bucket's region.
+			"GetBucketVersioning": {err: apiErr("PermanentRedirect")},
+		}),
+		clientcfg: newMockedClientConfig(map[ackv1alpha1.AWSRegion]map[string]opResult{
+			"eu-west-1": {
+				"GetBucketVersioning": {output: &svcsdk.GetBucketVersioningOutput{
+					Status: svcsdktypes.BucketVersioningStatusEnabled,
+				}},
+			},
+		}),
+		metrics:      ackmetrics.NewMetrics("s3"),
+		awsAccountID: "111122223333",
+		awsRegion:    "us-west-2",
+	}
+
+	desired := newBucketResource("my-eu-bucket")
+	latest, err := rm.customFindBucket(context.Background(), desired)
+	require.NoError(err)
+	require.NotNil(latest.ko.Spec.Versioning)
+	assert.Equal("Enabled", *latest.ko.Spec.Versioning.Status)
+	assert.Equal("eu-west-1", *latest.ko.Status.BucketRegion)
+	assert.Equal(ackv1alpha1.AWSRegion("us-west-2"), *latest.ko.Status.ACKResourceMetadata.Region)
+	assert.Empty(latest.ko.Annotations)
+	assert.Nil(desired.ko.Status.BucketRegion)
+}
+
+// Test_customFindBucket_RecordedRegion verifies that a bucket is first looked
+// for in the region recorded in its status.
+func Test_customFindBucket_RecordedRegion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rm := &resourceManager{
+		sdkapi: newMockedSDKClient(map[string]opResult{
+			"HeadBucket": {err: apiErr("UnexpectedCall")},
+		}),
+		clientcfg:    newMockedClientConfig(map[ackv1alpha1.AWSRegion]map[string]opResult{}),
+		metrics:      ackmetrics.NewMetrics("s3"),
+		awsAccountID: "111122223333",
+		awsRegion:    "us-west-2",
+	}
+
+	desired := newBucketResource("my-eu-bucket")
+	desired.ko.Status.BucketRegion = strPtr("eu-west-1")
+	latest, err := rm.customFindBucket(context.Background(), desired)
+	require.NoError(err)
+	assert.Equal("eu-west-1", *latest.ko.Status.BucketRegion)
+}
+
+// Test_managerForResource verifies that update and delete use the region
+// recorded by the read, and look it up when none is recorded rather than
+// falling back to the region of the resource manager.
+func Test_managerForResource(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	header := http.Header{}
+	header.Set("x-amz-bucket-region", "eu-west-1")
+	rm := &resourceManager{
+		sdkapi: newMockedSDKClient(map[string]opResult{
+			"HeadBucket": {err: headBucketErr(http.StatusMovedPermanently, header)},
+		}),
+		clientcfg:    newMockedClientConfig(map[ackv1alpha1.AWSRegion]map[string]opResult{}),
+		metrics:      ackmetrics.NewMetrics("s3"),
+		awsAccountID: "111122223333",
+		awsRegion:    "us-west-2",
+	}
+
+	recorded := newBucketResource("my-eu-bucket")
+	recorded.ko.Status.BucketRegion = strPtr("eu-west-1")
+	target, err := rm.managerForResource(context.Background(), recorded)
+	require.NoError(err)
+	assert.Equal(ackv1alpha1.AWSRegion("eu-west-1"), target.awsRegion)
+
+	target, err = rm.managerForResource(context.Background(), newBucketResource("my-eu-bucket"))
+	require.NoError(err)
+	assert.Equal(ackv1alpha1.AWSRegion("eu-west-1"), target.awsRegion)
+
+	recorded.ko.Status.BucketRegion = strPtr("us-west-2")
+	target, err = rm.managerForResource(context.Background(), recorded)
+	require.NoError(err)
+	assert.Same(rm, target)
+}
+
+// Test_terminalAWSError_PermanentRedirect verifies that a redirect to the
+// bucket's region is retried rather than treated as terminal.
+func Test_terminalAWSError_PermanentRedirect(t *testing.T) {
+	rm := &resourceManager{}
+	assert.False(t, rm.terminalAWSError(apiErr("PermanentRedirect")))
+	assert.True(t, rm.terminalAWSError(apiErr("InvalidLocationConstraint")))
+}
+
+// Test_customFindBucket_OwnedByAnotherAccount verifies that a 403 from
+// HeadBucket is not mistaken for NotFound, which would make the controller
+// attempt to create a bucket it can never own.
+func Test_customFindBucket_OwnedByAnotherAccount(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rm := &resourceManager{
+		sdkapi: newMockedSDKClient(map[string]opResult{
+			"HeadBucket": {err: headBucketErr(http.StatusForbidden, nil)},
+		}),
+		metrics:      ackmetrics.NewMetrics("s3"),
+		awsAccountID: "111122223333",
+	}
+
+	_, err := rm.customFindBucket(context.Background(), newBucketResource("someone-elses-bucket"))
+	require.Error(err)
+	assert.False(errors.Is(err, ackerr.NotFound))
+	var termErr *ackerr.TerminalError
+	assert.True(errors.As(err, &termErr))
+	assert.Contains(err.Error(), "owned by another account")
+	assert.Contains(err.Error(), "not allowed s3:ListBucket")
+}
+
+// Test_bucketARN_Partition verifies that bucket ARNs are built in the
+// partition the controller runs in, falling back to the partition of the
+// region when the controller could not resolve one.
+func Test_bucketARN_Partition(t *testing.T) {
+	tests := []struct {
+		name          string
+		partition     ackv1alpha1.AWSPartition
+		region        ackv1alpha1.AWSRegion
+		wantBucket    string
+		wantDirectory string
+	}{
+		{
+			name:          "commercial",
+			partition:     "aws",
+			region:        "us-west-2",
+			wantBucket:    "arn:aws:s3:::my-bucket",
+			wantDirectory: "arn:aws:s3express:us-west-2:111122223333:bucket/my-bucket--usw2-az1--x-s3",
+		},
+		{
+			name:          "govcloud",
+			partition:     "aws-us-gov",
+			region:        "us-gov-west-1",
+			wantBucket:    "arn:aws-us-gov:s3:::my-bucket",
+			wantDirectory: "arn:aws-us-gov:s3express:us-gov-west-1:111122223333:bucket/my-bucket--usw2-az1--x-s3",
+		},
+		{
+			name:          "china, partition derived from region",
+			region:        "cn-north-1",
+			wantBucket:    "arn:aws-cn:s3:::my-bucket",
+			wantDirectory: "arn:aws-cn:s3express:cn-north-1:111122223333:bucket/my-bucket--usw2-az1--x-s3",
+		},
+		{
+			name:          "unknown region defaults to aws",
+			wantBucket:    "arn:aws:s3:::my-bucket",
+			wantDirectory: "arn:aws:s3express::111122223333:bucket/my-bucket--usw2-az1--x-s3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := &resourceManager{
+				awsPartition: tt.partition,
+				awsRegion:    tt.region,
+				awsAccountID: "111122223333",
+			}
+			assert.Equal(t, tt.wantBucket, rm.bucketARN("my-bucket"))
+			assert.Equal(t, tt.wantDirectory, rm.directoryBucketARN("my-bucket--usw2-az1--x-s3"))
+		})
+	}
+}
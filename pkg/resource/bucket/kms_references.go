@@ -0,0 +1,53 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// copyKMSKeyReferences copies the KMS key reference fields of the desired
+// encryption and replication rules, which S3 knows nothing about, to the
+// latest ones.
+func copyKMSKeyReferences(
+	desired *svcapitypes.BucketSpec,
+	latest *svcapitypes.BucketSpec,
+) {
+	if desired.Encryption != nil && latest.Encryption != nil {
+		for i, rule := range latest.Encryption.Rules {
+			if i >= len(desired.Encryption.Rules) {
+				break
+			}
+			desiredRule := desired.Encryption.Rules[i]
+			if rule == nil || rule.ApplyServerSideEncryptionByDefault == nil ||
+				desiredRule == nil || desiredRule.ApplyServerSideEncryptionByDefault == nil {
+				continue
+			}
+			rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyRef = desiredRule.ApplyServerSideEncryptionByDefault.KMSMasterKeyRef
+		}
+	}
+	if desired.Replication != nil && latest.Replication != nil {
+		for i, rule := range latest.Replication.Rules {
+			if i >= len(desired.Replication.Rules) {
+				break
+			}
+			desiredRule := desired.Replication.Rules[i]
+			if rule == nil || rule.Destination == nil || rule.Destination.EncryptionConfiguration == nil ||
+				desiredRule == nil || desiredRule.Destination == nil || desiredRule.Destination.EncryptionConfiguration == nil {
+				continue
+			}
+			rule.Destination.EncryptionConfiguration.ReplicaKMSKeyRef = desiredRule.Destination.EncryptionConfiguration.ReplicaKMSKeyRef
+		}
+	}
+}
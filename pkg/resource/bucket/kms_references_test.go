@@ -0,0 +1,129 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	kmsapitypes "github.com/aws-controllers-k8s/kms-controller/apis/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// Test_ResolveReferences_KMSKeys verifies that the encryption and replication
+// KMS keys are resolved to the ARNs of the referenced KMS Keys.
+func Test_ResolveReferences_KMSKeys(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	const (
+		keyARN     = "arn:aws:kms:us-west-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+		replicaARN = "arn:aws:kms:us-east-1:111122223333:key/5678efgh-56ef-78gh-90ij-5678901234ef"
+	)
+	apiReader := newReferencedResourceReader(t,
+		&kmsapitypes.Key{
+			ObjectMeta: metav1.ObjectMeta{Name: "bucket-key", Namespace: "default"},
+			Status: kmsapitypes.KeyStatus{
+				ACKResourceMetadata: newReferencedResourceMetadata(keyARN),
+				Conditions:          newReferencedResourceConditions(corev1.ConditionTrue),
+			},
+		},
+		&kmsapitypes.Key{
+			ObjectMeta: metav1.ObjectMeta{Name: "replica", Namespace: "default"},
+			Status: kmsapitypes.KeyStatus{
+				ACKResourceMetadata: newReferencedResourceMetadata(replicaARN),
+				Conditions:          newReferencedResourceConditions(corev1.ConditionTrue),
+			},
+		},
+		&kmsapitypes.Key{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+			Status: kmsapitypes.KeyStatus{
+				Conditions: newReferencedResourceConditions(corev1.ConditionFalse),
+			},
+		},
+	)
+
+	rm := &resourceManager{}
+	desired := newBucketResource("my-bucket")
+	desired.ko.Namespace = "default"
+	desired.ko.Spec.Encryption = &svcapitypes.ServerSideEncryptionConfiguration{
+		Rules: []*svcapitypes.ServerSideEncryptionRule{{
+			ApplyServerSideEncryptionByDefault: &svcapitypes.ServerSideEncryptionByDefault{
+				SSEAlgorithm:    aws.String("aws:kms"),
+				KMSMasterKeyRef: newReference("bucket-key"),
+			},
+		}},
+	}
+	desired.ko.Spec.Replication = &svcapitypes.ReplicationConfiguration{
+		Role: aws.String("arn:aws:iam::111122223333:role/replication"),
+		Rules: []*svcapitypes.ReplicationRule{{
+			Destination: &svcapitypes.Destination{
+				Bucket: aws.String("arn:aws:s3:::replica"),
+				EncryptionConfiguration: &svcapitypes.EncryptionConfiguration{
+					ReplicaKMSKeyRef: newReference("replica"),
+				},
+			},
+		}},
+	}
+
+	resolved, hasReferences, err := rm.ResolveReferences(context.Background(), apiReader, desired.DeepCopy())
+	require.NoError(err)
+	assert.True(hasReferences)
+	spec := rm.concreteResource(resolved).ko.Spec
+	assert.Equal(keyARN, *spec.Encryption.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID)
+	assert.Equal(replicaARN, *spec.Replication.Rules[0].Destination.EncryptionConfiguration.ReplicaKMSKeyID)
+
+	// The resolved key IDs are cleared before the spec is written back
+	cleared := rm.concreteResource(rm.ClearResolvedReferences(resolved)).ko.Spec
+	assert.Nil(cleared.Encryption.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID)
+	assert.Nil(cleared.Replication.Rules[0].Destination.EncryptionConfiguration.ReplicaKMSKeyID)
+
+	// The references are not reported as a difference with the latest state
+	latest := newBucketResource("my-bucket")
+	latest.ko.Spec.Encryption = &svcapitypes.ServerSideEncryptionConfiguration{
+		Rules: []*svcapitypes.ServerSideEncryptionRule{{
+			ApplyServerSideEncryptionByDefault: &svcapitypes.ServerSideEncryptionByDefault{
+				SSEAlgorithm:   aws.String("aws:kms"),
+				KMSMasterKeyID: aws.String(keyARN),
+			},
+		}},
+	}
+	latest.ko.Spec.Replication = spec.Replication.DeepCopy()
+	latest.ko.Spec.Replication.Rules[0].Destination.EncryptionConfiguration.ReplicaKMSKeyRef = nil
+	delta := newResourceDelta(rm.concreteResource(resolved), latest)
+	assert.False(delta.DifferentAt("Spec.Encryption"))
+	assert.False(delta.DifferentAt("Spec.Replication"))
+
+	// A key which is not synced yet is waited for
+	desired.ko.Spec.Encryption.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyRef = newReference("pending")
+	_, _, err = rm.ResolveReferences(context.Background(), apiReader, desired.DeepCopy())
+	assert.ErrorContains(err, "pending")
+
+	// A key which does not exist at all is reported
+	desired.ko.Spec.Encryption.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyRef = newReference("missing")
+	_, _, err = rm.ResolveReferences(context.Background(), apiReader, desired.DeepCopy())
+	assert.ErrorContains(err, "missing")
+
+	// The key ID and the reference cannot both be set
+	desired.ko.Spec.Encryption.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyRef = newReference("bucket-key")
+	desired.ko.Spec.Encryption.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = aws.String(keyARN)
+	_, _, err = rm.ResolveReferences(context.Background(), apiReader, desired.DeepCopy())
+	assert.ErrorContains(err, "KMSMasterKeyRef")
+}
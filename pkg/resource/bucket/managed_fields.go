@@ -0,0 +1,103 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// By default, a bucket property left out of the spec is compared to, and
+// eventually applied as, its empty configuration, so that the controller
+// removes it from the bucket. Buckets whose Spec.ManagedFields is explicit
+// only have the properties declared in their spec managed: the others are
+// left out of the comparison with the bucket and never applied, leaving them
+// to other tools writing bucket configuration, such as Macie, AWS Backup or
+// security automation.
+//
+// A property is declared when any of the spec fields it is set through is
+// set, see syncStep.declaredBy. The mandatory policy statements are only
+// merged into the policy of buckets declaring one, as they would otherwise
+// replace the policy set by other tools.
+
+// managedFieldsExplicit is the Spec.ManagedFields value under which only the
+// bucket properties declared in the spec are managed.
+const managedFieldsExplicit = "explicit"
+
+// isExplicitlyManaged returns true if only the bucket properties declared in
+// the spec of the supplied resource are managed.
+func isExplicitlyManaged(r *resource) bool {
+	if r == nil || r.ko == nil {
+		return false
+	}
+	return aws.ToString(r.ko.Spec.ManagedFields) == managedFieldsExplicit
+}
+
+// specField returns the top-level spec field of ko at the given path, e.g.
+// "Spec.Lifecycle", or the zero Value if there is none.
+func specField(ko *svcapitypes.Bucket, path string) reflect.Value {
+	name, ok := strings.CutPrefix(path, "Spec.")
+	if !ok || strings.Contains(name, ".") {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(&ko.Spec).Elem().FieldByName(name)
+}
+
+// isFieldSet returns true if the top-level spec field of ko at the given path
+// is set. Empty lists are set, and declare that the property has no
+// configurations.
+func isFieldSet(ko *svcapitypes.Bucket, path string) bool {
+	field := specField(ko, path)
+	return field.IsValid() && !field.IsZero()
+}
+
+// declaredFields returns the spec fields the property of the given step is
+// declared by.
+func (s *syncStep) declaredFields() []string {
+	if s.declaredBy != nil {
+		return s.declaredBy
+	}
+	return s.fields
+}
+
+// isDeclared returns true if the property of the given step is declared in
+// the spec of the supplied resource.
+func (s *syncStep) isDeclared(r *resource) bool {
+	for _, path := range s.declaredFields() {
+		if isFieldSet(r.ko, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoreUndeclaredProperties unsets, in the spec of latest, the bucket
+// properties that desired does not declare, so that they never show up as a
+// difference.
+func ignoreUndeclaredProperties(desired, latest *resource) {
+	for _, step := range bucketSyncSteps {
+		if step.isDeclared(desired) {
+			continue
+		}
+		for _, path := range step.declaredFields() {
+			if field := specField(latest.ko, path); field.IsValid() {
+				field.SetZero()
+			}
+		}
+	}
+}
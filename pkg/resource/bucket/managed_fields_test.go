@@ -0,0 +1,148 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// observedBucket returns a bucket configured outside of the controller.
+func observedBucket() *resource {
+	r := newBucketResource("my-bucket")
+	r.ko.Spec.Lifecycle = &svcapitypes.BucketLifecycleConfiguration{
+		Rules: []*svcapitypes.LifecycleRule{{ID: strPtr("backup"), Status: strPtr("Enabled")}},
+	}
+	r.ko.Spec.Notification = &svcapitypes.NotificationConfiguration{
+		QueueConfigurations: []*svcapitypes.QueueConfiguration{
+			{QueueARN: strPtr("arn:aws:sqs:us-west-2:111122223333:macie")},
+		},
+	}
+	r.ko.Spec.Policy = strPtr(accountPolicy)
+	r.ko.Spec.Tagging = &svcapitypes.Tagging{
+		TagSet: []*svcapitypes.Tag{{Key: strPtr("backup"), Value: strPtr("daily")}},
+	}
+	r.ko.Spec.Versioning = versioning("Enabled")
+	r.ko.Spec.Replication = replication()
+	return r
+}
+
+func Test_newResourceDelta_ManagedFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		managedFields *string
+		desired       func(ko *svcapitypes.Bucket)
+		wantDiffs     []string
+		wantNoDiffs   []string
+	}{
+		{
+			name:      "properties left out are removed by default",
+			desired:   func(ko *svcapitypes.Bucket) {},
+			wantDiffs: []string{"Spec.Lifecycle", "Spec.Notification", "Spec.Tagging", "Spec.Replication"},
+		},
+		{
+			name:          "properties left out are removed when all are managed",
+			managedFields: strPtr("all"),
+			desired:       func(ko *svcapitypes.Bucket) {},
+			wantDiffs:     []string{"Spec.Lifecycle", "Spec.Notification", "Spec.Tagging"},
+		},
+		{
+			name:          "properties left out are ignored when explicit",
+			managedFields: strPtr(managedFieldsExplicit),
+			desired:       func(ko *svcapitypes.Bucket) {},
+			wantNoDiffs: []string{
+				"Spec.Lifecycle", "Spec.Notification", "Spec.Policy",
+				"Spec.Tagging", "Spec.Versioning", "Spec.Replication",
+			},
+		},
+		{
+			name:          "declared properties are compared when explicit",
+			managedFields: strPtr(managedFieldsExplicit),
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Spec.Tagging = &svcapitypes.Tagging{
+					TagSet: []*svcapitypes.Tag{{Key: strPtr("team"), Value: strPtr("storage")}},
+				}
+				ko.Spec.Versioning = versioning("Suspended")
+			},
+			wantDiffs:   []string{"Spec.Tagging", "Spec.Versioning"},
+			wantNoDiffs: []string{"Spec.Lifecycle", "Spec.Notification", "Spec.Replication"},
+		},
+		{
+			name:          "empty lists declare no configurations when explicit",
+			managedFields: strPtr(managedFieldsExplicit),
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Spec.Lifecycle = &svcapitypes.BucketLifecycleConfiguration{
+					Rules: []*svcapitypes.LifecycleRule{},
+				}
+			},
+			wantDiffs:   []string{"Spec.Lifecycle"},
+			wantNoDiffs: []string{"Spec.Notification", "Spec.Tagging"},
+		},
+		{
+			name:          "policy declared through its document when explicit",
+			managedFields: strPtr(managedFieldsExplicit),
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Spec.PolicyDocument = &svcapitypes.BucketPolicyDocument{}
+			},
+			wantDiffs: []string{"Spec.Policy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			desired := newBucketResource("my-bucket")
+			desired.ko.Spec.ManagedFields = tt.managedFields
+			tt.desired(desired.ko)
+			latest := observedBucket()
+			latest.ko.Spec.ManagedFields = tt.managedFields
+
+			delta := newResourceDelta(desired, latest)
+			for _, field := range tt.wantDiffs {
+				assert.True(delta.DifferentAt(field), field)
+			}
+			for _, field := range tt.wantNoDiffs {
+				assert.False(delta.DifferentAt(field), field)
+			}
+		})
+	}
+}
+
+func Test_planBucketSync_ManagedFieldsExplicit(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	desired := newBucketResource("my-bucket")
+	desired.ko.Spec.ManagedFields = strPtr(managedFieldsExplicit)
+	desired.ko.Spec.Replication = replication()
+	latest := observedBucket()
+
+	// Versioning is only planned along with replication when declared.
+	delta := deltaAt("Spec.Lifecycle", "Spec.Replication", "Spec.Tagging")
+	plan, err := planBucketSync(bucketSyncSteps, desired, latest, delta, false)
+	require.NoError(err)
+	order, _ := planProperties(plan)
+	assert.Equal([]string{"Replication"}, order)
+
+	desired.ko.Spec.Versioning = versioning("Enabled")
+	plan, err = planBucketSync(bucketSyncSteps, desired, latest, delta, false)
+	require.NoError(err)
+	order, dependsOn := planProperties(plan)
+	assert.Equal([]string{"Versioning", "Replication"}, order)
+	assert.Equal(map[string][]string{"Replication": {"Versioning"}}, dependsOn)
+}
@@ -0,0 +1,263 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
+)
+
+// Mandatory policy statements are configured for the whole controller, and
+// merged by the controller into the policy of every general purpose bucket.
+// They are identified by their Sid: statements of the bucket policy with the
+// same Sid are replaced by the mandatory ones, and the mandatory statements
+// are left out of the policy read back from S3, so that they never show up as
+// a difference with the policy in the spec. Should a mandatory statement be
+// removed or altered outside of the controller, the policy is reported as
+// different and put again.
+//
+// The statements are read from a JSON file, typically mounted from a
+// ConfigMap, holding an array of IAM policy statements. Their string values
+// may use the same placeholders as Spec.PolicyDocument, e.g.:
+//
+//	[{
+//	  "Sid": "DenyInsecureTransport",
+//	  "Effect": "Deny",
+//	  "Principal": "*",
+//	  "Action": "s3:*",
+//	  "Resource": ["${bucket.arn}", "${bucket.arn}/*"],
+//	  "Condition": {"Bool": {"aws:SecureTransport": "false"}}
+//	}]
+//
+// The file is read once, the first time a bucket policy is reconciled, so the
+// controller must be restarted for changes to take effect. Its path is set by
+// the --bucket-policy-mandatory-statements-file flag of the controller.
+//
+// The policy of a new bucket is only fully applied by the reconciliation that
+// follows its creation, so the mandatory statements are put on their own right
+// after the bucket is created, see putMandatoryPolicyStatements.
+
+// MandatoryPolicyStatementsFile is the path of the file holding the mandatory
+// policy statements, if any.
+var MandatoryPolicyStatementsFile string
+
+// mandatoryPolicyStatements are the policy statements merged into the policy
+// of every general purpose bucket.
+type mandatoryPolicyStatements struct {
+	statements []*iampolicy.Statement
+	sids       map[string]bool
+}
+
+// loadMandatoryPolicyStatements returns the mandatory policy statements, or
+// nil if none are configured.
+var loadMandatoryPolicyStatements = sync.OnceValues(func() (*mandatoryPolicyStatements, error) {
+	return readMandatoryPolicyStatements(MandatoryPolicyStatementsFile)
+})
+
+// readMandatoryPolicyStatements reads the mandatory policy statements from
+// the file at path, or returns nil if path is empty.
+func readMandatoryPolicyStatements(path string) (*mandatoryPolicyStatements, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read mandatory bucket policy statements: %w", err)
+	}
+	return parseMandatoryPolicyStatements(data)
+}
+
+// parseMandatoryPolicyStatements parses a JSON array of policy statements,
+// each of which must have a distinct Sid.
+func parseMandatoryPolicyStatements(data []byte) (*mandatoryPolicyStatements, error) {
+	var rawStatements []json.RawMessage
+	if err := json.Unmarshal(data, &rawStatements); err != nil {
+		return nil, fmt.Errorf("invalid mandatory bucket policy statements: %w", err)
+	}
+	mandatory := &mandatoryPolicyStatements{sids: map[string]bool{}}
+	for i, rawStatement := range rawStatements {
+		statement, err := iampolicy.ParseStatement(rawStatement)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mandatory bucket policy statement %d: %w", i, err)
+		}
+		if statement.Sid == "" {
+			return nil, fmt.Errorf("mandatory bucket policy statement %d has no Sid", i)
+		}
+		if mandatory.sids[statement.Sid] {
+			return nil, fmt.Errorf("duplicate mandatory bucket policy statement Sid %q", statement.Sid)
+		}
+		mandatory.sids[statement.Sid] = true
+		mandatory.statements = append(mandatory.statements, statement)
+	}
+	return mandatory, nil
+}
+
+// resolve returns copies of the mandatory statements with their placeholders
+// resolved by placeholders.
+func (m *mandatoryPolicyStatements) resolve(
+	placeholders *strings.Replacer,
+) []*iampolicy.Statement {
+	resolved := make([]*iampolicy.Statement, 0, len(m.statements))
+	for _, statement := range m.statements {
+		resolved = append(resolved, statement.Resolve(placeholders))
+	}
+	return resolved
+}
+
+// mandatoryPolicyStatementsFor returns the mandatory policy statements that
+// apply to the given bucket, with their placeholders resolved, or nil if
+// there are none.
+func (rm *resourceManager) mandatoryPolicyStatementsFor(
+	r *resource,
+) (*mandatoryPolicyStatements, []*iampolicy.Statement, error) {
+	// Directory buckets only support the s3express:CreateSession action.
+	if IsDirectoryBucketName(aws.ToString(r.ko.Spec.Name)) {
+		return nil, nil, nil
+	}
+	mandatory, err := loadMandatoryPolicyStatements()
+	if err != nil || mandatory == nil || len(mandatory.statements) == 0 {
+		return nil, nil, err
+	}
+	return mandatory, mandatory.resolve(rm.policyPlaceholders(r)), nil
+}
+
+// withMandatoryPolicyStatements returns the given bucket policy with the
+// mandatory statements merged in, replacing any statement with the same Sid.
+// The policy may be nil, in which case only the mandatory statements are
+// returned.
+func (rm *resourceManager) withMandatoryPolicyStatements(
+	r *resource,
+	policy *string,
+) (*string, error) {
+	mandatory, statements, err := rm.mandatoryPolicyStatementsFor(r)
+	if err != nil || mandatory == nil {
+		return policy, err
+	}
+
+	doc := &iampolicy.Document{Version: defaultPolicyDocumentVersion}
+	if policy != nil {
+		if doc, err = iampolicy.Parse(*policy); err != nil {
+			return nil, fmt.Errorf("cannot merge mandatory statements into bucket policy: %w", err)
+		}
+	}
+	merged := make([]*iampolicy.Statement, 0, len(doc.Statement)+len(statements))
+	for _, statement := range doc.Statement {
+		if !mandatory.sids[statement.Sid] {
+			merged = append(merged, statement)
+		}
+	}
+	doc.Statement = append(merged, statements...)
+
+	rendered, err := doc.Render()
+	if err != nil {
+		return nil, err
+	}
+	return &rendered, nil
+}
+
+// withoutMandatoryPolicyStatements returns the given bucket policy, as read
+// from S3, without the mandatory statements, or nil if no other statement is
+// left. If any mandatory statement is missing or differs from its
+// configuration, a policy that differs from the policy in the spec is
+// returned instead, so that the policy is put again: the policy as read if it
+// already differs, or else a policy without statements.
+func (rm *resourceManager) withoutMandatoryPolicyStatements(
+	r *resource,
+	policy *string,
+) *string {
+	mandatory, statements, err := rm.mandatoryPolicyStatementsFor(r)
+	if err != nil || mandatory == nil {
+		return policy
+	}
+
+	doc := &iampolicy.Document{Version: defaultPolicyDocumentVersion}
+	if policy != nil {
+		if doc, err = iampolicy.Parse(*policy); err != nil {
+			return policy
+		}
+	}
+
+	remaining := []*iampolicy.Statement{}
+	found := 0
+	for _, statement := range doc.Statement {
+		if !mandatory.sids[statement.Sid] {
+			remaining = append(remaining, statement)
+			continue
+		}
+		for _, expected := range statements {
+			if expected.Sid == statement.Sid && iampolicy.StatementsEqual(expected, statement) {
+				found++
+			}
+		}
+	}
+
+	if found != len(statements) {
+		spec, err := rm.specPolicy(r)
+		if err == nil && policy != nil && (spec == nil || !iampolicy.Equal(*spec, *policy)) {
+			return policy
+		}
+		drifted, err := (&iampolicy.Document{
+			Version:   defaultPolicyDocumentVersion,
+			Statement: []*iampolicy.Statement{},
+		}).Render()
+		if err != nil {
+			return policy
+		}
+		return &drifted
+	}
+
+	if len(remaining) == 0 {
+		return nil
+	}
+	doc.Statement = remaining
+	rendered, err := doc.Render()
+	if err != nil {
+		return policy
+	}
+	return &rendered
+}
+
+// putMandatoryPolicyStatements puts a policy holding only the mandatory
+// statements on a bucket that was just created, so that the bucket is never
+// left without them until the next reconciliation applies its whole policy.
+// A failure is only logged, as that reconciliation puts the policy again.
+func (rm *resourceManager) putMandatoryPolicyStatements(
+	ctx context.Context,
+	r *resource,
+) {
+	// The policy of buckets with only their declared properties managed is
+	// left to other tools when the spec does not declare one.
+	if isExplicitlyManaged(r) &&
+		!isFieldSet(r.ko, "Spec.Policy") && !isFieldSet(r.ko, "Spec.PolicyDocument") {
+		return
+	}
+	policy, err := rm.withMandatoryPolicyStatements(r, nil)
+	if err == nil && policy != nil {
+		err = rm.putPolicy(ctx, r, policy, false)
+	}
+	if err != nil {
+		ackrtlog.FromContext(ctx).Info(
+			"cannot put mandatory policy statements on created bucket", "error", err,
+		)
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
+)
+
+const denyInsecureTransportStatements = `[{
+	"Sid": "DenyInsecureTransport",
+	"Effect": "Deny",
+	"Principal": "*",
+	"Action": "s3:*",
+	"Resource": ["${bucket.arn}", "${bucket.arn}/*"],
+	"Condition": {"Bool": {"aws:SecureTransport": "false"}}
+}]`
+
+// denyInsecureTransportPolicy is the policy S3 returns for my-bucket when
+// only the mandatory statements are set.
+const denyInsecureTransportPolicy = `{"Version":"2012-10-17","Statement":[{"Sid":"DenyInsecureTransport","Effect":"Deny","Principal":"*","Action":"s3:*","Resource":["arn:aws:s3:::my-bucket","arn:aws:s3:::my-bucket/*"],"Condition":{"Bool":{"aws:SecureTransport":"false"}}}]}`
+
+// withMandatoryStatements configures the given mandatory policy
+// statements for the duration of the test.
+func withMandatoryStatements(t *testing.T, statements string) {
+	t.Helper()
+	mandatory, err := parseMandatoryPolicyStatements([]byte(statements))
+	require.NoError(t, err)
+	load := loadMandatoryPolicyStatements
+	loadMandatoryPolicyStatements = func() (*mandatoryPolicyStatements, error) {
+		return mandatory, nil
+	}
+	t.Cleanup(func() { loadMandatoryPolicyStatements = load })
+}
+
+func newMandatoryPolicyResourceManager() *resourceManager {
+	return &resourceManager{
+		awsAccountID: ackv1alpha1.AWSAccountID("111122223333"),
+		awsPartition: ackv1alpha1.AWSPartition("aws"),
+	}
+}
+
+// Test_desiredPolicy_MandatoryStatements verifies that the mandatory
+// statements are merged into the policy of the spec, replacing the statements
+// with the same Sid.
+func Test_desiredPolicy_MandatoryStatements(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	withMandatoryStatements(t, denyInsecureTransportStatements)
+	rm := newMandatoryPolicyResourceManager()
+
+	// No policy in the spec
+	policy, err := rm.desiredPolicy(newBucketResource("my-bucket"))
+	require.NoError(err)
+	require.NotNil(policy)
+	assert.True(iampolicy.Equal(denyInsecureTransportPolicy, *policy), *policy)
+
+	// Merged with the policy of the spec
+	r := newBucketResource("my-bucket")
+	r.ko.Spec.Policy = aws.String(publicReadPolicy)
+	policy, err = rm.desiredPolicy(r)
+	require.NoError(err)
+	require.NotNil(policy)
+	doc, err := iampolicy.Parse(*policy)
+	require.NoError(err)
+	require.Len(doc.Statement, 2)
+	assert.Equal("", doc.Statement[0].Sid)
+	assert.Equal("DenyInsecureTransport", doc.Statement[1].Sid)
+
+	// A statement of the spec with the same Sid is replaced
+	r.ko.Spec.Policy = aws.String(`{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Sid": "DenyInsecureTransport",
+			"Effect": "Allow",
+			"Principal": "*",
+			"Action": "s3:*",
+			"Resource": "arn:aws:s3:::my-bucket/*"
+		}]
+	}`)
+	policy, err = rm.desiredPolicy(r)
+	require.NoError(err)
+	assert.True(iampolicy.Equal(denyInsecureTransportPolicy, *policy), *policy)
+
+	// An invalid policy cannot be merged
+	r.ko.Spec.Policy = aws.String(`{"Statement": "nope"}`)
+	_, err = rm.desiredPolicy(r)
+	assert.Error(err)
+
+	// Directory buckets are left alone
+	policy, err = rm.desiredPolicy(newBucketResource("my-bucket--usw2-az1--x-s3"))
+	require.NoError(err)
+	assert.Nil(policy)
+}
+
+// Test_setResourcePolicy_MandatoryStatements verifies that the mandatory
+// statements are left out of the policy read from S3, unless they were
+// removed or altered, in which case the policy differs from the spec.
+func Test_setResourcePolicy_MandatoryStatements(t *testing.T) {
+	assert := assert.New(t)
+	withMandatoryStatements(t, denyInsecureTransportStatements)
+	rm := newMandatoryPolicyResourceManager()
+
+	desired := newBucketResource("my-bucket")
+	merged, err := rm.desiredPolicy(desired)
+	require.NoError(t, err)
+
+	// Only the mandatory statements are set
+	ko := desired.ko.DeepCopy()
+	rm.setResourcePolicy(desired, ko, merged)
+	assert.Nil(ko.Spec.Policy)
+	assert.False(newResourceDelta(desired, &resource{ko}).DifferentAt("Spec.Policy"))
+
+	// The mandatory statements were removed
+	ko = desired.ko.DeepCopy()
+	rm.setResourcePolicy(desired, ko, nil)
+	assert.True(newResourceDelta(desired, &resource{ko}).DifferentAt("Spec.Policy"))
+
+	desired.ko.Spec.Policy = aws.String(publicReadPolicy)
+	merged, err = rm.desiredPolicy(desired)
+	require.NoError(t, err)
+
+	// The policy of the spec is set along with the mandatory statements
+	ko = desired.ko.DeepCopy()
+	rm.setResourcePolicy(desired, ko, merged)
+	assert.True(iampolicy.Equal(publicReadPolicy, *ko.Spec.Policy), *ko.Spec.Policy)
+	assert.False(newResourceDelta(desired, &resource{ko}).DifferentAt("Spec.Policy"))
+
+	// The mandatory statements were removed, leaving the policy of the spec
+	ko = desired.ko.DeepCopy()
+	rm.setResourcePolicy(desired, ko, aws.String(publicReadPolicy))
+	assert.True(newResourceDelta(desired, &resource{ko}).DifferentAt("Spec.Policy"))
+
+	// A mandatory statement was altered
+	ko = desired.ko.DeepCopy()
+	rm.setResourcePolicy(desired, ko, aws.String(`{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Effect": "Allow",
+			"Principal": "*",
+			"Action": "s3:GetObject",
+			"Resource": "arn:aws:s3:::my-bucket/*"
+		}, {
+			"Sid": "DenyInsecureTransport",
+			"Effect": "Deny",
+			"Principal": "*",
+			"Action": "s3:*",
+			"Resource": "arn:aws:s3:::my-bucket/*",
+			"Condition": {"Bool": {"aws:SecureTransport": "false"}}
+		}]
+	}`))
+	assert.True(newResourceDelta(desired, &resource{ko}).DifferentAt("Spec.Policy"))
+}
+
+// Test_sdkCreate_MandatoryStatements verifies that the mandatory statements
+// are put on a bucket as soon as it is created, unless its policy is left to
+// other tools.
+func Test_sdkCreate_MandatoryStatements(t *testing.T) {
+	withMandatoryStatements(t, denyInsecureTransportStatements)
+	withBucketClasses(t)
+
+	tests := []struct {
+		name          string
+		managedFields *string
+		policy        *string
+		wantPolicy    *string
+	}{
+		{
+			name:       "bucket without policy",
+			wantPolicy: aws.String(denyInsecureTransportPolicy),
+		},
+		{
+			name:       "policy of the spec is left to the next reconciliation",
+			policy:     aws.String(accountPolicy),
+			wantPolicy: aws.String(denyInsecureTransportPolicy),
+		},
+		{
+			name:          "undeclared policy when explicit",
+			managedFields: aws.String(managedFieldsExplicit),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var inputs []*svcsdk.PutBucketPolicyInput
+			rm := newDestinationGrantsResourceManager(map[string]opResult{
+				"CreateBucket": {output: &svcsdk.CreateBucketOutput{}},
+			}, &inputs)
+			desired := newBucketResource("my-bucket")
+			desired.ko.Spec.ManagedFields = tt.managedFields
+			desired.ko.Spec.Policy = tt.policy
+
+			_, err := rm.sdkCreate(context.Background(), desired)
+			assert.Error(err, "requeue for updates")
+			if tt.wantPolicy == nil {
+				assert.Empty(inputs)
+				return
+			}
+			if assert.Len(inputs, 1) {
+				assert.True(iampolicy.Equal(*tt.wantPolicy, aws.ToString(inputs[0].Policy)))
+			}
+		})
+	}
+}
+
+// Test_readMandatoryPolicyStatements verifies that the statements are read
+// from the configured file, and that each must have a distinct Sid.
+func Test_readMandatoryPolicyStatements(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "statements.json")
+	require.NoError(os.WriteFile(path, []byte(denyInsecureTransportStatements), 0o600))
+	mandatory, err := readMandatoryPolicyStatements(path)
+	require.NoError(err)
+	require.Len(mandatory.statements, 1)
+	assert.True(mandatory.sids["DenyInsecureTransport"])
+
+	mandatory, err = readMandatoryPolicyStatements("")
+	require.NoError(err)
+	assert.Nil(mandatory)
+
+	_, err = readMandatoryPolicyStatements(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(err)
+
+	for name, statements := range map[string]string{
+		"not an array":   `{"Sid": "A", "Effect": "Deny"}`,
+		"missing Sid":    `[{"Effect": "Deny", "Action": "s3:*"}]`,
+		"duplicate Sid":  `[{"Sid": "A", "Effect": "Deny"}, {"Sid": "A", "Effect": "Allow"}]`,
+		"unknown fields": `[{"Sid": "A", "Effect": "Deny", "Actions": "s3:*"}]`,
+	} {
+		_, err := parseMandatoryPolicyStatements([]byte(statements))
+		assert.Error(err, name)
+	}
+}
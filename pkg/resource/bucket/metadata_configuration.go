@@ -0,0 +1,310 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"reflect"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// S3 Metadata records the objects of a bucket in a journal table and,
+// optionally, an inventory table, both created by S3 in an AWS managed table
+// bucket. The destination and the status of the tables are reported in
+// Status.MetadataConfigurationResult.
+//
+// The encryption settings of the tables are not returned by
+// GetBucketMetadataConfiguration, so they are only applied when the
+// configuration, or the inventory table, is created. The encryption of the
+// journal table cannot be changed afterwards. Nor can the table bucket be
+// chosen, so the configuration has no reference to one: S3 reports the ARN of
+// the table bucket it uses in Status.MetadataConfigurationResult.
+
+// metadataConfigurationNotFoundCodes are the error codes returned by
+// GetBucketMetadataConfiguration when the bucket has no S3 Metadata
+// configuration.
+var metadataConfigurationNotFoundCodes = map[string]bool{
+	"MetadataConfigurationNotFound":      true,
+	"MetadataTableConfigurationNotFound": true,
+}
+
+func (rm *resourceManager) newGetBucketMetadataConfigurationPayload(
+	r *resource,
+) *svcsdk.GetBucketMetadataConfigurationInput {
+	res := &svcsdk.GetBucketMetadataConfigurationInput{}
+	res.Bucket = r.ko.Spec.Name
+	return res
+}
+
+// getMetadataConfiguration returns the S3 Metadata configuration of the
+// bucket, or nil if it has none.
+func (rm *resourceManager) getMetadataConfiguration(
+	ctx context.Context,
+	r *resource,
+) (*svcsdktypes.MetadataConfigurationResult, error) {
+	resp, err := rm.sdkapi.GetBucketMetadataConfiguration(ctx, rm.newGetBucketMetadataConfigurationPayload(r))
+	if err != nil {
+		if awsErr, ok := ackerr.AWSError(err); ok && metadataConfigurationNotFoundCodes[awsErr.ErrorCode()] {
+			return nil, nil
+		}
+		// S3 Metadata is not supported in every region, ignore any errors if
+		// we attempt to describe it in a region in which it's not supported.
+		if awsErr, ok := ackerr.AWSError(err); !ok || (awsErr.ErrorCode() != "MethodNotAllowed" && awsErr.ErrorCode() != "UnsupportedArgument") {
+			return nil, err
+		}
+		return nil, nil
+	}
+	if resp.GetBucketMetadataConfigurationResult == nil {
+		return nil, nil
+	}
+	return resp.GetBucketMetadataConfigurationResult.MetadataConfigurationResult, nil
+}
+
+// setResourceMetadataConfiguration returns the `MetadataConfiguration` spec
+// field given the S3 Metadata configuration of the bucket. The encryption
+// settings, which S3 does not return, are taken from the supplied resource.
+func (rm *resourceManager) setResourceMetadataConfiguration(
+	r *resource,
+	result *svcsdktypes.MetadataConfigurationResult,
+) *svcapitypes.MetadataConfiguration {
+	res := &svcapitypes.MetadataConfiguration{}
+	desired := r.ko.Spec.MetadataConfiguration
+	if desired == nil {
+		desired = &svcapitypes.MetadataConfiguration{}
+	}
+
+	if journal := result.JournalTableConfigurationResult; journal != nil {
+		res.JournalTableConfiguration = &svcapitypes.JournalTableConfiguration{
+			RecordExpiration: newResourceRecordExpiration(journal.RecordExpiration),
+		}
+		if desired.JournalTableConfiguration != nil {
+			res.JournalTableConfiguration.EncryptionConfiguration = desired.JournalTableConfiguration.EncryptionConfiguration.DeepCopy()
+		}
+	}
+
+	// A disabled inventory table is equivalent to none.
+	if inventory := result.InventoryTableConfigurationResult; inventory != nil &&
+		(inventory.ConfigurationState != svcsdktypes.InventoryConfigurationStateDisabled ||
+			desired.InventoryTableConfiguration != nil) {
+		res.InventoryTableConfiguration = &svcapitypes.InventoryTableConfiguration{
+			ConfigurationState: aws.String(string(inventory.ConfigurationState)),
+		}
+		if desired.InventoryTableConfiguration != nil {
+			res.InventoryTableConfiguration.EncryptionConfiguration = desired.InventoryTableConfiguration.EncryptionConfiguration.DeepCopy()
+		}
+	}
+	return res
+}
+
+func newResourceRecordExpiration(
+	in *svcsdktypes.RecordExpiration,
+) *svcapitypes.RecordExpiration {
+	if in == nil {
+		return nil
+	}
+	res := &svcapitypes.RecordExpiration{}
+	if in.Expiration != "" {
+		res.Expiration = aws.String(string(in.Expiration))
+	}
+	if in.Days != nil {
+		res.Days = aws.Int64(int64(*in.Days))
+	}
+	return res
+}
+
+// newMetadataConfigurationResult returns the
+// `Status.MetadataConfigurationResult` field given the S3 Metadata
+// configuration of the bucket.
+func newMetadataConfigurationResult(
+	result *svcsdktypes.MetadataConfigurationResult,
+) *svcapitypes.MetadataConfigurationResult {
+	res := &svcapitypes.MetadataConfigurationResult{}
+	if destination := result.DestinationResult; destination != nil {
+		res.DestinationResult = &svcapitypes.DestinationResult{
+			TableBucketARN: destination.TableBucketArn,
+			TableNamespace: destination.TableNamespace,
+		}
+		if destination.TableBucketType != "" {
+			res.DestinationResult.TableBucketType = aws.String(string(destination.TableBucketType))
+		}
+	}
+	if journal := result.JournalTableConfigurationResult; journal != nil {
+		res.JournalTableConfigurationResult = &svcapitypes.JournalTableConfigurationResult{
+			Error:            newResourceErrorDetails(journal.Error),
+			RecordExpiration: newResourceRecordExpiration(journal.RecordExpiration),
+			TableARN:         journal.TableArn,
+			TableName:        journal.TableName,
+			TableStatus:      journal.TableStatus,
+		}
+	}
+	if inventory := result.InventoryTableConfigurationResult; inventory != nil {
+		res.InventoryTableConfigurationResult = &svcapitypes.InventoryTableConfigurationResult{
+			Error:       newResourceErrorDetails(inventory.Error),
+			TableARN:    inventory.TableArn,
+			TableName:   inventory.TableName,
+			TableStatus: inventory.TableStatus,
+		}
+		if inventory.ConfigurationState != "" {
+			res.InventoryTableConfigurationResult.ConfigurationState = aws.String(string(inventory.ConfigurationState))
+		}
+	}
+	return res
+}
+
+func newResourceErrorDetails(in *svcsdktypes.ErrorDetails) *svcapitypes.ErrorDetails {
+	if in == nil {
+		return nil
+	}
+	return &svcapitypes.ErrorDetails{
+		ErrorCode:    in.ErrorCode,
+		ErrorMessage: in.ErrorMessage,
+	}
+}
+
+func newMetadataTableEncryptionConfiguration(
+	in *svcapitypes.MetadataTableEncryptionConfiguration,
+) *svcsdktypes.MetadataTableEncryptionConfiguration {
+	if in == nil {
+		return nil
+	}
+	res := &svcsdktypes.MetadataTableEncryptionConfiguration{
+		KmsKeyArn: in.KMSKeyARN,
+	}
+	if in.SSEAlgorithm != nil {
+		res.SseAlgorithm = svcsdktypes.TableSseAlgorithm(*in.SSEAlgorithm)
+	}
+	return res
+}
+
+func newRecordExpiration(in *svcapitypes.RecordExpiration) *svcsdktypes.RecordExpiration {
+	if in == nil {
+		return nil
+	}
+	res := &svcsdktypes.RecordExpiration{}
+	if in.Expiration != nil {
+		res.Expiration = svcsdktypes.ExpirationState(*in.Expiration)
+	}
+	if in.Days != nil {
+		res.Days = aws.Int32(int32(*in.Days))
+	}
+	return res
+}
+
+func (rm *resourceManager) newCreateBucketMetadataConfigurationPayload(
+	r *resource,
+) *svcsdk.CreateBucketMetadataConfigurationInput {
+	res := &svcsdk.CreateBucketMetadataConfigurationInput{}
+	res.Bucket = r.ko.Spec.Name
+	res.MetadataConfiguration = &svcsdktypes.MetadataConfiguration{}
+
+	config := r.ko.Spec.MetadataConfiguration
+	if journal := config.JournalTableConfiguration; journal != nil {
+		res.MetadataConfiguration.JournalTableConfiguration = &svcsdktypes.JournalTableConfiguration{
+			EncryptionConfiguration: newMetadataTableEncryptionConfiguration(journal.EncryptionConfiguration),
+			RecordExpiration:        newRecordExpiration(journal.RecordExpiration),
+		}
+	}
+	if inventory := config.InventoryTableConfiguration; inventory != nil {
+		res.MetadataConfiguration.InventoryTableConfiguration = &svcsdktypes.InventoryTableConfiguration{
+			EncryptionConfiguration: newMetadataTableEncryptionConfiguration(inventory.EncryptionConfiguration),
+		}
+		if inventory.ConfigurationState != nil {
+			res.MetadataConfiguration.InventoryTableConfiguration.ConfigurationState = svcsdktypes.InventoryConfigurationState(*inventory.ConfigurationState)
+		}
+	}
+	return res
+}
+
+// syncMetadataConfiguration creates, updates or deletes the S3 Metadata
+// configuration of the bucket. Deleting the configuration stops recording
+// the objects of the bucket but leaves the tables in place.
+func (rm *resourceManager) syncMetadataConfiguration(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.syncMetadataConfiguration")
+	defer exit(err)
+
+	desiredConfig := desired.ko.Spec.MetadataConfiguration
+	latestConfig := latest.ko.Spec.MetadataConfiguration
+
+	if desiredConfig == nil {
+		if latestConfig == nil {
+			return nil
+		}
+		_, err = rm.sdkapi.DeleteBucketMetadataConfiguration(ctx, &svcsdk.DeleteBucketMetadataConfigurationInput{
+			Bucket: desired.ko.Spec.Name,
+		})
+		rm.metrics.RecordAPICall("UPDATE", "DeleteBucketMetadataConfiguration", err)
+		return err
+	}
+
+	if latestConfig == nil {
+		_, err = rm.sdkapi.CreateBucketMetadataConfiguration(ctx, rm.newCreateBucketMetadataConfigurationPayload(desired))
+		rm.metrics.RecordAPICall("UPDATE", "CreateBucketMetadataConfiguration", err)
+		return err
+	}
+
+	var desiredExpiration, latestExpiration *svcapitypes.RecordExpiration
+	if desiredConfig.JournalTableConfiguration != nil {
+		desiredExpiration = desiredConfig.JournalTableConfiguration.RecordExpiration
+	}
+	if latestConfig.JournalTableConfiguration != nil {
+		latestExpiration = latestConfig.JournalTableConfiguration.RecordExpiration
+	}
+	if !reflect.DeepEqual(desiredExpiration, latestExpiration) {
+		_, err = rm.sdkapi.UpdateBucketMetadataJournalTableConfiguration(ctx, &svcsdk.UpdateBucketMetadataJournalTableConfigurationInput{
+			Bucket: desired.ko.Spec.Name,
+			JournalTableConfiguration: &svcsdktypes.JournalTableConfigurationUpdates{
+				RecordExpiration: newRecordExpiration(desiredExpiration),
+			},
+		})
+		rm.metrics.RecordAPICall("UPDATE", "UpdateBucketMetadataJournalTableConfiguration", err)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !reflect.DeepEqual(desiredConfig.InventoryTableConfiguration, latestConfig.InventoryTableConfiguration) {
+		updates := &svcsdktypes.InventoryTableConfigurationUpdates{
+			ConfigurationState: svcsdktypes.InventoryConfigurationStateDisabled,
+		}
+		if inventory := desiredConfig.InventoryTableConfiguration; inventory != nil {
+			if inventory.ConfigurationState != nil {
+				updates.ConfigurationState = svcsdktypes.InventoryConfigurationState(*inventory.ConfigurationState)
+			}
+			if updates.ConfigurationState == svcsdktypes.InventoryConfigurationStateEnabled {
+				updates.EncryptionConfiguration = newMetadataTableEncryptionConfiguration(inventory.EncryptionConfiguration)
+			}
+		}
+		_, err = rm.sdkapi.UpdateBucketMetadataInventoryTableConfiguration(ctx, &svcsdk.UpdateBucketMetadataInventoryTableConfigurationInput{
+			Bucket:                      desired.ko.Spec.Name,
+			InventoryTableConfiguration: updates,
+		})
+		rm.metrics.RecordAPICall("UPDATE", "UpdateBucketMetadataInventoryTableConfiguration", err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
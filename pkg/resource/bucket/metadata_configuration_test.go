@@ -0,0 +1,199 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// shouldNotBeCalled fails every S3 Metadata write, for the tests to override
+// the ones they expect.
+var shouldNotBeCalled = map[string]opResult{
+	"CreateBucketMetadataConfiguration":               {err: apiErr("ShouldNotBeCalled")},
+	"DeleteBucketMetadataConfiguration":               {err: apiErr("ShouldNotBeCalled")},
+	"UpdateBucketMetadataJournalTableConfiguration":   {err: apiErr("ShouldNotBeCalled")},
+	"UpdateBucketMetadataInventoryTableConfiguration": {err: apiErr("ShouldNotBeCalled")},
+}
+
+func newMetadataConfigurationResourceManager(results map[string]opResult) *resourceManager {
+	merged := map[string]opResult{}
+	for op, result := range shouldNotBeCalled {
+		merged[op] = result
+	}
+	for op, result := range results {
+		merged[op] = result
+	}
+	return &resourceManager{
+		sdkapi:  newMockedSDKClient(merged),
+		metrics: ackmetrics.NewMetrics("s3"),
+	}
+}
+
+func newJournalOnlyMetadataConfiguration(expiration string) *svcapitypes.MetadataConfiguration {
+	return &svcapitypes.MetadataConfiguration{
+		JournalTableConfiguration: &svcapitypes.JournalTableConfiguration{
+			RecordExpiration: &svcapitypes.RecordExpiration{Expiration: aws.String(expiration)},
+		},
+	}
+}
+
+// Test_addPutFieldsToSpec_MetadataConfiguration verifies that the S3
+// Metadata configuration is read into the spec, with the encryption settings
+// S3 does not return taken from the desired state, and that the tables are
+// reported in the status.
+func Test_addPutFieldsToSpec_MetadataConfiguration(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rm := &resourceManager{
+		sdkapi: newMockedSDKClient(map[string]opResult{
+			"GetBucketMetadataConfiguration": {output: &svcsdk.GetBucketMetadataConfigurationOutput{
+				GetBucketMetadataConfigurationResult: &svcsdktypes.GetBucketMetadataConfigurationResult{
+					MetadataConfigurationResult: &svcsdktypes.MetadataConfigurationResult{
+						DestinationResult: &svcsdktypes.DestinationResult{
+							TableBucketArn:  aws.String("arn:aws:s3tables:us-west-2:111122223333:bucket/aws-s3"),
+							TableBucketType: svcsdktypes.S3TablesBucketTypeAws,
+							TableNamespace:  aws.String("b_my_bucket"),
+						},
+						JournalTableConfigurationResult: &svcsdktypes.JournalTableConfigurationResult{
+							RecordExpiration: &svcsdktypes.RecordExpiration{
+								Expiration: svcsdktypes.ExpirationStateEnabled,
+								Days:       aws.Int32(30),
+							},
+							TableName:   aws.String("journal"),
+							TableStatus: aws.String("ACTIVE"),
+						},
+						InventoryTableConfigurationResult: &svcsdktypes.InventoryTableConfigurationResult{
+							ConfigurationState: svcsdktypes.InventoryConfigurationStateDisabled,
+							TableStatus:        aws.String("FAILED"),
+							Error: &svcsdktypes.ErrorDetails{
+								ErrorCode:    aws.String("AccessDenied"),
+								ErrorMessage: aws.String("denied"),
+							},
+						},
+					},
+				},
+			}},
+		}),
+	}
+
+	desired := newBucketResource("my-bucket")
+	desired.ko.Spec.MetadataConfiguration = newJournalOnlyMetadataConfiguration("ENABLED")
+	desired.ko.Spec.MetadataConfiguration.JournalTableConfiguration.RecordExpiration.Days = aws.Int64(30)
+	desired.ko.Spec.MetadataConfiguration.JournalTableConfiguration.EncryptionConfiguration = &svcapitypes.MetadataTableEncryptionConfiguration{
+		SSEAlgorithm: aws.String("AES256"),
+	}
+
+	ko := desired.ko.DeepCopy()
+	require.NoError(rm.addPutFieldsToSpec(context.Background(), desired, ko))
+
+	// The disabled inventory table is left out
+	assert.Equal(desired.ko.Spec.MetadataConfiguration, ko.Spec.MetadataConfiguration)
+	assert.False(newResourceDelta(desired, &resource{ko}).DifferentAt("Spec.MetadataConfiguration"))
+
+	result := ko.Status.MetadataConfigurationResult
+	require.NotNil(result)
+	assert.Equal("aws", *result.DestinationResult.TableBucketType)
+	assert.Equal("b_my_bucket", *result.DestinationResult.TableNamespace)
+	assert.Equal("ACTIVE", *result.JournalTableConfigurationResult.TableStatus)
+	assert.Equal("FAILED", *result.InventoryTableConfigurationResult.TableStatus)
+	assert.Equal("AccessDenied", *result.InventoryTableConfigurationResult.Error.ErrorCode)
+
+	// Without a configuration, both the spec and status are cleared
+	rm.sdkapi = newMockedSDKClient(nil)
+	require.NoError(rm.addPutFieldsToSpec(context.Background(), desired, ko))
+	assert.Nil(ko.Spec.MetadataConfiguration)
+	assert.Nil(ko.Status.MetadataConfigurationResult)
+
+	// Nor is it an error in regions where S3 Metadata is not supported
+	for _, code := range []string{"MethodNotAllowed", "UnsupportedArgument"} {
+		rm.sdkapi = newMockedSDKClient(map[string]opResult{
+			"GetBucketMetadataConfiguration": {err: apiErr(code)},
+		})
+		require.NoError(rm.addPutFieldsToSpec(context.Background(), desired, ko), code)
+		assert.Nil(ko.Spec.MetadataConfiguration, code)
+	}
+}
+
+// Test_syncMetadataConfiguration verifies that the S3 Metadata configuration
+// is created, updated or deleted according to the desired and latest states.
+func Test_syncMetadataConfiguration(t *testing.T) {
+	for name, tt := range map[string]struct {
+		desired *svcapitypes.MetadataConfiguration
+		latest  *svcapitypes.MetadataConfiguration
+		results map[string]opResult
+	}{
+		"create": {
+			desired: newJournalOnlyMetadataConfiguration("DISABLED"),
+			results: map[string]opResult{
+				"CreateBucketMetadataConfiguration": {output: &svcsdk.CreateBucketMetadataConfigurationOutput{}},
+			},
+		},
+		"delete": {
+			latest: newJournalOnlyMetadataConfiguration("DISABLED"),
+			results: map[string]opResult{
+				"DeleteBucketMetadataConfiguration": {output: &svcsdk.DeleteBucketMetadataConfigurationOutput{}},
+			},
+		},
+		"update journal record expiration": {
+			desired: newJournalOnlyMetadataConfiguration("ENABLED"),
+			latest:  newJournalOnlyMetadataConfiguration("DISABLED"),
+			results: map[string]opResult{
+				"UpdateBucketMetadataJournalTableConfiguration": {output: &svcsdk.UpdateBucketMetadataJournalTableConfigurationOutput{}},
+			},
+		},
+		"enable inventory table": {
+			desired: &svcapitypes.MetadataConfiguration{
+				JournalTableConfiguration: newJournalOnlyMetadataConfiguration("DISABLED").JournalTableConfiguration,
+				InventoryTableConfiguration: &svcapitypes.InventoryTableConfiguration{
+					ConfigurationState: aws.String("ENABLED"),
+				},
+			},
+			latest: newJournalOnlyMetadataConfiguration("DISABLED"),
+			results: map[string]opResult{
+				"UpdateBucketMetadataInventoryTableConfiguration": {output: &svcsdk.UpdateBucketMetadataInventoryTableConfigurationOutput{}},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rm := newMetadataConfigurationResourceManager(tt.results)
+			desired := newBucketResource("my-bucket")
+			desired.ko.Spec.MetadataConfiguration = tt.desired
+			latest := newBucketResource("my-bucket")
+			latest.ko.Spec.MetadataConfiguration = tt.latest
+
+			assert.NoError(t, rm.syncMetadataConfiguration(context.Background(), desired, latest))
+		})
+	}
+}
+
+// Test_validateDirectoryBucketSpec_MetadataConfiguration verifies that S3
+// Metadata is rejected for directory buckets.
+func Test_validateDirectoryBucketSpec_MetadataConfiguration(t *testing.T) {
+	r := newBucketResource("my-bucket--usw2-az1--x-s3")
+	r.ko.Spec.MetadataConfiguration = newJournalOnlyMetadataConfiguration("DISABLED")
+	err := validateDirectoryBucketSpec(r.ko)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MetadataConfiguration")
+}
@@ -0,0 +1,45 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// copyNotificationReferences copies the reference fields of the desired
+// notification configurations, which S3 knows nothing about, to the latest
+// ones.
+func copyNotificationReferences(
+	desired *svcapitypes.NotificationConfiguration,
+	latest *svcapitypes.NotificationConfiguration,
+) {
+	if desired == nil || latest == nil {
+		return
+	}
+	for i, config := range latest.LambdaFunctionConfigurations {
+		if i < len(desired.LambdaFunctionConfigurations) && desired.LambdaFunctionConfigurations[i] != nil {
+			config.LambdaFunctionRef = desired.LambdaFunctionConfigurations[i].LambdaFunctionRef
+		}
+	}
+	for i, config := range latest.QueueConfigurations {
+		if i < len(desired.QueueConfigurations) && desired.QueueConfigurations[i] != nil {
+			config.QueueRef = desired.QueueConfigurations[i].QueueRef
+		}
+	}
+	for i, config := range latest.TopicConfigurations {
+		if i < len(desired.TopicConfigurations) && desired.TopicConfigurations[i] != nil {
+			config.TopicRef = desired.TopicConfigurations[i].TopicRef
+		}
+	}
+}
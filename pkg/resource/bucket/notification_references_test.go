@@ -0,0 +1,165 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	kmsapitypes "github.com/aws-controllers-k8s/kms-controller/apis/v1alpha1"
+	lambdaapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	snsapitypes "github.com/aws-controllers-k8s/sns-controller/apis/v1alpha1"
+	sqsapitypes "github.com/aws-controllers-k8s/sqs-controller/apis/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// newReferencedResourceMetadata returns the metadata of a resource of another
+// ACK controller with the given ARN, or nil if it has none yet.
+func newReferencedResourceMetadata(arn string) *ackv1alpha1.ResourceMetadata {
+	if arn == "" {
+		return nil
+	}
+	resourceARN := ackv1alpha1.AWSResourceName(arn)
+	return &ackv1alpha1.ResourceMetadata{ARN: &resourceARN}
+}
+
+// newReferencedResourceConditions returns the conditions of a resource of
+// another ACK controller with the given ResourceSynced status.
+func newReferencedResourceConditions(synced corev1.ConditionStatus) []*ackv1alpha1.Condition {
+	return []*ackv1alpha1.Condition{{
+		Type:   ackv1alpha1.ConditionTypeResourceSynced,
+		Status: synced,
+	}}
+}
+
+func newReferencedResourceReader(t *testing.T, objs ...client.Object) client.Reader {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kmsapitypes.AddToScheme(scheme))
+	require.NoError(t, lambdaapitypes.AddToScheme(scheme))
+	require.NoError(t, snsapitypes.AddToScheme(scheme))
+	require.NoError(t, sqsapitypes.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newReference(name string) *ackv1alpha1.AWSResourceReferenceWrapper {
+	return &ackv1alpha1.AWSResourceReferenceWrapper{
+		From: &ackv1alpha1.AWSResourceReference{Name: aws.String(name)},
+	}
+}
+
+// Test_ResolveReferences_Notification verifies that the notification targets
+// are resolved to the ARNs of the referenced Lambda, SQS and SNS resources.
+func Test_ResolveReferences_Notification(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	const (
+		functionARN = "arn:aws:lambda:us-west-2:111122223333:function:on-upload"
+		queueARN    = "arn:aws:sqs:us-west-2:111122223333:uploads"
+		topicARN    = "arn:aws:sns:us-west-2:111122223333:uploads"
+	)
+	apiReader := newReferencedResourceReader(t,
+		&lambdaapitypes.Function{
+			ObjectMeta: metav1.ObjectMeta{Name: "on-upload", Namespace: "default"},
+			Status: lambdaapitypes.FunctionStatus{
+				ACKResourceMetadata: newReferencedResourceMetadata(functionARN),
+				Conditions:          newReferencedResourceConditions(corev1.ConditionTrue),
+			},
+		},
+		&sqsapitypes.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: "uploads", Namespace: "default"},
+			Status: sqsapitypes.QueueStatus{
+				ACKResourceMetadata: newReferencedResourceMetadata(queueARN),
+				Conditions:          newReferencedResourceConditions(corev1.ConditionTrue),
+			},
+		},
+		&snsapitypes.Topic{
+			ObjectMeta: metav1.ObjectMeta{Name: "uploads", Namespace: "default"},
+			Status: snsapitypes.TopicStatus{
+				ACKResourceMetadata: newReferencedResourceMetadata(topicARN),
+				Conditions:          newReferencedResourceConditions(corev1.ConditionTrue),
+			},
+		},
+		&snsapitypes.Topic{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+			Status: snsapitypes.TopicStatus{
+				Conditions: newReferencedResourceConditions(corev1.ConditionFalse),
+			},
+		},
+	)
+
+	rm := &resourceManager{}
+	desired := newBucketResource("my-bucket")
+	desired.ko.Namespace = "default"
+	desired.ko.Spec.Notification = &svcapitypes.NotificationConfiguration{
+		LambdaFunctionConfigurations: []*svcapitypes.LambdaFunctionConfiguration{
+			{LambdaFunctionRef: newReference("on-upload")},
+		},
+		QueueConfigurations: []*svcapitypes.QueueConfiguration{
+			{QueueRef: newReference("uploads")},
+		},
+		TopicConfigurations: []*svcapitypes.TopicConfiguration{
+			{TopicRef: newReference("uploads")},
+		},
+	}
+
+	resolved, hasReferences, err := rm.ResolveReferences(context.Background(), apiReader, desired.DeepCopy())
+	require.NoError(err)
+	assert.True(hasReferences)
+	notification := rm.concreteResource(resolved).ko.Spec.Notification
+	assert.Equal(functionARN, *notification.LambdaFunctionConfigurations[0].LambdaFunctionARN)
+	assert.Equal(queueARN, *notification.QueueConfigurations[0].QueueARN)
+	assert.Equal(topicARN, *notification.TopicConfigurations[0].TopicARN)
+
+	// The resolved ARNs are cleared before the spec is written back
+	cleared := rm.concreteResource(rm.ClearResolvedReferences(resolved)).ko.Spec.Notification
+	assert.Nil(cleared.QueueConfigurations[0].QueueARN)
+	assert.NotNil(cleared.QueueConfigurations[0].QueueRef)
+
+	// The references are not reported as a difference with the latest state
+	latest := newBucketResource("my-bucket")
+	latest.ko.Spec.Notification = &svcapitypes.NotificationConfiguration{
+		LambdaFunctionConfigurations: []*svcapitypes.LambdaFunctionConfiguration{
+			{LambdaFunctionARN: aws.String(functionARN)},
+		},
+		QueueConfigurations: []*svcapitypes.QueueConfiguration{
+			{QueueARN: aws.String(queueARN)},
+		},
+		TopicConfigurations: []*svcapitypes.TopicConfiguration{
+			{TopicARN: aws.String(topicARN)},
+		},
+	}
+	assert.False(newResourceDelta(rm.concreteResource(resolved), latest).DifferentAt("Spec.Notification"))
+
+	// A target which is not synced yet is waited for
+	desired.ko.Spec.Notification.TopicConfigurations[0].TopicRef = newReference("pending")
+	_, _, err = rm.ResolveReferences(context.Background(), apiReader, desired.DeepCopy())
+	assert.ErrorContains(err, "pending")
+
+	// The ARN and the reference cannot both be set
+	desired.ko.Spec.Notification.TopicConfigurations[0].TopicRef = newReference("uploads")
+	desired.ko.Spec.Notification.TopicConfigurations[0].TopicARN = aws.String(topicARN)
+	_, _, err = rm.ResolveReferences(context.Background(), apiReader, desired.DeepCopy())
+	assert.ErrorContains(err, "TopicRef")
+}
@@ -0,0 +1,283 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
+)
+
+// defaultPolicyDocumentVersion is the policy language version of the policies
+// rendered from Spec.PolicyDocument when it does not specify one. Unlike the
+// IAM default it supports policy variables.
+const defaultPolicyDocumentVersion = "2012-10-17"
+
+// Placeholders resolved in the string values of Spec.PolicyDocument.
+const (
+	policyPlaceholderBucketName = "${bucket.name}"
+	policyPlaceholderBucketARN  = "${bucket.arn}"
+	policyPlaceholderAccountID  = "${account.id}"
+	policyPlaceholderPartition  = "${partition}"
+)
+
+// newPolicyDocument returns the policy described by a Spec.PolicyDocument,
+// with its placeholders resolved by placeholders, if not nil.
+func newPolicyDocument(
+	in *svcapitypes.BucketPolicyDocument,
+	placeholders *strings.Replacer,
+) *iampolicy.Document {
+	resolve := func(values []*string) []string {
+		if values == nil {
+			return nil
+		}
+		resolved := make([]string, 0, len(values))
+		for _, value := range values {
+			if value == nil {
+				continue
+			}
+			if placeholders != nil {
+				resolved = append(resolved, placeholders.Replace(*value))
+			} else {
+				resolved = append(resolved, *value)
+			}
+		}
+		return resolved
+	}
+	principal := func(in *svcapitypes.BucketPolicyPrincipal) map[string][]string {
+		if in == nil {
+			return nil
+		}
+		out := map[string][]string{}
+		for principalType, values := range map[string][]*string{
+			"AWS":           in.AWS,
+			"CanonicalUser": in.CanonicalUser,
+			"Federated":     in.Federated,
+			"Service":       in.Service,
+		} {
+			if len(values) > 0 {
+				out[principalType] = resolve(values)
+			}
+		}
+		return out
+	}
+
+	doc := &iampolicy.Document{
+		Version: aws.ToString(in.Version),
+		ID:      aws.ToString(in.ID),
+	}
+	if doc.Version == "" {
+		doc.Version = defaultPolicyDocumentVersion
+	}
+	for _, s := range in.Statements {
+		if s == nil {
+			continue
+		}
+		statement := &iampolicy.Statement{
+			Sid:          aws.ToString(s.Sid),
+			Effect:       aws.ToString(s.Effect),
+			Principal:    principal(s.Principal),
+			NotPrincipal: principal(s.NotPrincipal),
+			Action:       resolve(s.Actions),
+			NotAction:    resolve(s.NotActions),
+			Resource:     resolve(s.Resources),
+			NotResource:  resolve(s.NotResources),
+		}
+		for _, c := range s.Conditions {
+			if c == nil || c.Operator == nil || c.Key == nil {
+				continue
+			}
+			if statement.Condition == nil {
+				statement.Condition = map[string]map[string][]string{}
+			}
+			if statement.Condition[*c.Operator] == nil {
+				statement.Condition[*c.Operator] = map[string][]string{}
+			}
+			statement.Condition[*c.Operator][*c.Key] = resolve(c.Values)
+		}
+		doc.Statement = append(doc.Statement, statement)
+	}
+	return doc
+}
+
+// apiPolicyDocument returns the document d as a Spec.PolicyDocument.
+func apiPolicyDocument(d *iampolicy.Document) *svcapitypes.BucketPolicyDocument {
+	values := func(in []string) []*string {
+		if in == nil {
+			return nil
+		}
+		return aws.StringSlice(in)
+	}
+	principal := func(in map[string][]string) *svcapitypes.BucketPolicyPrincipal {
+		if in == nil {
+			return nil
+		}
+		return &svcapitypes.BucketPolicyPrincipal{
+			AWS:           values(in["AWS"]),
+			CanonicalUser: values(in["CanonicalUser"]),
+			Federated:     values(in["Federated"]),
+			Service:       values(in["Service"]),
+		}
+	}
+	optional := func(in string) *string {
+		if in == "" {
+			return nil
+		}
+		return aws.String(in)
+	}
+
+	out := &svcapitypes.BucketPolicyDocument{
+		Version:    optional(d.Version),
+		ID:         optional(d.ID),
+		Statements: []*svcapitypes.BucketPolicyStatement{},
+	}
+	for _, s := range d.Statement {
+		statement := &svcapitypes.BucketPolicyStatement{
+			Sid:          optional(s.Sid),
+			Effect:       aws.String(s.Effect),
+			Principal:    principal(s.Principal),
+			NotPrincipal: principal(s.NotPrincipal),
+			Actions:      values(s.Action),
+			NotActions:   values(s.NotAction),
+			Resources:    values(s.Resource),
+			NotResources: values(s.NotResource),
+		}
+		operators := make([]string, 0, len(s.Condition))
+		for operator := range s.Condition {
+			operators = append(operators, operator)
+		}
+		sort.Strings(operators)
+		for _, operator := range operators {
+			keys := make([]string, 0, len(s.Condition[operator]))
+			for key := range s.Condition[operator] {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				statement.Conditions = append(statement.Conditions, &svcapitypes.BucketPolicyCondition{
+					Operator: aws.String(operator),
+					Key:      aws.String(key),
+					Values:   values(s.Condition[operator][key]),
+				})
+			}
+		}
+		out.Statements = append(out.Statements, statement)
+	}
+	return out
+}
+
+// policyPlaceholders returns the values of the placeholders that may be used
+// in the Spec.PolicyDocument of the given bucket.
+func (rm *resourceManager) policyPlaceholders(r *resource) *strings.Replacer {
+	bucketName := aws.ToString(r.ko.Spec.Name)
+	bucketARN := rm.bucketARN(bucketName)
+	if IsDirectoryBucketName(bucketName) {
+		bucketARN = rm.directoryBucketARN(bucketName)
+	}
+	return strings.NewReplacer(
+		policyPlaceholderBucketName, bucketName,
+		policyPlaceholderBucketARN, bucketARN,
+		policyPlaceholderAccountID, string(rm.awsAccountID),
+		policyPlaceholderPartition, rm.partition(),
+	)
+}
+
+// validatePolicySpec checks that the bucket policy is set through at most one
+// of Spec.Policy and Spec.PolicyDocument.
+func validatePolicySpec(ko *svcapitypes.Bucket) error {
+	if ko.Spec.Policy != nil && ko.Spec.PolicyDocument != nil {
+		return ackerr.NewTerminalError(fmt.Errorf(
+			"only one of spec.policy and spec.policyDocument may be set",
+		))
+	}
+	return nil
+}
+
+// specPolicy returns the JSON bucket policy set in the spec of the given
+// bucket, rendered from Spec.PolicyDocument if it is set, or nil if the spec
+// sets no policy.
+func (rm *resourceManager) specPolicy(r *resource) (*string, error) {
+	if r.ko.Spec.PolicyDocument != nil {
+		policy, err := newPolicyDocument(r.ko.Spec.PolicyDocument, rm.policyPlaceholders(r)).Render()
+		if err != nil {
+			return nil, err
+		}
+		return &policy, nil
+	}
+	if r.ko.Spec.Policy == nil || *r.ko.Spec.Policy == "" {
+		return nil, nil
+	}
+	return r.ko.Spec.Policy, nil
+}
+
+// desiredPolicy returns the JSON bucket policy to apply for the given bucket,
+// that is the policy of the spec with the mandatory policy statements merged
+// in, or nil if the bucket should have no policy.
+func (rm *resourceManager) desiredPolicy(r *resource) (*string, error) {
+	policy, err := rm.specPolicy(r)
+	if err != nil {
+		return nil, err
+	}
+	return rm.withMandatoryPolicyStatements(r, policy)
+}
+
+// setResourcePolicy sets the policy read from GetBucketPolicy in the spec of
+// ko, in the same form as the desired resource r. When r uses
+// Spec.PolicyDocument and the policy grants the same permissions, the desired
+// document is kept as is, placeholders included; otherwise the document is
+// reconstructed from the policy. The mandatory policy statements and the
+// destination grants are left out, as they are not part of the spec.
+func (rm *resourceManager) setResourcePolicy(
+	r *resource,
+	ko *svcapitypes.Bucket,
+	policy *string,
+) {
+	policy = rm.withoutMandatoryPolicyStatements(r, withoutDestinationGrants(policy))
+	if r.ko.Spec.PolicyDocument == nil || policy == nil {
+		ko.Spec.Policy = policy
+		ko.Spec.PolicyDocument = nil
+		return
+	}
+
+	ko.Spec.Policy = nil
+	desired, err := rm.specPolicy(r)
+	if err == nil && desired != nil && iampolicy.Equal(*desired, *policy) {
+		ko.Spec.PolicyDocument = r.ko.Spec.PolicyDocument.DeepCopy()
+		return
+	}
+	doc, err := iampolicy.Parse(*policy)
+	if err != nil {
+		// Keep the policy visible in the observed state, which also
+		// differs from the desired document.
+		ko.Spec.Policy = policy
+		ko.Spec.PolicyDocument = nil
+		return
+	}
+	ko.Spec.PolicyDocument = apiPolicyDocument(doc)
+}
+
+// desiredPolicyGrantsPublicAccess returns true if the policy of the desired
+// bucket has an unconditional Allow statement whose principal is everyone.
+func desiredPolicyGrantsPublicAccess(r *resource) bool {
+	if r.ko.Spec.PolicyDocument != nil {
+		return newPolicyDocument(r.ko.Spec.PolicyDocument, nil).GrantsPublicAccess()
+	}
+	return r.ko.Spec.Policy != nil && iampolicy.GrantsPublicAccess(*r.ko.Spec.Policy)
+}
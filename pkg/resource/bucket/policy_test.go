@@ -0,0 +1,196 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"errors"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
+)
+
+// Test_newResourceDelta_PolicyNormalizedByS3 verifies that a policy read back
+// from S3 in a different but equivalent form is not reported as a difference.
+func Test_newResourceDelta_PolicyNormalizedByS3(t *testing.T) {
+	assert := assert.New(t)
+
+	desired := newBucketResource("my-bucket")
+	desired.ko.Spec.Policy = strPtr(`{
+		"Statement": {
+			"Effect": "Allow",
+			"Principal": {"AWS": "111122223333"},
+			"Action": ["s3:GetObject", "s3:ListBucket"],
+			"Resource": ["arn:aws:s3:::my-bucket", "arn:aws:s3:::my-bucket/*"]
+		}
+	}`)
+	latest := newBucketResource("my-bucket")
+	latest.ko.Spec.Policy = strPtr(`{"Version":"2008-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111122223333:root"},"Action":["s3:ListBucket","s3:GetObject"],"Resource":["arn:aws:s3:::my-bucket/*","arn:aws:s3:::my-bucket"]}]}`)
+	assert.False(newResourceDelta(desired, latest).DifferentAt("Spec.Policy"))
+
+	latest.ko.Spec.Policy = strPtr(accountPolicy)
+	assert.True(newResourceDelta(desired, latest).DifferentAt("Spec.Policy"))
+}
+
+// newReadOnlyPolicyDocument returns a policy document granting read access to
+// the objects of the bucket to a role and, over TLS only, to everyone in the
+// account.
+func newReadOnlyPolicyDocument() *svcapitypes.BucketPolicyDocument {
+	return &svcapitypes.BucketPolicyDocument{
+		Statements: []*svcapitypes.BucketPolicyStatement{
+			{
+				Sid:    aws.String("ReadObjects"),
+				Effect: aws.String("Allow"),
+				Principal: &svcapitypes.BucketPolicyPrincipal{
+					AWS: aws.StringSlice([]string{"arn:${partition}:iam::${account.id}:role/reader"}),
+				},
+				Actions:   aws.StringSlice([]string{"s3:GetObject"}),
+				Resources: aws.StringSlice([]string{"${bucket.arn}/*"}),
+				Conditions: []*svcapitypes.BucketPolicyCondition{
+					{
+						Operator: aws.String("Bool"),
+						Key:      aws.String("aws:SecureTransport"),
+						Values:   aws.StringSlice([]string{"true"}),
+					},
+					{
+						Operator: aws.String("StringLike"),
+						Key:      aws.String("s3:prefix"),
+						Values:   aws.StringSlice([]string{"home/${aws:username}/*"}),
+					},
+				},
+			},
+		},
+	}
+}
+
+// Test_desiredPolicy_PolicyDocument verifies that a structured policy is
+// rendered to JSON with its placeholders resolved, and IAM policy variables
+// left untouched.
+func Test_desiredPolicy_PolicyDocument(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rm := &resourceManager{
+		awsAccountID: ackv1alpha1.AWSAccountID("111122223333"),
+		awsPartition: ackv1alpha1.AWSPartition("aws-cn"),
+	}
+	r := newBucketResource("my-bucket")
+	r.ko.Spec.PolicyDocument = newReadOnlyPolicyDocument()
+
+	policy, err := rm.desiredPolicy(r)
+	require.NoError(err)
+	require.NotNil(policy)
+	assert.True(iampolicy.Equal(*policy, `{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Sid": "ReadObjects",
+			"Effect": "Allow",
+			"Principal": {"AWS": "arn:aws-cn:iam::111122223333:role/reader"},
+			"Action": "s3:GetObject",
+			"Resource": "arn:aws-cn:s3:::my-bucket/*",
+			"Condition": {
+				"Bool": {"aws:SecureTransport": "true"},
+				"StringLike": {"s3:prefix": "home/${aws:username}/*"}
+			}
+		}]
+	}`), *policy)
+
+	// No policy at all
+	policy, err = rm.desiredPolicy(newBucketResource("my-bucket"))
+	require.NoError(err)
+	assert.Nil(policy)
+}
+
+// Test_setResourcePolicy verifies that the policy read from S3 is presented
+// in the same form as the desired resource: as the desired document itself
+// when equivalent, or reconstructed from the JSON policy otherwise.
+func Test_setResourcePolicy(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rm := &resourceManager{
+		awsAccountID: ackv1alpha1.AWSAccountID("111122223333"),
+		awsPartition: ackv1alpha1.AWSPartition("aws"),
+	}
+	desired := newBucketResource("my-bucket")
+	desired.ko.Spec.PolicyDocument = newReadOnlyPolicyDocument()
+
+	// S3 returns an equivalent policy in its own form
+	ko := desired.ko.DeepCopy()
+	rm.setResourcePolicy(desired, ko, aws.String(`{"Version":"2012-10-17","Statement":[{"Sid":"ReadObjects","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111122223333:role/reader"},"Action":"s3:GetObject","Resource":"arn:aws:s3:::my-bucket/*","Condition":{"StringLike":{"s3:prefix":"home/${aws:username}/*"},"Bool":{"aws:SecureTransport":"true"}}}]}`))
+	assert.Nil(ko.Spec.Policy)
+	assert.Equal(desired.ko.Spec.PolicyDocument, ko.Spec.PolicyDocument)
+	assert.False(newResourceDelta(desired, &resource{ko}).DifferentAt("Spec.PolicyDocument"))
+
+	// The policy was changed outside of the controller
+	ko = desired.ko.DeepCopy()
+	rm.setResourcePolicy(desired, ko, aws.String(publicReadPolicy))
+	assert.Nil(ko.Spec.Policy)
+	require.NotNil(ko.Spec.PolicyDocument)
+	require.Len(ko.Spec.PolicyDocument.Statements, 1)
+	statement := ko.Spec.PolicyDocument.Statements[0]
+	assert.Equal("2012-10-17", *ko.Spec.PolicyDocument.Version)
+	assert.Equal([]string{"*"}, aws.ToStringSlice(statement.Principal.AWS))
+	assert.Equal([]string{"arn:aws:s3:::my-bucket/*"}, aws.ToStringSlice(statement.Resources))
+	assert.True(newResourceDelta(desired, &resource{ko}).DifferentAt("Spec.PolicyDocument"))
+
+	// The policy was deleted outside of the controller
+	ko = desired.ko.DeepCopy()
+	rm.setResourcePolicy(desired, ko, nil)
+	assert.Nil(ko.Spec.PolicyDocument)
+	assert.Nil(ko.Spec.Policy)
+
+	// Resources using the string field are unaffected
+	desired = newBucketResource("my-bucket")
+	desired.ko.Spec.Policy = aws.String(accountPolicy)
+	ko = desired.ko.DeepCopy()
+	rm.setResourcePolicy(desired, ko, aws.String(publicReadPolicy))
+	assert.Equal(publicReadPolicy, *ko.Spec.Policy)
+	assert.Nil(ko.Spec.PolicyDocument)
+}
+
+// Test_policyDocument_RoundTrip verifies that a policy reconstructed from its
+// JSON form renders back to an equivalent policy.
+func Test_policyDocument_RoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	policy := `{"Version":"2012-10-17","Id":"p","Statement":[
+		{"Effect":"Deny","NotPrincipal":{"Service":"logging.s3.amazonaws.com"},"NotAction":["s3:GetObject"],"NotResource":"arn:aws:s3:::b/*"},
+		{"Effect":"Allow","Principal":{"AWS":["111122223333"],"CanonicalUser":"abc"},"Action":"s3:ListBucket","Resource":"arn:aws:s3:::b","Condition":{"NumericLessThan":{"s3:max-keys":10}}}]}`
+	doc, err := iampolicy.Parse(policy)
+	require.NoError(err)
+	rendered, err := newPolicyDocument(apiPolicyDocument(doc), nil).Render()
+	require.NoError(err)
+	assert.True(iampolicy.Equal(policy, rendered), rendered)
+}
+
+func Test_validatePolicySpec(t *testing.T) {
+	assert := assert.New(t)
+
+	r := newBucketResource("my-bucket")
+	assert.NoError(validatePolicySpec(r.ko))
+	r.ko.Spec.Policy = aws.String(accountPolicy)
+	assert.NoError(validatePolicySpec(r.ko))
+	r.ko.Spec.PolicyDocument = newReadOnlyPolicyDocument()
+	err := validatePolicySpec(r.ko)
+	var termErr *ackerr.TerminalError
+	assert.True(errors.As(err, &termErr))
+}
@@ -24,14 +24,28 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	iamapitypes "github.com/aws-controllers-k8s/iam-controller/apis/v1alpha1"
+	kmsapitypes "github.com/aws-controllers-k8s/kms-controller/apis/v1alpha1"
+	lambdaapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
 	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	snsapitypes "github.com/aws-controllers-k8s/sns-controller/apis/v1alpha1"
+	sqsapitypes "github.com/aws-controllers-k8s/sqs-controller/apis/v1alpha1"
 
 	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
 )
 
+// +kubebuilder:rbac:groups=s3.services.k8s.aws,resources=buckets,verbs=get;list
+// +kubebuilder:rbac:groups=s3.services.k8s.aws,resources=buckets/status,verbs=get;list
+// +kubebuilder:rbac:groups=kms.services.k8s.aws,resources=keys,verbs=get;list
+// +kubebuilder:rbac:groups=kms.services.k8s.aws,resources=keys/status,verbs=get;list
+// +kubebuilder:rbac:groups=lambda.services.k8s.aws,resources=functions,verbs=get;list
+// +kubebuilder:rbac:groups=lambda.services.k8s.aws,resources=functions/status,verbs=get;list
+// +kubebuilder:rbac:groups=sqs.services.k8s.aws,resources=queues,verbs=get;list
+// +kubebuilder:rbac:groups=sqs.services.k8s.aws,resources=queues/status,verbs=get;list
+// +kubebuilder:rbac:groups=sns.services.k8s.aws,resources=topics,verbs=get;list
+// +kubebuilder:rbac:groups=sns.services.k8s.aws,resources=topics/status,verbs=get;list
 // +kubebuilder:rbac:groups=iam.services.k8s.aws,resources=roles,verbs=get;list
 // +kubebuilder:rbac:groups=iam.services.k8s.aws,resources=roles/status,verbs=get;list
 
@@ -42,12 +56,100 @@ import (
 func (rm *resourceManager) ClearResolvedReferences(res acktypes.AWSResource) acktypes.AWSResource {
 	ko := rm.concreteResource(res).ko.DeepCopy()
 
+	for f0idx, f0iter := range ko.Spec.Analytics {
+		if f0iter.StorageClassAnalysis != nil {
+			if f0iter.StorageClassAnalysis.DataExport != nil {
+				if f0iter.StorageClassAnalysis.DataExport.Destination != nil {
+					if f0iter.StorageClassAnalysis.DataExport.Destination.S3BucketDestination != nil {
+						if f0iter.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.BucketRef != nil {
+							ko.Spec.Analytics[f0idx].StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket = nil
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if ko.Spec.Encryption != nil {
+		for f0idx, f0iter := range ko.Spec.Encryption.Rules {
+			if f0iter.ApplyServerSideEncryptionByDefault != nil {
+				if f0iter.ApplyServerSideEncryptionByDefault.KMSMasterKeyRef != nil {
+					ko.Spec.Encryption.Rules[f0idx].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = nil
+				}
+			}
+		}
+	}
+
+	for f0idx, f0iter := range ko.Spec.Inventory {
+		if f0iter.Destination != nil {
+			if f0iter.Destination.S3BucketDestination != nil {
+				if f0iter.Destination.S3BucketDestination.BucketRef != nil {
+					ko.Spec.Inventory[f0idx].Destination.S3BucketDestination.Bucket = nil
+				}
+			}
+		}
+	}
+
+	if ko.Spec.Logging != nil {
+		if ko.Spec.Logging.LoggingEnabled != nil {
+			if ko.Spec.Logging.LoggingEnabled.TargetBucketRef != nil {
+				ko.Spec.Logging.LoggingEnabled.TargetBucket = nil
+			}
+		}
+	}
+
+	if ko.Spec.Notification != nil {
+		for f0idx, f0iter := range ko.Spec.Notification.LambdaFunctionConfigurations {
+			if f0iter.LambdaFunctionRef != nil {
+				ko.Spec.Notification.LambdaFunctionConfigurations[f0idx].LambdaFunctionARN = nil
+			}
+		}
+	}
+
+	if ko.Spec.Notification != nil {
+		for f0idx, f0iter := range ko.Spec.Notification.QueueConfigurations {
+			if f0iter.QueueRef != nil {
+				ko.Spec.Notification.QueueConfigurations[f0idx].QueueARN = nil
+			}
+		}
+	}
+
+	if ko.Spec.Notification != nil {
+		for f0idx, f0iter := range ko.Spec.Notification.TopicConfigurations {
+			if f0iter.TopicRef != nil {
+				ko.Spec.Notification.TopicConfigurations[f0idx].TopicARN = nil
+			}
+		}
+	}
+
 	if ko.Spec.Replication != nil {
 		if ko.Spec.Replication.RoleRef != nil {
 			ko.Spec.Replication.Role = nil
 		}
 	}
 
+	if ko.Spec.Replication != nil {
+		for f0idx, f0iter := range ko.Spec.Replication.Rules {
+			if f0iter.Destination != nil {
+				if f0iter.Destination.BucketRef != nil {
+					ko.Spec.Replication.Rules[f0idx].Destination.Bucket = nil
+				}
+			}
+		}
+	}
+
+	if ko.Spec.Replication != nil {
+		for f0idx, f0iter := range ko.Spec.Replication.Rules {
+			if f0iter.Destination != nil {
+				if f0iter.Destination.EncryptionConfiguration != nil {
+					if f0iter.Destination.EncryptionConfiguration.ReplicaKMSKeyRef != nil {
+						ko.Spec.Replication.Rules[f0idx].Destination.EncryptionConfiguration.ReplicaKMSKeyID = nil
+					}
+				}
+			}
+		}
+	}
+
 	return &resource{ko}
 }
 
@@ -67,12 +169,66 @@ func (rm *resourceManager) ResolveReferences(
 
 	resourceHasReferences := false
 	err := validateReferenceFields(ko)
+	if fieldHasReferences, err := rm.resolveReferenceForAnalytics_StorageClassAnalysis_DataExport_Destination_S3BucketDestination_Bucket(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
+	if fieldHasReferences, err := rm.resolveReferenceForEncryption_Rules_ApplyServerSideEncryptionByDefault_KMSMasterKeyID(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
+	if fieldHasReferences, err := rm.resolveReferenceForInventory_Destination_S3BucketDestination_Bucket(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
+	if fieldHasReferences, err := rm.resolveReferenceForLogging_LoggingEnabled_TargetBucket(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
+	if fieldHasReferences, err := rm.resolveReferenceForNotification_LambdaFunctionConfigurations_LambdaFunctionARN(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
+	if fieldHasReferences, err := rm.resolveReferenceForNotification_QueueConfigurations_QueueARN(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
+	if fieldHasReferences, err := rm.resolveReferenceForNotification_TopicConfigurations_TopicARN(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
 	if fieldHasReferences, err := rm.resolveReferenceForReplication_Role(ctx, apiReader, ko); err != nil {
 		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
 	} else {
 		resourceHasReferences = resourceHasReferences || fieldHasReferences
 	}
 
+	if fieldHasReferences, err := rm.resolveReferenceForReplication_Rules_Destination_Bucket(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
+	if fieldHasReferences, err := rm.resolveReferenceForReplication_Rules_Destination_EncryptionConfiguration_ReplicaKMSKeyID(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
 	return &resource{ko}, resourceHasReferences, err
 }
 
@@ -80,11 +236,687 @@ func (rm *resourceManager) ResolveReferences(
 // identifier field.
 func validateReferenceFields(ko *svcapitypes.Bucket) error {
 
+	for _, f0iter := range ko.Spec.Analytics {
+		if f0iter.StorageClassAnalysis != nil {
+			if f0iter.StorageClassAnalysis.DataExport != nil {
+				if f0iter.StorageClassAnalysis.DataExport.Destination != nil {
+					if f0iter.StorageClassAnalysis.DataExport.Destination.S3BucketDestination != nil {
+						if f0iter.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.BucketRef != nil && f0iter.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket != nil {
+							return ackerr.ResourceReferenceAndIDNotSupportedFor("Analytics.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket", "Analytics.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.BucketRef")
+						}
+						if f0iter.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.BucketRef == nil && f0iter.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket == nil {
+							return ackerr.ResourceReferenceOrIDRequiredFor("Analytics.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket", "Analytics.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.BucketRef")
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if ko.Spec.Encryption != nil {
+		for _, f0iter := range ko.Spec.Encryption.Rules {
+			if f0iter.ApplyServerSideEncryptionByDefault != nil {
+				if f0iter.ApplyServerSideEncryptionByDefault.KMSMasterKeyRef != nil && f0iter.ApplyServerSideEncryptionByDefault.KMSMasterKeyID != nil {
+					return ackerr.ResourceReferenceAndIDNotSupportedFor("Encryption.Rules.ApplyServerSideEncryptionByDefault.KMSMasterKeyID", "Encryption.Rules.ApplyServerSideEncryptionByDefault.KMSMasterKeyRef")
+				}
+			}
+		}
+	}
+
+	for _, f0iter := range ko.Spec.Inventory {
+		if f0iter.Destination != nil {
+			if f0iter.Destination.S3BucketDestination != nil {
+				if f0iter.Destination.S3BucketDestination.BucketRef != nil && f0iter.Destination.S3BucketDestination.Bucket != nil {
+					return ackerr.ResourceReferenceAndIDNotSupportedFor("Inventory.Destination.S3BucketDestination.Bucket", "Inventory.Destination.S3BucketDestination.BucketRef")
+				}
+				if f0iter.Destination.S3BucketDestination.BucketRef == nil && f0iter.Destination.S3BucketDestination.Bucket == nil {
+					return ackerr.ResourceReferenceOrIDRequiredFor("Inventory.Destination.S3BucketDestination.Bucket", "Inventory.Destination.S3BucketDestination.BucketRef")
+				}
+			}
+		}
+	}
+
+	if ko.Spec.Logging != nil {
+		if ko.Spec.Logging.LoggingEnabled != nil {
+			if ko.Spec.Logging.LoggingEnabled.TargetBucketRef != nil && ko.Spec.Logging.LoggingEnabled.TargetBucket != nil {
+				return ackerr.ResourceReferenceAndIDNotSupportedFor("Logging.LoggingEnabled.TargetBucket", "Logging.LoggingEnabled.TargetBucketRef")
+			}
+			if ko.Spec.Logging.LoggingEnabled.TargetBucketRef == nil && ko.Spec.Logging.LoggingEnabled.TargetBucket == nil {
+				return ackerr.ResourceReferenceOrIDRequiredFor("Logging.LoggingEnabled.TargetBucket", "Logging.LoggingEnabled.TargetBucketRef")
+			}
+		}
+	}
+
+	if ko.Spec.Notification != nil {
+		for _, f0iter := range ko.Spec.Notification.LambdaFunctionConfigurations {
+			if f0iter.LambdaFunctionRef != nil && f0iter.LambdaFunctionARN != nil {
+				return ackerr.ResourceReferenceAndIDNotSupportedFor("Notification.LambdaFunctionConfigurations.LambdaFunctionARN", "Notification.LambdaFunctionConfigurations.LambdaFunctionRef")
+			}
+			if f0iter.LambdaFunctionRef == nil && f0iter.LambdaFunctionARN == nil {
+				return ackerr.ResourceReferenceOrIDRequiredFor("Notification.LambdaFunctionConfigurations.LambdaFunctionARN", "Notification.LambdaFunctionConfigurations.LambdaFunctionRef")
+			}
+		}
+	}
+
+	if ko.Spec.Notification != nil {
+		for _, f0iter := range ko.Spec.Notification.QueueConfigurations {
+			if f0iter.QueueRef != nil && f0iter.QueueARN != nil {
+				return ackerr.ResourceReferenceAndIDNotSupportedFor("Notification.QueueConfigurations.QueueARN", "Notification.QueueConfigurations.QueueRef")
+			}
+			if f0iter.QueueRef == nil && f0iter.QueueARN == nil {
+				return ackerr.ResourceReferenceOrIDRequiredFor("Notification.QueueConfigurations.QueueARN", "Notification.QueueConfigurations.QueueRef")
+			}
+		}
+	}
+
+	if ko.Spec.Notification != nil {
+		for _, f0iter := range ko.Spec.Notification.TopicConfigurations {
+			if f0iter.TopicRef != nil && f0iter.TopicARN != nil {
+				return ackerr.ResourceReferenceAndIDNotSupportedFor("Notification.TopicConfigurations.TopicARN", "Notification.TopicConfigurations.TopicRef")
+			}
+			if f0iter.TopicRef == nil && f0iter.TopicARN == nil {
+				return ackerr.ResourceReferenceOrIDRequiredFor("Notification.TopicConfigurations.TopicARN", "Notification.TopicConfigurations.TopicRef")
+			}
+		}
+	}
+
 	if ko.Spec.Replication != nil {
 		if ko.Spec.Replication.RoleRef != nil && ko.Spec.Replication.Role != nil {
 			return ackerr.ResourceReferenceAndIDNotSupportedFor("Replication.Role", "Replication.RoleRef")
 		}
 	}
+
+	if ko.Spec.Replication != nil {
+		for _, f0iter := range ko.Spec.Replication.Rules {
+			if f0iter.Destination != nil {
+				if f0iter.Destination.BucketRef != nil && f0iter.Destination.Bucket != nil {
+					return ackerr.ResourceReferenceAndIDNotSupportedFor("Replication.Rules.Destination.Bucket", "Replication.Rules.Destination.BucketRef")
+				}
+				if f0iter.Destination.BucketRef == nil && f0iter.Destination.Bucket == nil {
+					return ackerr.ResourceReferenceOrIDRequiredFor("Replication.Rules.Destination.Bucket", "Replication.Rules.Destination.BucketRef")
+				}
+			}
+		}
+	}
+
+	if ko.Spec.Replication != nil {
+		for _, f0iter := range ko.Spec.Replication.Rules {
+			if f0iter.Destination != nil {
+				if f0iter.Destination.EncryptionConfiguration != nil {
+					if f0iter.Destination.EncryptionConfiguration.ReplicaKMSKeyRef != nil && f0iter.Destination.EncryptionConfiguration.ReplicaKMSKeyID != nil {
+						return ackerr.ResourceReferenceAndIDNotSupportedFor("Replication.Rules.Destination.EncryptionConfiguration.ReplicaKMSKeyID", "Replication.Rules.Destination.EncryptionConfiguration.ReplicaKMSKeyRef")
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolveReferenceForAnalytics_StorageClassAnalysis_DataExport_Destination_S3BucketDestination_Bucket reads the resource referenced
+// from Analytics.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.BucketRef field and sets the Analytics.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket
+// from referenced resource. Returns a boolean indicating whether a reference
+// contains references, or an error
+func (rm *resourceManager) resolveReferenceForAnalytics_StorageClassAnalysis_DataExport_Destination_S3BucketDestination_Bucket(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Bucket,
+) (hasReferences bool, err error) {
+	for f0idx, f0iter := range ko.Spec.Analytics {
+		if f0iter.StorageClassAnalysis != nil {
+			if f0iter.StorageClassAnalysis.DataExport != nil {
+				if f0iter.StorageClassAnalysis.DataExport.Destination != nil {
+					if f0iter.StorageClassAnalysis.DataExport.Destination.S3BucketDestination != nil {
+						if f0iter.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.BucketRef != nil && f0iter.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.BucketRef.From != nil {
+							hasReferences = true
+							arr := f0iter.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.BucketRef.From
+							if arr.Name == nil || *arr.Name == "" {
+								return hasReferences, fmt.Errorf("provided resource reference is nil or empty: Analytics.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.BucketRef")
+							}
+							namespace, err := ackrt.ResolveCrossNamespaceReference(
+								ctx,
+								rm.cfg.EnableCrossNamespace,
+								&ko.Status.Conditions,
+								ackrt.CrossNamespaceRefKindResource,
+								ko.ObjectMeta.GetNamespace(),
+								arr.Namespace,
+								*arr.Name,
+							)
+							if err != nil {
+								return hasReferences, err
+							}
+							obj := &svcapitypes.Bucket{}
+							if err := getReferencedResourceState_Bucket(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+								return hasReferences, err
+							}
+							ko.Spec.Analytics[f0idx].StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket = (*string)(obj.Status.ACKResourceMetadata.ARN)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return hasReferences, nil
+}
+
+// getReferencedResourceState_Bucket looks up whether a referenced resource
+// exists and is in a ACK.ResourceSynced=True state. If the referenced resource does exist and is
+// in a Synced state, returns nil, otherwise returns `ackerr.ResourceReferenceTerminalFor` or
+// `ResourceReferenceNotSyncedFor` depending on if the resource is in a Terminal state.
+func getReferencedResourceState_Bucket(
+	ctx context.Context,
+	apiReader client.Reader,
+	obj *svcapitypes.Bucket,
+	name string, // the Kubernetes name of the referenced resource
+	namespace string, // the Kubernetes namespace of the referenced resource
+) error {
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := apiReader.Get(ctx, namespacedName, obj)
+	if err != nil {
+		return err
+	}
+	var refResourceTerminal bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeTerminal &&
+			cond.Status == corev1.ConditionTrue {
+			return ackerr.ResourceReferenceTerminalFor(
+				"Bucket",
+				namespace, name)
+		}
+	}
+	if refResourceTerminal {
+		return ackerr.ResourceReferenceTerminalFor(
+			"Bucket",
+			namespace, name)
+	}
+	var refResourceSynced bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeResourceSynced &&
+			cond.Status == corev1.ConditionTrue {
+			refResourceSynced = true
+		}
+	}
+	if !refResourceSynced {
+		return ackerr.ResourceReferenceNotSyncedFor(
+			"Bucket",
+			namespace, name)
+	}
+	if obj.Status.ACKResourceMetadata == nil || obj.Status.ACKResourceMetadata.ARN == nil {
+		return ackerr.ResourceReferenceMissingTargetFieldFor(
+			"Bucket",
+			namespace, name,
+			"Status.ACKResourceMetadata.ARN")
+	}
+	return nil
+}
+
+// resolveReferenceForEncryption_Rules_ApplyServerSideEncryptionByDefault_KMSMasterKeyID reads the resource referenced
+// from Encryption.Rules.ApplyServerSideEncryptionByDefault.KMSMasterKeyRef field and sets the Encryption.Rules.ApplyServerSideEncryptionByDefault.KMSMasterKeyID
+// from referenced resource. Returns a boolean indicating whether a reference
+// contains references, or an error
+func (rm *resourceManager) resolveReferenceForEncryption_Rules_ApplyServerSideEncryptionByDefault_KMSMasterKeyID(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Bucket,
+) (hasReferences bool, err error) {
+	if ko.Spec.Encryption != nil {
+		for f0idx, f0iter := range ko.Spec.Encryption.Rules {
+			if f0iter.ApplyServerSideEncryptionByDefault != nil {
+				if f0iter.ApplyServerSideEncryptionByDefault.KMSMasterKeyRef != nil && f0iter.ApplyServerSideEncryptionByDefault.KMSMasterKeyRef.From != nil {
+					hasReferences = true
+					arr := f0iter.ApplyServerSideEncryptionByDefault.KMSMasterKeyRef.From
+					if arr.Name == nil || *arr.Name == "" {
+						return hasReferences, fmt.Errorf("provided resource reference is nil or empty: Encryption.Rules.ApplyServerSideEncryptionByDefault.KMSMasterKeyRef")
+					}
+					namespace, err := ackrt.ResolveCrossNamespaceReference(
+						ctx,
+						rm.cfg.EnableCrossNamespace,
+						&ko.Status.Conditions,
+						ackrt.CrossNamespaceRefKindResource,
+						ko.ObjectMeta.GetNamespace(),
+						arr.Namespace,
+						*arr.Name,
+					)
+					if err != nil {
+						return hasReferences, err
+					}
+					obj := &kmsapitypes.Key{}
+					if err := getReferencedResourceState_Key(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+						return hasReferences, err
+					}
+					ko.Spec.Encryption.Rules[f0idx].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = (*string)(obj.Status.ACKResourceMetadata.ARN)
+				}
+			}
+		}
+	}
+
+	return hasReferences, nil
+}
+
+// getReferencedResourceState_Key looks up whether a referenced resource
+// exists and is in a ACK.ResourceSynced=True state. If the referenced resource does exist and is
+// in a Synced state, returns nil, otherwise returns `ackerr.ResourceReferenceTerminalFor` or
+// `ResourceReferenceNotSyncedFor` depending on if the resource is in a Terminal state.
+func getReferencedResourceState_Key(
+	ctx context.Context,
+	apiReader client.Reader,
+	obj *kmsapitypes.Key,
+	name string, // the Kubernetes name of the referenced resource
+	namespace string, // the Kubernetes namespace of the referenced resource
+) error {
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := apiReader.Get(ctx, namespacedName, obj)
+	if err != nil {
+		return err
+	}
+	var refResourceTerminal bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeTerminal &&
+			cond.Status == corev1.ConditionTrue {
+			return ackerr.ResourceReferenceTerminalFor(
+				"Key",
+				namespace, name)
+		}
+	}
+	if refResourceTerminal {
+		return ackerr.ResourceReferenceTerminalFor(
+			"Key",
+			namespace, name)
+	}
+	var refResourceSynced bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeResourceSynced &&
+			cond.Status == corev1.ConditionTrue {
+			refResourceSynced = true
+		}
+	}
+	if !refResourceSynced {
+		return ackerr.ResourceReferenceNotSyncedFor(
+			"Key",
+			namespace, name)
+	}
+	if obj.Status.ACKResourceMetadata == nil || obj.Status.ACKResourceMetadata.ARN == nil {
+		return ackerr.ResourceReferenceMissingTargetFieldFor(
+			"Key",
+			namespace, name,
+			"Status.ACKResourceMetadata.ARN")
+	}
+	return nil
+}
+
+// resolveReferenceForInventory_Destination_S3BucketDestination_Bucket reads the resource referenced
+// from Inventory.Destination.S3BucketDestination.BucketRef field and sets the Inventory.Destination.S3BucketDestination.Bucket
+// from referenced resource. Returns a boolean indicating whether a reference
+// contains references, or an error
+func (rm *resourceManager) resolveReferenceForInventory_Destination_S3BucketDestination_Bucket(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Bucket,
+) (hasReferences bool, err error) {
+	for f0idx, f0iter := range ko.Spec.Inventory {
+		if f0iter.Destination != nil {
+			if f0iter.Destination.S3BucketDestination != nil {
+				if f0iter.Destination.S3BucketDestination.BucketRef != nil && f0iter.Destination.S3BucketDestination.BucketRef.From != nil {
+					hasReferences = true
+					arr := f0iter.Destination.S3BucketDestination.BucketRef.From
+					if arr.Name == nil || *arr.Name == "" {
+						return hasReferences, fmt.Errorf("provided resource reference is nil or empty: Inventory.Destination.S3BucketDestination.BucketRef")
+					}
+					namespace, err := ackrt.ResolveCrossNamespaceReference(
+						ctx,
+						rm.cfg.EnableCrossNamespace,
+						&ko.Status.Conditions,
+						ackrt.CrossNamespaceRefKindResource,
+						ko.ObjectMeta.GetNamespace(),
+						arr.Namespace,
+						*arr.Name,
+					)
+					if err != nil {
+						return hasReferences, err
+					}
+					obj := &svcapitypes.Bucket{}
+					if err := getReferencedResourceState_Bucket(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+						return hasReferences, err
+					}
+					ko.Spec.Inventory[f0idx].Destination.S3BucketDestination.Bucket = (*string)(obj.Status.ACKResourceMetadata.ARN)
+				}
+			}
+		}
+	}
+
+	return hasReferences, nil
+}
+
+// resolveReferenceForLogging_LoggingEnabled_TargetBucket reads the resource referenced
+// from Logging.LoggingEnabled.TargetBucketRef field and sets the Logging.LoggingEnabled.TargetBucket
+// from referenced resource. Returns a boolean indicating whether a reference
+// contains references, or an error
+func (rm *resourceManager) resolveReferenceForLogging_LoggingEnabled_TargetBucket(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Bucket,
+) (hasReferences bool, err error) {
+	if ko.Spec.Logging != nil {
+		if ko.Spec.Logging.LoggingEnabled != nil {
+			if ko.Spec.Logging.LoggingEnabled.TargetBucketRef != nil && ko.Spec.Logging.LoggingEnabled.TargetBucketRef.From != nil {
+				hasReferences = true
+				arr := ko.Spec.Logging.LoggingEnabled.TargetBucketRef.From
+				if arr.Name == nil || *arr.Name == "" {
+					return hasReferences, fmt.Errorf("provided resource reference is nil or empty: Logging.LoggingEnabled.TargetBucketRef")
+				}
+				namespace, err := ackrt.ResolveCrossNamespaceReference(
+					ctx,
+					rm.cfg.EnableCrossNamespace,
+					&ko.Status.Conditions,
+					ackrt.CrossNamespaceRefKindResource,
+					ko.ObjectMeta.GetNamespace(),
+					arr.Namespace,
+					*arr.Name,
+				)
+				if err != nil {
+					return hasReferences, err
+				}
+				obj := &svcapitypes.Bucket{}
+				if err := getReferencedResourceState_Bucket(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+					return hasReferences, err
+				}
+				ko.Spec.Logging.LoggingEnabled.TargetBucket = (*string)(obj.Spec.Name)
+			}
+		}
+	}
+
+	return hasReferences, nil
+}
+
+// resolveReferenceForNotification_LambdaFunctionConfigurations_LambdaFunctionARN reads the resource referenced
+// from Notification.LambdaFunctionConfigurations.LambdaFunctionRef field and sets the Notification.LambdaFunctionConfigurations.LambdaFunctionARN
+// from referenced resource. Returns a boolean indicating whether a reference
+// contains references, or an error
+func (rm *resourceManager) resolveReferenceForNotification_LambdaFunctionConfigurations_LambdaFunctionARN(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Bucket,
+) (hasReferences bool, err error) {
+	if ko.Spec.Notification != nil {
+		for f0idx, f0iter := range ko.Spec.Notification.LambdaFunctionConfigurations {
+			if f0iter.LambdaFunctionRef != nil && f0iter.LambdaFunctionRef.From != nil {
+				hasReferences = true
+				arr := f0iter.LambdaFunctionRef.From
+				if arr.Name == nil || *arr.Name == "" {
+					return hasReferences, fmt.Errorf("provided resource reference is nil or empty: Notification.LambdaFunctionConfigurations.LambdaFunctionRef")
+				}
+				namespace, err := ackrt.ResolveCrossNamespaceReference(
+					ctx,
+					rm.cfg.EnableCrossNamespace,
+					&ko.Status.Conditions,
+					ackrt.CrossNamespaceRefKindResource,
+					ko.ObjectMeta.GetNamespace(),
+					arr.Namespace,
+					*arr.Name,
+				)
+				if err != nil {
+					return hasReferences, err
+				}
+				obj := &lambdaapitypes.Function{}
+				if err := getReferencedResourceState_Function(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+					return hasReferences, err
+				}
+				ko.Spec.Notification.LambdaFunctionConfigurations[f0idx].LambdaFunctionARN = (*string)(obj.Status.ACKResourceMetadata.ARN)
+			}
+		}
+	}
+
+	return hasReferences, nil
+}
+
+// getReferencedResourceState_Function looks up whether a referenced resource
+// exists and is in a ACK.ResourceSynced=True state. If the referenced resource does exist and is
+// in a Synced state, returns nil, otherwise returns `ackerr.ResourceReferenceTerminalFor` or
+// `ResourceReferenceNotSyncedFor` depending on if the resource is in a Terminal state.
+func getReferencedResourceState_Function(
+	ctx context.Context,
+	apiReader client.Reader,
+	obj *lambdaapitypes.Function,
+	name string, // the Kubernetes name of the referenced resource
+	namespace string, // the Kubernetes namespace of the referenced resource
+) error {
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := apiReader.Get(ctx, namespacedName, obj)
+	if err != nil {
+		return err
+	}
+	var refResourceTerminal bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeTerminal &&
+			cond.Status == corev1.ConditionTrue {
+			return ackerr.ResourceReferenceTerminalFor(
+				"Function",
+				namespace, name)
+		}
+	}
+	if refResourceTerminal {
+		return ackerr.ResourceReferenceTerminalFor(
+			"Function",
+			namespace, name)
+	}
+	var refResourceSynced bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeResourceSynced &&
+			cond.Status == corev1.ConditionTrue {
+			refResourceSynced = true
+		}
+	}
+	if !refResourceSynced {
+		return ackerr.ResourceReferenceNotSyncedFor(
+			"Function",
+			namespace, name)
+	}
+	if obj.Status.ACKResourceMetadata == nil || obj.Status.ACKResourceMetadata.ARN == nil {
+		return ackerr.ResourceReferenceMissingTargetFieldFor(
+			"Function",
+			namespace, name,
+			"Status.ACKResourceMetadata.ARN")
+	}
+	return nil
+}
+
+// resolveReferenceForNotification_QueueConfigurations_QueueARN reads the resource referenced
+// from Notification.QueueConfigurations.QueueRef field and sets the Notification.QueueConfigurations.QueueARN
+// from referenced resource. Returns a boolean indicating whether a reference
+// contains references, or an error
+func (rm *resourceManager) resolveReferenceForNotification_QueueConfigurations_QueueARN(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Bucket,
+) (hasReferences bool, err error) {
+	if ko.Spec.Notification != nil {
+		for f0idx, f0iter := range ko.Spec.Notification.QueueConfigurations {
+			if f0iter.QueueRef != nil && f0iter.QueueRef.From != nil {
+				hasReferences = true
+				arr := f0iter.QueueRef.From
+				if arr.Name == nil || *arr.Name == "" {
+					return hasReferences, fmt.Errorf("provided resource reference is nil or empty: Notification.QueueConfigurations.QueueRef")
+				}
+				namespace, err := ackrt.ResolveCrossNamespaceReference(
+					ctx,
+					rm.cfg.EnableCrossNamespace,
+					&ko.Status.Conditions,
+					ackrt.CrossNamespaceRefKindResource,
+					ko.ObjectMeta.GetNamespace(),
+					arr.Namespace,
+					*arr.Name,
+				)
+				if err != nil {
+					return hasReferences, err
+				}
+				obj := &sqsapitypes.Queue{}
+				if err := getReferencedResourceState_Queue(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+					return hasReferences, err
+				}
+				ko.Spec.Notification.QueueConfigurations[f0idx].QueueARN = (*string)(obj.Status.ACKResourceMetadata.ARN)
+			}
+		}
+	}
+
+	return hasReferences, nil
+}
+
+// getReferencedResourceState_Queue looks up whether a referenced resource
+// exists and is in a ACK.ResourceSynced=True state. If the referenced resource does exist and is
+// in a Synced state, returns nil, otherwise returns `ackerr.ResourceReferenceTerminalFor` or
+// `ResourceReferenceNotSyncedFor` depending on if the resource is in a Terminal state.
+func getReferencedResourceState_Queue(
+	ctx context.Context,
+	apiReader client.Reader,
+	obj *sqsapitypes.Queue,
+	name string, // the Kubernetes name of the referenced resource
+	namespace string, // the Kubernetes namespace of the referenced resource
+) error {
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := apiReader.Get(ctx, namespacedName, obj)
+	if err != nil {
+		return err
+	}
+	var refResourceTerminal bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeTerminal &&
+			cond.Status == corev1.ConditionTrue {
+			return ackerr.ResourceReferenceTerminalFor(
+				"Queue",
+				namespace, name)
+		}
+	}
+	if refResourceTerminal {
+		return ackerr.ResourceReferenceTerminalFor(
+			"Queue",
+			namespace, name)
+	}
+	var refResourceSynced bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeResourceSynced &&
+			cond.Status == corev1.ConditionTrue {
+			refResourceSynced = true
+		}
+	}
+	if !refResourceSynced {
+		return ackerr.ResourceReferenceNotSyncedFor(
+			"Queue",
+			namespace, name)
+	}
+	if obj.Status.ACKResourceMetadata == nil || obj.Status.ACKResourceMetadata.ARN == nil {
+		return ackerr.ResourceReferenceMissingTargetFieldFor(
+			"Queue",
+			namespace, name,
+			"Status.ACKResourceMetadata.ARN")
+	}
+	return nil
+}
+
+// resolveReferenceForNotification_TopicConfigurations_TopicARN reads the resource referenced
+// from Notification.TopicConfigurations.TopicRef field and sets the Notification.TopicConfigurations.TopicARN
+// from referenced resource. Returns a boolean indicating whether a reference
+// contains references, or an error
+func (rm *resourceManager) resolveReferenceForNotification_TopicConfigurations_TopicARN(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Bucket,
+) (hasReferences bool, err error) {
+	if ko.Spec.Notification != nil {
+		for f0idx, f0iter := range ko.Spec.Notification.TopicConfigurations {
+			if f0iter.TopicRef != nil && f0iter.TopicRef.From != nil {
+				hasReferences = true
+				arr := f0iter.TopicRef.From
+				if arr.Name == nil || *arr.Name == "" {
+					return hasReferences, fmt.Errorf("provided resource reference is nil or empty: Notification.TopicConfigurations.TopicRef")
+				}
+				namespace, err := ackrt.ResolveCrossNamespaceReference(
+					ctx,
+					rm.cfg.EnableCrossNamespace,
+					&ko.Status.Conditions,
+					ackrt.CrossNamespaceRefKindResource,
+					ko.ObjectMeta.GetNamespace(),
+					arr.Namespace,
+					*arr.Name,
+				)
+				if err != nil {
+					return hasReferences, err
+				}
+				obj := &snsapitypes.Topic{}
+				if err := getReferencedResourceState_Topic(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+					return hasReferences, err
+				}
+				ko.Spec.Notification.TopicConfigurations[f0idx].TopicARN = (*string)(obj.Status.ACKResourceMetadata.ARN)
+			}
+		}
+	}
+
+	return hasReferences, nil
+}
+
+// getReferencedResourceState_Topic looks up whether a referenced resource
+// exists and is in a ACK.ResourceSynced=True state. If the referenced resource does exist and is
+// in a Synced state, returns nil, otherwise returns `ackerr.ResourceReferenceTerminalFor` or
+// `ResourceReferenceNotSyncedFor` depending on if the resource is in a Terminal state.
+func getReferencedResourceState_Topic(
+	ctx context.Context,
+	apiReader client.Reader,
+	obj *snsapitypes.Topic,
+	name string, // the Kubernetes name of the referenced resource
+	namespace string, // the Kubernetes namespace of the referenced resource
+) error {
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := apiReader.Get(ctx, namespacedName, obj)
+	if err != nil {
+		return err
+	}
+	var refResourceTerminal bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeTerminal &&
+			cond.Status == corev1.ConditionTrue {
+			return ackerr.ResourceReferenceTerminalFor(
+				"Topic",
+				namespace, name)
+		}
+	}
+	if refResourceTerminal {
+		return ackerr.ResourceReferenceTerminalFor(
+			"Topic",
+			namespace, name)
+	}
+	var refResourceSynced bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeResourceSynced &&
+			cond.Status == corev1.ConditionTrue {
+			refResourceSynced = true
+		}
+	}
+	if !refResourceSynced {
+		return ackerr.ResourceReferenceNotSyncedFor(
+			"Topic",
+			namespace, name)
+	}
+	if obj.Status.ACKResourceMetadata == nil || obj.Status.ACKResourceMetadata.ARN == nil {
+		return ackerr.ResourceReferenceMissingTargetFieldFor(
+			"Topic",
+			namespace, name,
+			"Status.ACKResourceMetadata.ARN")
+	}
 	return nil
 }
 
@@ -180,3 +1012,91 @@ func getReferencedResourceState_Role(
 	}
 	return nil
 }
+
+// resolveReferenceForReplication_Rules_Destination_Bucket reads the resource referenced
+// from Replication.Rules.Destination.BucketRef field and sets the Replication.Rules.Destination.Bucket
+// from referenced resource. Returns a boolean indicating whether a reference
+// contains references, or an error
+func (rm *resourceManager) resolveReferenceForReplication_Rules_Destination_Bucket(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Bucket,
+) (hasReferences bool, err error) {
+	if ko.Spec.Replication != nil {
+		for f0idx, f0iter := range ko.Spec.Replication.Rules {
+			if f0iter.Destination != nil {
+				if f0iter.Destination.BucketRef != nil && f0iter.Destination.BucketRef.From != nil {
+					hasReferences = true
+					arr := f0iter.Destination.BucketRef.From
+					if arr.Name == nil || *arr.Name == "" {
+						return hasReferences, fmt.Errorf("provided resource reference is nil or empty: Replication.Rules.Destination.BucketRef")
+					}
+					namespace, err := ackrt.ResolveCrossNamespaceReference(
+						ctx,
+						rm.cfg.EnableCrossNamespace,
+						&ko.Status.Conditions,
+						ackrt.CrossNamespaceRefKindResource,
+						ko.ObjectMeta.GetNamespace(),
+						arr.Namespace,
+						*arr.Name,
+					)
+					if err != nil {
+						return hasReferences, err
+					}
+					obj := &svcapitypes.Bucket{}
+					if err := getReferencedResourceState_Bucket(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+						return hasReferences, err
+					}
+					ko.Spec.Replication.Rules[f0idx].Destination.Bucket = (*string)(obj.Status.ACKResourceMetadata.ARN)
+				}
+			}
+		}
+	}
+
+	return hasReferences, nil
+}
+
+// resolveReferenceForReplication_Rules_Destination_EncryptionConfiguration_ReplicaKMSKeyID reads the resource referenced
+// from Replication.Rules.Destination.EncryptionConfiguration.ReplicaKMSKeyRef field and sets the Replication.Rules.Destination.EncryptionConfiguration.ReplicaKMSKeyID
+// from referenced resource. Returns a boolean indicating whether a reference
+// contains references, or an error
+func (rm *resourceManager) resolveReferenceForReplication_Rules_Destination_EncryptionConfiguration_ReplicaKMSKeyID(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Bucket,
+) (hasReferences bool, err error) {
+	if ko.Spec.Replication != nil {
+		for f0idx, f0iter := range ko.Spec.Replication.Rules {
+			if f0iter.Destination != nil {
+				if f0iter.Destination.EncryptionConfiguration != nil {
+					if f0iter.Destination.EncryptionConfiguration.ReplicaKMSKeyRef != nil && f0iter.Destination.EncryptionConfiguration.ReplicaKMSKeyRef.From != nil {
+						hasReferences = true
+						arr := f0iter.Destination.EncryptionConfiguration.ReplicaKMSKeyRef.From
+						if arr.Name == nil || *arr.Name == "" {
+							return hasReferences, fmt.Errorf("provided resource reference is nil or empty: Replication.Rules.Destination.EncryptionConfiguration.ReplicaKMSKeyRef")
+						}
+						namespace, err := ackrt.ResolveCrossNamespaceReference(
+							ctx,
+							rm.cfg.EnableCrossNamespace,
+							&ko.Status.Conditions,
+							ackrt.CrossNamespaceRefKindResource,
+							ko.ObjectMeta.GetNamespace(),
+							arr.Namespace,
+							*arr.Name,
+						)
+						if err != nil {
+							return hasReferences, err
+						}
+						obj := &kmsapitypes.Key{}
+						if err := getReferencedResourceState_Key(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+							return hasReferences, err
+						}
+						ko.Spec.Replication.Rules[f0idx].Destination.EncryptionConfiguration.ReplicaKMSKeyID = (*string)(obj.Status.ACKResourceMetadata.ARN)
+					}
+				}
+			}
+		}
+	}
+
+	return hasReferences, nil
+}
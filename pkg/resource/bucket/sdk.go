@@ -74,6 +74,9 @@ func (rm *resourceManager) sdkCreate(
 	defer func() {
 		exit(err)
 	}()
+	if err := applyBucketClass(ctx, desired.ko, true); err != nil {
+		return nil, err
+	}
 	input, err := rm.newCreateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
@@ -83,6 +86,9 @@ func (rm *resourceManager) sdkCreate(
 	if err := validateDirectoryBucketSpec(desired.ko); err != nil {
 		return nil, err
 	}
+	if err := validatePolicySpec(desired.ko); err != nil {
+		return nil, err
+	}
 
 	// Only set default LocationConstraint for general-purpose buckets
 	// Directory buckets use CreateBucketConfiguration.Location instead
@@ -132,6 +138,7 @@ func (rm *resourceManager) sdkCreate(
 	}
 
 	rm.setStatusDefaults(ko)
+	rm.putMandatoryPolicyStatements(ctx, &resource{ko})
 	ackcondition.SetSynced(&resource{ko}, corev1.ConditionFalse, aws.String("bucket created, requeue for updates"), nil)
 	err = ackrequeue.NeededAfter(fmt.Errorf("Reconciling to sync additional fields"), time.Second)
 	return &resource{ko}, err
@@ -229,6 +236,29 @@ func (rm *resourceManager) sdkDelete(
 	defer func() {
 		exit(err)
 	}()
+	// Use the region the bucket was found in by the preceding read
+	rm, err = rm.managerForResource(ctx, r)
+	if err != nil {
+		return r, err
+	}
+	if err := rm.ensureDeletable(ctx, r); err != nil {
+		return r, err
+	}
+	if isForceDeleteEnabled(r) {
+		emptied, err := rm.emptyBucket(ctx, r)
+		if err != nil {
+			return r, err
+		}
+		if !emptied {
+			return r, requeueWaitWhileEmptying
+		}
+	}
+	if isDestinationPolicyManaged(r) || hasDestinationGrants(r) {
+		if err := rm.removeDestinationGrants(ctx, r); err != nil {
+			return r, err
+		}
+	}
+
 	input, err := rm.newDeleteRequestPayload(r)
 	if err != nil {
 		return nil, err
@@ -365,8 +395,7 @@ func (rm *resourceManager) terminalAWSError(err error) bool {
 		return false
 	}
 	switch terminalErr.ErrorCode() {
-	case "PermanentRedirect",
-		"InvalidLocationConstraint",
+	case "InvalidLocationConstraint",
 		"MalformedXML",
 		"IllegalLocationConstraintException",
 		"InvalidNamespaceHeader",
@@ -0,0 +1,111 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// subresourceSyncer applies the properties of a bucket one at a time. Unlike
+// returning on the first error, every property is attempted, the outcome of
+// each is recorded in Status.SubresourceConditions and the errors are
+// aggregated, so that one failing Put call does not hide whether the others
+// were applied.
+type subresourceSyncer struct {
+	ko     *svcapitypes.Bucket
+	errs   []error
+	failed map[string]bool
+}
+
+// newSubresourceSyncer returns a subresourceSyncer recording conditions in the
+// status of ko.
+func newSubresourceSyncer(ko *svcapitypes.Bucket) *subresourceSyncer {
+	return &subresourceSyncer{
+		ko:     ko,
+		failed: map[string]bool{},
+	}
+}
+
+// sync applies the named property with syncFn, unless one of the properties
+// it depends on failed to sync earlier, and records the outcome.
+func (s *subresourceSyncer) sync(
+	property string,
+	syncFn func() error,
+	dependsOn ...string,
+) {
+	for _, dependency := range dependsOn {
+		if s.failed[dependency] {
+			s.record(property, fmt.Errorf(
+				"not attempted because property '%s' failed to sync", dependency,
+			))
+			return
+		}
+	}
+	s.record(property, syncFn())
+}
+
+// record sets the condition of the named property according to err and
+// collects err, if any.
+func (s *subresourceSyncer) record(property string, err error) {
+	if err != nil {
+		s.failed[property] = true
+		s.errs = append(s.errs, fmt.Errorf(ErrSyncingPutProperty+": %w", property, err))
+	}
+	setSubresourceCondition(s.ko, property, err)
+}
+
+// err returns the errors of all the properties that failed to sync, or nil.
+func (s *subresourceSyncer) err() error {
+	return errors.Join(s.errs...)
+}
+
+// setSubresourceCondition sets the condition of the named property in the
+// status of ko to Synced if err is nil, or to Error otherwise. As with
+// resource conditions, the transition time only changes with the status.
+func setSubresourceCondition(
+	ko *svcapitypes.Bucket,
+	property string,
+	err error,
+) {
+	status := svcapitypes.SubresourceConditionStatusSynced
+	var message *string
+	if err != nil {
+		status = svcapitypes.SubresourceConditionStatusError
+		msg := err.Error()
+		message = &msg
+	}
+
+	var condition *svcapitypes.SubresourceCondition
+	for _, c := range ko.Status.SubresourceConditions {
+		if c != nil && c.Name == property {
+			condition = c
+			break
+		}
+	}
+	if condition == nil {
+		condition = &svcapitypes.SubresourceCondition{Name: property}
+		ko.Status.SubresourceConditions = append(ko.Status.SubresourceConditions, condition)
+	}
+	if condition.Status != status {
+		now := metav1.Now()
+		condition.LastTransitionTime = &now
+	}
+	condition.Status = status
+	condition.Message = message
+}
@@ -0,0 +1,122 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// subresourceCondition returns the condition recorded for the named property,
+// or nil.
+func subresourceCondition(
+	ko *svcapitypes.Bucket,
+	property string,
+) *svcapitypes.SubresourceCondition {
+	for _, c := range ko.Status.SubresourceConditions {
+		if c.Name == property {
+			return c
+		}
+	}
+	return nil
+}
+
+// Test_customUpdateBucket_AttemptsEveryProperty verifies that a property
+// failing to sync neither prevents the others from being applied nor hides
+// which one failed, and that a property depending on the failed one is not
+// attempted.
+func Test_customUpdateBucket_AttemptsEveryProperty(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rm := &resourceManager{
+		sdkapi: newMockedSDKClient(map[string]opResult{
+			"DeleteBucketEncryption":  {output: &svcsdk.DeleteBucketEncryptionOutput{}},
+			"PutBucketLogging":        {err: apiErr("AccessDenied")},
+			"DeleteBucketLifecycle":   {output: &svcsdk.DeleteBucketLifecycleOutput{}},
+			"DeletePublicAccessBlock": {err: apiErr("AccessDenied")},
+			"PutBucketPolicy":         {err: apiErr("ShouldNotBeCalled")},
+		}),
+		metrics: ackmetrics.NewMetrics("s3"),
+	}
+
+	desired := newBucketResource("my-bucket")
+	desired.ko.Spec.Policy = strPtr(publicReadPolicy)
+	latest := newBucketResource("my-bucket")
+	delta := ackcompare.NewDelta()
+	delta.Add("Spec.Encryption", nil, nil)
+	delta.Add("Spec.Logging", nil, nil)
+	delta.Add("Spec.Lifecycle", nil, nil)
+	delta.Add("Spec.PublicAccessBlock", nil, nil)
+	delta.Add("Spec.Policy", nil, nil)
+
+	updated, err := rm.customUpdateBucket(context.Background(), desired, latest, delta)
+	require.Error(err)
+	require.NotNil(updated)
+	assert.Contains(err.Error(), "Logging")
+	assert.Contains(err.Error(), "PublicAccessBlock")
+	assert.NotContains(err.Error(), "ShouldNotBeCalled")
+
+	ko := updated.ko
+	for property, status := range map[string]svcapitypes.SubresourceConditionStatus{
+		"Encryption":        svcapitypes.SubresourceConditionStatusSynced,
+		"Lifecycle":         svcapitypes.SubresourceConditionStatusSynced,
+		"Logging":           svcapitypes.SubresourceConditionStatusError,
+		"PublicAccessBlock": svcapitypes.SubresourceConditionStatusError,
+		"Policy":            svcapitypes.SubresourceConditionStatusError,
+	} {
+		c := subresourceCondition(ko, property)
+		require.NotNil(c, property)
+		assert.Equal(status, c.Status, property)
+		assert.NotNil(c.LastTransitionTime, property)
+	}
+	assert.Nil(subresourceCondition(ko, "Encryption").Message)
+	require.NotNil(subresourceCondition(ko, "Logging").Message)
+	assert.Contains(*subresourceCondition(ko, "Logging").Message, "AccessDenied")
+	require.NotNil(subresourceCondition(ko, "Policy").Message)
+	assert.Contains(*subresourceCondition(ko, "Policy").Message, "PublicAccessBlock")
+	assert.Nil(subresourceCondition(ko, "Tagging"))
+}
+
+// Test_setSubresourceCondition_TransitionTime verifies that the transition
+// time of a condition only changes along with its status.
+func Test_setSubresourceCondition_TransitionTime(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ko := newBucketResource("my-bucket").ko
+	setSubresourceCondition(ko, "Policy", errors.New("boom"))
+	require.Len(ko.Status.SubresourceConditions, 1)
+	c := ko.Status.SubresourceConditions[0]
+	first := c.LastTransitionTime
+
+	setSubresourceCondition(ko, "Policy", errors.New("boom again"))
+	require.Len(ko.Status.SubresourceConditions, 1)
+	assert.Same(first, c.LastTransitionTime)
+	assert.Equal("boom again", *c.Message)
+
+	setSubresourceCondition(ko, "Policy", nil)
+	assert.Equal(svcapitypes.SubresourceConditionStatusSynced, c.Status)
+	assert.NotSame(first, c.LastTransitionTime)
+	assert.Nil(c.Message)
+}
@@ -0,0 +1,442 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// syncStep describes how customUpdateBucket applies one bucket property, and
+// how that property relates to the others.
+type syncStep struct {
+	// property names the step in Status.SubresourceConditions.
+	property string
+	// fields are the spec fields that, when they differ, require the step.
+	fields []string
+	// declaredBy are the spec fields declaring the property, when only the
+	// properties declared in the spec are managed. Defaults to fields.
+	declaredBy []string
+	// directoryBuckets is true if the property is supported for directory
+	// buckets.
+	directoryBuckets bool
+	// enabled returns false when the property is not managed for the
+	// bucket, in which case the step is never planned. Steps without it are
+	// always enabled.
+	enabled func(desired, latest *resource) bool
+	// requires returns the properties that must be applied, successfully,
+	// before this one to reach the desired state. Properties that are not
+	// part of the update are ignored.
+	requires func(desired, latest *resource) []string
+	// precondition returns an error when the desired state cannot be applied
+	// regardless of ordering, in which case the step is not attempted.
+	precondition func(desired, latest *resource) error
+	// sync applies the property.
+	sync func(
+		ctx context.Context,
+		rm *resourceManager,
+		desired *resource,
+		latest *resource,
+		isDirectoryBucket bool,
+	) error
+}
+
+// plannedSync is a step of a sync plan, along with the properties of the plan
+// it depends on.
+type plannedSync struct {
+	*syncStep
+	dependsOn []string
+}
+
+// bucketSyncSteps lists the bucket properties applied by customUpdateBucket.
+// Independent properties are applied in the order they are listed here.
+var bucketSyncSteps = []*syncStep{
+	{
+		property: "ABAC",
+		fields:   []string{"Spec.Abac"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncABAC(ctx, desired)
+		},
+	},
+	{
+		property: "Accelerate",
+		fields:   []string{"Spec.Accelerate"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncAccelerate(ctx, desired)
+		},
+	},
+	{
+		property: "Analytics",
+		fields:   []string{"Spec.Analytics"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, latest *resource, _ bool) error {
+			return rm.syncAnalytics(ctx, desired, latest)
+		},
+	},
+	{
+		property: "ACL",
+		fields: []string{
+			"Spec.ACL",
+			"Spec.GrantFullControl",
+			"Spec.GrantRead",
+			"Spec.GrantReadACP",
+			"Spec.GrantWrite",
+			"Spec.GrantWriteACP",
+		},
+		// ACLs are rejected while ownership is enforced, so lift the
+		// enforcement first.
+		requires: func(desired, _ *resource) []string {
+			if !bucketOwnerEnforced(desired) {
+				return []string{"OwnershipControls"}
+			}
+			return nil
+		},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncACL(ctx, desired)
+		},
+	},
+	{
+		property: "CORS",
+		fields:   []string{"Spec.CORS"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncCORS(ctx, desired)
+		},
+	},
+	{
+		property: "DestinationPolicies",
+		fields: []string{
+			"Spec.Analytics",
+			"Spec.Inventory",
+			"Spec.Logging",
+			"Spec.Replication",
+			destinationGrantsPath,
+		},
+		declaredBy: []string{
+			"Spec.Analytics",
+			"Spec.Inventory",
+			"Spec.Logging",
+			"Spec.Replication",
+		},
+		// The grants recorded in the status are revoked even once the
+		// destination policies are no longer managed.
+		enabled: func(desired, latest *resource) bool {
+			return isDestinationPolicyManaged(desired) || hasDestinationGrants(latest)
+		},
+		sync: func(ctx context.Context, rm *resourceManager, desired, latest *resource, _ bool) error {
+			return rm.syncDestinationPolicies(ctx, desired, latest)
+		},
+	},
+	{
+		property:         "Encryption",
+		fields:           []string{"Spec.Encryption"},
+		directoryBuckets: true,
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncEncryption(ctx, desired)
+		},
+	},
+	{
+		property: "IntelligentTiering",
+		fields:   []string{"Spec.IntelligentTiering"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, latest *resource, _ bool) error {
+			return rm.syncIntelligentTiering(ctx, desired, latest)
+		},
+	},
+	{
+		property: "Inventory",
+		fields:   []string{"Spec.Inventory"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, latest *resource, _ bool) error {
+			return rm.syncInventory(ctx, desired, latest)
+		},
+	},
+	{
+		property:         "Lifecycle",
+		fields:           []string{"Spec.Lifecycle"},
+		directoryBuckets: true,
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncLifecycle(ctx, desired)
+		},
+	},
+	{
+		property: "Logging",
+		fields:   []string{"Spec.Logging"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncLogging(ctx, desired)
+		},
+	},
+	{
+		property: "MetadataConfiguration",
+		fields:   []string{"Spec.MetadataConfiguration"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, latest *resource, _ bool) error {
+			return rm.syncMetadataConfiguration(ctx, desired, latest)
+		},
+	},
+	{
+		property: "Metrics",
+		fields:   []string{"Spec.Metrics"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, latest *resource, _ bool) error {
+			return rm.syncMetrics(ctx, desired, latest)
+		},
+	},
+	{
+		property: "Notification",
+		fields:   []string{"Spec.Notification"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncNotification(ctx, desired)
+		},
+	},
+	{
+		property: "OwnershipControls",
+		fields:   []string{"Spec.OwnershipControls"},
+		// Enforcing ownership fails while the ACL grants access to others,
+		// so reset the ACL first.
+		requires: func(desired, _ *resource) []string {
+			if bucketOwnerEnforced(desired) {
+				return []string{"ACL"}
+			}
+			return nil
+		},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncOwnershipControls(ctx, desired)
+		},
+	},
+	{
+		property: "PublicAccessBlock",
+		fields:   []string{"Spec.PublicAccessBlock"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncPublicAccessBlock(ctx, desired)
+		},
+	},
+	{
+		property:         "Policy",
+		fields:           []string{"Spec.Policy", "Spec.PolicyDocument"},
+		directoryBuckets: true,
+		// A policy granting public access is rejected until the public
+		// access block allows it.
+		requires: func(desired, _ *resource) []string {
+			if desiredPolicyGrantsPublicAccess(desired) {
+				return []string{"PublicAccessBlock"}
+			}
+			return nil
+		},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, isDirectoryBucket bool) error {
+			return rm.syncPolicy(ctx, desired, isDirectoryBucket)
+		},
+	},
+	{
+		property: "RequestPayment",
+		fields:   []string{"Spec.RequestPayment"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncRequestPayment(ctx, desired)
+		},
+	},
+	{
+		property:         "Tagging",
+		fields:           []string{"Spec.Tagging"},
+		directoryBuckets: true,
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncTagging(ctx, desired)
+		},
+	},
+	{
+		property: "Website",
+		fields:   []string{"Spec.Website"},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncWebsite(ctx, desired)
+		},
+	},
+	{
+		property:   "Versioning",
+		fields:     []string{"Spec.Versioning", "Spec.Replication"},
+		declaredBy: []string{"Spec.Versioning"},
+		// Versioning cannot be suspended while replication is configured,
+		// so remove replication first.
+		requires: func(desired, _ *resource) []string {
+			if !replicationEnabled(desired) {
+				return []string{"Replication"}
+			}
+			return nil
+		},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncVersioning(ctx, desired)
+		},
+	},
+	{
+		property:   "Replication",
+		fields:     []string{"Spec.Versioning", "Spec.Replication"},
+		declaredBy: []string{"Spec.Replication"},
+		requires: func(desired, _ *resource) []string {
+			if replicationEnabled(desired) {
+				return []string{"Versioning"}
+			}
+			return nil
+		},
+		precondition: func(desired, latest *resource) error {
+			if replicationEnabled(desired) && !versioningEnabled(desired, latest) {
+				return errors.New("replication requires versioning to be Enabled")
+			}
+			return nil
+		},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncReplication(ctx, desired)
+		},
+	},
+	{
+		property: "ObjectLockConfiguration",
+		fields:   []string{"Spec.ObjectLockConfiguration", "Spec.ObjectLockEnabledForBucket"},
+		requires: func(desired, _ *resource) []string {
+			if objectLockEnabled(desired) {
+				return []string{"Versioning"}
+			}
+			return nil
+		},
+		precondition: func(desired, latest *resource) error {
+			if objectLockEnabled(desired) && !versioningEnabled(desired, latest) {
+				return errors.New("object lock requires versioning to be Enabled")
+			}
+			return nil
+		},
+		sync: func(ctx context.Context, rm *resourceManager, desired, _ *resource, _ bool) error {
+			return rm.syncObjectLockConfiguration(ctx, desired)
+		},
+	},
+}
+
+// planBucketSync returns the steps needed to bring latest to desired, given
+// the delta between them, ordered so that each step comes after the steps it
+// requires. Steps that are not ordered relative to each other keep their
+// order in steps. An error is returned if the requirements form a cycle.
+func planBucketSync(
+	steps []*syncStep,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+	isDirectoryBucket bool,
+) ([]plannedSync, error) {
+	var nodes []plannedSync
+	index := map[string]int{}
+	for _, step := range steps {
+		if isDirectoryBucket && !step.directoryBuckets {
+			continue
+		}
+		if step.enabled != nil && !step.enabled(desired, latest) {
+			continue
+		}
+		if isExplicitlyManaged(desired) && !step.isDeclared(desired) {
+			continue
+		}
+		for _, field := range step.fields {
+			if delta.DifferentAt(field) {
+				index[step.property] = len(nodes)
+				nodes = append(nodes, plannedSync{syncStep: step})
+				break
+			}
+		}
+	}
+
+	// dependents[i] lists the nodes that require node i.
+	dependents := make([][]int, len(nodes))
+	pending := make([]int, len(nodes))
+	for i := range nodes {
+		if nodes[i].requires == nil {
+			continue
+		}
+		for _, property := range nodes[i].requires(desired, latest) {
+			j, planned := index[property]
+			if !planned {
+				continue
+			}
+			nodes[i].dependsOn = append(nodes[i].dependsOn, property)
+			dependents[j] = append(dependents[j], i)
+			pending[i]++
+		}
+	}
+
+	// Repeatedly pick the first node whose requirements are all planned.
+	plan := make([]plannedSync, 0, len(nodes))
+	done := make([]bool, len(nodes))
+	for len(plan) < len(nodes) {
+		next := -1
+		for i := range nodes {
+			if !done[i] && pending[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			var cycle []string
+			for i := range nodes {
+				if !done[i] {
+					cycle = append(cycle, nodes[i].property)
+				}
+			}
+			return nil, fmt.Errorf(
+				"cannot order bucket properties with circular requirements: %s",
+				strings.Join(cycle, ", "),
+			)
+		}
+		done[next] = true
+		plan = append(plan, nodes[next])
+		for _, i := range dependents[next] {
+			pending[i]--
+		}
+	}
+	return plan, nil
+}
+
+// bucketOwnerEnforced returns true if the desired ownership controls disable
+// ACLs.
+func bucketOwnerEnforced(r *resource) bool {
+	oc := r.ko.Spec.OwnershipControls
+	if oc == nil {
+		return false
+	}
+	for _, rule := range oc.Rules {
+		if rule != nil && rule.ObjectOwnership != nil &&
+			*rule.ObjectOwnership == string(svcapitypes.ObjectOwnership_BucketOwnerEnforced) {
+			return true
+		}
+	}
+	return false
+}
+
+// replicationEnabled returns true if the desired state configures replication.
+func replicationEnabled(r *resource) bool {
+	return r.ko.Spec.Replication != nil && r.ko.Spec.Replication.Rules != nil
+}
+
+// objectLockEnabled returns true if the desired state enables object lock or
+// sets a default retention rule.
+func objectLockEnabled(r *resource) bool {
+	if r.ko.Spec.ObjectLockEnabledForBucket != nil && *r.ko.Spec.ObjectLockEnabledForBucket {
+		return true
+	}
+	return r.ko.Spec.ObjectLockConfiguration != nil && r.ko.Spec.ObjectLockConfiguration.Rule != nil
+}
+
+// versioningEnabled returns true if versioning is Enabled in the desired
+// state or, when the desired state leaves it unset, in the latest state.
+func versioningEnabled(desired, latest *resource) bool {
+	versioning := desired.ko.Spec.Versioning
+	if versioning == nil && latest != nil {
+		versioning = latest.ko.Spec.Versioning
+	}
+	return versioning != nil && versioning.Status != nil &&
+		*versioning.Status == string(svcsdktypes.BucketVersioningStatusEnabled)
+}
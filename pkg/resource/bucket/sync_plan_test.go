@@ -0,0 +1,327 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"testing"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+const publicReadPolicy = `{
+	"Version": "2012-10-17",
+	"Statement": [{
+		"Effect": "Allow",
+		"Principal": "*",
+		"Action": "s3:GetObject",
+		"Resource": "arn:aws:s3:::my-bucket/*"
+	}]
+}`
+
+const accountPolicy = `{
+	"Version": "2012-10-17",
+	"Statement": {
+		"Effect": "Allow",
+		"Principal": {"AWS": "arn:aws:iam::111122223333:root"},
+		"Action": "s3:GetObject",
+		"Resource": "arn:aws:s3:::my-bucket/*"
+	}
+}`
+
+// deltaAt returns a delta that differs at each of the given fields.
+func deltaAt(fields ...string) *ackcompare.Delta {
+	delta := ackcompare.NewDelta()
+	for _, field := range fields {
+		delta.Add(field, nil, nil)
+	}
+	return delta
+}
+
+// planProperties returns the properties of plan, in order, and the
+// properties each of them depends on.
+func planProperties(plan []plannedSync) ([]string, map[string][]string) {
+	order := []string{}
+	dependsOn := map[string][]string{}
+	for _, step := range plan {
+		order = append(order, step.property)
+		if len(step.dependsOn) > 0 {
+			dependsOn[step.property] = step.dependsOn
+		}
+	}
+	return order, dependsOn
+}
+
+func ownershipControls(ownership svcapitypes.ObjectOwnership) *svcapitypes.OwnershipControls {
+	return &svcapitypes.OwnershipControls{
+		Rules: []*svcapitypes.OwnershipControlsRule{
+			{ObjectOwnership: strPtr(string(ownership))},
+		},
+	}
+}
+
+func versioning(status string) *svcapitypes.VersioningConfiguration {
+	return &svcapitypes.VersioningConfiguration{Status: strPtr(status)}
+}
+
+func replication() *svcapitypes.ReplicationConfiguration {
+	return &svcapitypes.ReplicationConfiguration{
+		Role: strPtr("arn:aws:iam::111122223333:role/replication"),
+		Rules: []*svcapitypes.ReplicationRule{
+			{ID: strPtr("all"), Status: strPtr("Enabled")},
+		},
+	}
+}
+
+// Test_planBucketSync_Transitions verifies the order of each pair of
+// properties with requirements on one another, in both directions.
+func Test_planBucketSync_Transitions(t *testing.T) {
+	tests := []struct {
+		name          string
+		desired       func(ko *svcapitypes.Bucket)
+		delta         *ackcompare.Delta
+		wantOrder     []string
+		wantDependsOn map[string][]string
+	}{
+		{
+			name: "enable replication after versioning",
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Spec.Versioning = versioning("Enabled")
+				ko.Spec.Replication = replication()
+			},
+			delta:         deltaAt("Spec.Versioning", "Spec.Replication"),
+			wantOrder:     []string{"Versioning", "Replication"},
+			wantDependsOn: map[string][]string{"Replication": {"Versioning"}},
+		},
+		{
+			name: "disable replication before suspending versioning",
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Spec.Versioning = versioning("Suspended")
+			},
+			delta:         deltaAt("Spec.Versioning", "Spec.Replication"),
+			wantOrder:     []string{"Replication", "Versioning"},
+			wantDependsOn: map[string][]string{"Versioning": {"Replication"}},
+		},
+		{
+			name: "destination policies along with the destinations when managed",
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Annotations = map[string]string{svcapitypes.AnnotationManageDestinationPolicies: "true"}
+			},
+			delta:         deltaAt("Spec.Logging"),
+			wantOrder:     []string{"DestinationPolicies", "Logging"},
+			wantDependsOn: map[string][]string{},
+		},
+		{
+			name: "enable object lock after versioning",
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Spec.Versioning = versioning("Enabled")
+				ko.Spec.ObjectLockEnabledForBucket = boolPtr(true)
+			},
+			delta:         deltaAt("Spec.Versioning", "Spec.ObjectLockEnabledForBucket"),
+			wantOrder:     []string{"Replication", "Versioning", "ObjectLockConfiguration"},
+			wantDependsOn: map[string][]string{"Versioning": {"Replication"}, "ObjectLockConfiguration": {"Versioning"}},
+		},
+		{
+			name: "object lock left unset does not wait on versioning",
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Spec.Versioning = versioning("Enabled")
+				ko.Spec.Replication = replication()
+			},
+			delta:         deltaAt("Spec.Replication", "Spec.ObjectLockConfiguration"),
+			wantOrder:     []string{"Versioning", "Replication", "ObjectLockConfiguration"},
+			wantDependsOn: map[string][]string{"Replication": {"Versioning"}},
+		},
+		{
+			name: "relax public access block before a public policy",
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Spec.PublicAccessBlock = &svcapitypes.PublicAccessBlockConfiguration{
+					BlockPublicPolicy: boolPtr(false),
+				}
+				ko.Spec.Policy = strPtr(publicReadPolicy)
+			},
+			delta:         deltaAt("Spec.Policy", "Spec.PublicAccessBlock"),
+			wantOrder:     []string{"PublicAccessBlock", "Policy"},
+			wantDependsOn: map[string][]string{"Policy": {"PublicAccessBlock"}},
+		},
+		{
+			name: "tighten public access block independently of a private policy",
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Spec.PublicAccessBlock = &svcapitypes.PublicAccessBlockConfiguration{
+					BlockPublicPolicy: boolPtr(true),
+				}
+				ko.Spec.Policy = strPtr(accountPolicy)
+			},
+			delta:         deltaAt("Spec.Policy", "Spec.PublicAccessBlock"),
+			wantOrder:     []string{"PublicAccessBlock", "Policy"},
+			wantDependsOn: map[string][]string{},
+		},
+		{
+			name: "lift ownership enforcement before applying ACLs",
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Spec.OwnershipControls = ownershipControls(svcapitypes.ObjectOwnership_ObjectWriter)
+				ko.Spec.ACL = strPtr("public-read")
+			},
+			delta:         deltaAt("Spec.ACL", "Spec.OwnershipControls"),
+			wantOrder:     []string{"OwnershipControls", "ACL"},
+			wantDependsOn: map[string][]string{"ACL": {"OwnershipControls"}},
+		},
+		{
+			name: "reset ACLs before enforcing ownership",
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Spec.OwnershipControls = ownershipControls(svcapitypes.ObjectOwnership_BucketOwnerEnforced)
+				ko.Spec.ACL = strPtr("private")
+			},
+			delta:         deltaAt("Spec.GrantRead", "Spec.OwnershipControls"),
+			wantOrder:     []string{"ACL", "OwnershipControls"},
+			wantDependsOn: map[string][]string{"OwnershipControls": {"ACL"}},
+		},
+		{
+			name: "requirements outside of the delta are ignored",
+			desired: func(ko *svcapitypes.Bucket) {
+				ko.Spec.OwnershipControls = ownershipControls(svcapitypes.ObjectOwnership_ObjectWriter)
+				ko.Spec.Policy = strPtr(publicReadPolicy)
+			},
+			delta:         deltaAt("Spec.ACL", "Spec.Policy"),
+			wantOrder:     []string{"ACL", "Policy"},
+			wantDependsOn: map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			desired := newBucketResource("my-bucket")
+			tt.desired(desired.ko)
+			latest := newBucketResource("my-bucket")
+
+			plan, err := planBucketSync(bucketSyncSteps, desired, latest, tt.delta, false)
+			require.NoError(err)
+			order, dependsOn := planProperties(plan)
+			assert.Equal(tt.wantOrder, order)
+			assert.Equal(tt.wantDependsOn, dependsOn)
+		})
+	}
+}
+
+// Test_planBucketSync_DirectoryBucket verifies that properties unsupported by
+// directory buckets are left out of their plans.
+func Test_planBucketSync_DirectoryBucket(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	desired := newBucketResource("my-bucket--usw2-az1--x-s3")
+	latest := newBucketResource("my-bucket--usw2-az1--x-s3")
+	delta := deltaAt("Spec.Encryption", "Spec.Logging", "Spec.Policy", "Spec.Versioning")
+
+	plan, err := planBucketSync(bucketSyncSteps, desired, latest, delta, true)
+	require.NoError(err)
+	order, _ := planProperties(plan)
+	assert.Equal([]string{"Encryption", "Policy"}, order)
+}
+
+// Test_planBucketSync_DestinationPolicies verifies that the destination
+// grants recorded for a bucket are synced, to be revoked, once its
+// destination policies are no longer managed.
+func Test_planBucketSync_DestinationPolicies(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	desired := newBucketResource("source")
+	desired.ko.Spec.Logging = &svcapitypes.BucketLoggingStatus{
+		LoggingEnabled: &svcapitypes.LoggingEnabled{
+			TargetBucket:    strPtr("logs"),
+			TargetBucketRef: newReference("logs"),
+		},
+	}
+	latest := newBucketResource("source")
+	latest.ko.Spec.Logging = desired.ko.Spec.Logging.DeepCopy()
+
+	delta := newResourceDelta(desired, latest)
+	assert.False(delta.DifferentAt("Spec"))
+
+	latest.ko.Status.DestinationGrants = []*string{strPtr("logs")}
+	delta = newResourceDelta(desired, latest)
+	require.True(delta.DifferentAt(destinationGrantsPath))
+
+	plan, err := planBucketSync(bucketSyncSteps, desired, latest, delta, false)
+	require.NoError(err)
+	order, _ := planProperties(plan)
+	assert.Equal([]string{"DestinationPolicies"}, order)
+
+	// Once revoked, nothing is left to sync
+	latest.ko.Status.DestinationGrants = nil
+	plan, err = planBucketSync(bucketSyncSteps, desired, latest, delta, false)
+	require.NoError(err)
+	assert.Empty(plan)
+}
+
+// Test_planBucketSync_Cycle verifies that circular requirements are reported
+// rather than resolved arbitrarily.
+func Test_planBucketSync_Cycle(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	steps := []*syncStep{
+		{
+			property: "A",
+			fields:   []string{"Spec.A"},
+			requires: func(_, _ *resource) []string { return []string{"B"} },
+		},
+		{
+			property: "B",
+			fields:   []string{"Spec.B"},
+			requires: func(_, _ *resource) []string { return []string{"A"} },
+		},
+		{
+			property: "C",
+			fields:   []string{"Spec.C"},
+		},
+	}
+	r := newBucketResource("my-bucket")
+	_, err := planBucketSync(steps, r, r, deltaAt("Spec.A", "Spec.B", "Spec.C"), false)
+	require.Error(err)
+	assert.Contains(err.Error(), "A, B")
+}
+
+// Test_syncStep_Preconditions verifies that replication and object lock are
+// refused while versioning is not Enabled.
+func Test_syncStep_Preconditions(t *testing.T) {
+	assert := assert.New(t)
+
+	steps := map[string]*syncStep{}
+	for _, step := range bucketSyncSteps {
+		steps[step.property] = step
+	}
+
+	desired := newBucketResource("my-bucket")
+	desired.ko.Spec.Replication = replication()
+	desired.ko.Spec.ObjectLockEnabledForBucket = boolPtr(true)
+	latest := newBucketResource("my-bucket")
+
+	assert.ErrorContains(steps["Replication"].precondition(desired, latest), "versioning")
+	assert.ErrorContains(steps["ObjectLockConfiguration"].precondition(desired, latest), "versioning")
+
+	// Versioning already enabled on the bucket satisfies the precondition
+	// when the desired state leaves it unset.
+	latest.ko.Spec.Versioning = versioning("Enabled")
+	assert.NoError(steps["Replication"].precondition(desired, latest))
+	assert.NoError(steps["ObjectLockConfiguration"].precondition(desired, latest))
+
+	desired.ko.Spec.Versioning = versioning("Suspended")
+	assert.Error(steps["Replication"].precondition(desired, latest))
+}
@@ -0,0 +1,501 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
+)
+
+const (
+	// maxBucketNameLength is the maximum length of the name of any kind of
+	// bucket.
+	maxBucketNameLength = 63
+	// maxLifecycleRules is the maximum number of rules of a lifecycle
+	// configuration.
+	maxLifecycleRules = 1000
+	// maxReplicationRules is the maximum number of rules of a replication
+	// configuration.
+	maxReplicationRules = 1000
+	// maxCORSRules is the maximum number of rules of a CORS configuration.
+	maxCORSRules = 100
+	// maxRuleIDLength is the maximum length of the ID of a lifecycle,
+	// replication or CORS rule.
+	maxRuleIDLength = 255
+)
+
+var (
+	// generalPurposeBucketNameRegex matches the characters allowed in the
+	// name of a general purpose bucket, which must begin and end with a
+	// letter or a number.
+	generalPurposeBucketNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*[a-z0-9]$`)
+	// directoryBucketNameRegex matches the bucket-base-name--zone-id--x-s3
+	// format of directory bucket names, where the zone ID is the ID of an
+	// Availability Zone (usw2-az1) or of a Local Zone (usw2-lax1-az1).
+	directoryBucketNameRegex = regexp.MustCompile(`^([a-z0-9][a-z0-9-]*[a-z0-9])--([a-z0-9]+(-[a-z0-9]+)*-az[0-9]+)--x-s3$`)
+	// accountRegionalBucketNameRegex matches the
+	// bucket-base-name-account-id-region-an format of the names of buckets in
+	// the account regional namespace.
+	accountRegionalBucketNameRegex = regexp.MustCompile(`^(.+)-([0-9]{12})-([a-z]{2}(-[a-z]+)+-[0-9]+)-an$`)
+
+	// reservedBucketNamePrefixes and reservedBucketNameSuffixes are reserved
+	// by S3 for other kinds of buckets and access point aliases.
+	reservedBucketNamePrefixes = []string{"xn--", "sthree-", "amzn-s3-demo-"}
+	reservedBucketNameSuffixes = []string{"-s3alias", "--ol-s3", ".mrap", "--x-s3", "--table-s3"}
+
+	ruleStatuses = []string{"Enabled", "Disabled"}
+	corsMethods  = []string{"GET", "PUT", "HEAD", "POST", "DELETE"}
+)
+
+// validateBucketSpec checks the spec of the given bucket against the rules S3
+// enforces on bucket names and configurations, so that mistakes are reported
+// when the bucket is admitted rather than as MalformedXML or InvalidArgument
+// errors once the controller calls S3.
+func validateBucketSpec(ko *svcapitypes.Bucket) field.ErrorList {
+	return validateBucketSpecFields(ko, func(string) bool { return true })
+}
+
+// validateBucketSpecUpdate checks the spec of an updated bucket like
+// validateBucketSpec, but only the parts of it read from fields the update
+// changed, so that buckets admitted before a validation rule was introduced
+// can still be updated. The name is immutable, so it is only checked when the
+// bucket is created.
+func validateBucketSpecUpdate(old, ko *svcapitypes.Bucket) field.ErrorList {
+	changed := changedSpecFields(old, ko)
+	return validateBucketSpecFields(ko, func(name string) bool {
+		return name != "name" && changed[name]
+	})
+}
+
+// validateBucketSpecFields checks the parts of the spec of the given bucket
+// read from the top-level spec fields, identified by their JSON name, for
+// which check returns true.
+func validateBucketSpecFields(ko *svcapitypes.Bucket, check func(name string) bool) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+	if ko.Spec.Name != nil {
+		if check("name") {
+			allErrs = append(allErrs, validateBucketName(*ko.Spec.Name, specPath.Child("name"))...)
+		}
+		if IsDirectoryBucketName(*ko.Spec.Name) {
+			for _, unsupported := range unsupportedDirectoryBucketFields(ko) {
+				if !check(unsupported.path) {
+					continue
+				}
+				allErrs = append(allErrs, field.Forbidden(
+					specPath.Child(unsupported.path),
+					"directory buckets do not support this field",
+				))
+			}
+		}
+	}
+	if ko.Spec.Lifecycle != nil && check("lifecycle") {
+		allErrs = append(allErrs, validateLifecycle(ko.Spec.Lifecycle, specPath.Child("lifecycle"))...)
+	}
+	if ko.Spec.Replication != nil && (check("replication") || check("versioning")) {
+		allErrs = append(allErrs, validateReplication(ko.Spec.Replication, ko.Spec.Versioning, specPath)...)
+	}
+	if ko.Spec.CORS != nil && check("cors") {
+		allErrs = append(allErrs, validateCORS(ko.Spec.CORS, specPath.Child("cors"))...)
+	}
+	if check("policy") || check("policyDocument") {
+		allErrs = append(allErrs, validatePolicy(ko, specPath)...)
+	}
+	return allErrs
+}
+
+// changedSpecFields returns the JSON names of the top-level spec fields whose
+// values differ between old and ko.
+func changedSpecFields(old, ko *svcapitypes.Bucket) map[string]bool {
+	changed := map[string]bool{}
+	oldSpec, spec := reflect.ValueOf(old.Spec), reflect.ValueOf(ko.Spec)
+	for i := 0; i < spec.NumField(); i++ {
+		if apiequality.Semantic.DeepEqual(oldSpec.Field(i).Interface(), spec.Field(i).Interface()) {
+			continue
+		}
+		name, _, _ := strings.Cut(spec.Type().Field(i).Tag.Get("json"), ",")
+		changed[name] = true
+	}
+	return changed
+}
+
+// validateBucketName checks the name of a general purpose, directory or
+// account regional namespace bucket.
+func validateBucketName(name string, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(name) < 3 || len(name) > maxBucketNameLength {
+		allErrs = append(allErrs, field.Invalid(path, name,
+			fmt.Sprintf("bucket names must be between 3 and %d characters long", maxBucketNameLength)))
+	}
+
+	if IsDirectoryBucketName(name) {
+		if !directoryBucketNameRegex.MatchString(name) {
+			allErrs = append(allErrs, field.Invalid(path, name,
+				"directory bucket names must follow the bucket-base-name--zone-id--x-s3 format "+
+					"and only contain lowercase letters, numbers and hyphens"))
+		}
+		return allErrs
+	}
+
+	if !generalPurposeBucketNameRegex.MatchString(name) {
+		allErrs = append(allErrs, field.Invalid(path, name,
+			"bucket names must only contain lowercase letters, numbers, dots and hyphens, "+
+				"and begin and end with a letter or a number"))
+	}
+	if strings.Contains(name, "..") {
+		allErrs = append(allErrs, field.Invalid(path, name,
+			"bucket names must not contain two adjacent periods"))
+	}
+	if net.ParseIP(name) != nil {
+		allErrs = append(allErrs, field.Invalid(path, name,
+			"bucket names must not be formatted as an IP address"))
+	}
+	for _, prefix := range reservedBucketNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			allErrs = append(allErrs, field.Invalid(path, name,
+				fmt.Sprintf("bucket names must not start with the reserved prefix %q", prefix)))
+		}
+	}
+	for _, suffix := range reservedBucketNameSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			allErrs = append(allErrs, field.Invalid(path, name,
+				fmt.Sprintf("bucket names must not end with the reserved suffix %q", suffix)))
+		}
+	}
+	if IsAccountRegionalBucketName(name) && !accountRegionalBucketNameRegex.MatchString(name) {
+		allErrs = append(allErrs, field.Invalid(path, name,
+			"account regional namespace bucket names must follow the "+
+				"bucket-base-name-account-id-region-an format"))
+	}
+	return allErrs
+}
+
+// validateLifecycle checks the number, IDs, statuses, actions and filters of
+// the rules of a lifecycle configuration.
+func validateLifecycle(config *svcapitypes.BucketLifecycleConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	rulesPath := path.Child("rules")
+	if len(config.Rules) > maxLifecycleRules {
+		allErrs = append(allErrs, field.TooMany(rulesPath, len(config.Rules), maxLifecycleRules))
+	}
+	ids := map[string]bool{}
+	for i, rule := range config.Rules {
+		if rule == nil {
+			continue
+		}
+		rulePath := rulesPath.Index(i)
+		allErrs = append(allErrs, validateRuleID(rule.ID, ids, rulePath.Child("id"))...)
+		allErrs = append(allErrs, validateRuleStatus(rule.Status, rulePath.Child("status"))...)
+
+		if rule.Expiration == nil && len(rule.Transitions) == 0 &&
+			rule.NoncurrentVersionExpiration == nil && len(rule.NoncurrentVersionTransitions) == 0 &&
+			rule.AbortIncompleteMultipartUpload == nil {
+			allErrs = append(allErrs, field.Required(rulePath,
+				"lifecycle rules must specify at least one action"))
+		}
+		if rule.Expiration != nil {
+			allErrs = append(allErrs, validateLifecycleExpiration(rule.Expiration, rulePath.Child("expiration"))...)
+		}
+
+		if rule.Prefix != nil && rule.Filter != nil {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("prefix"),
+				"prefix may not be set along with filter, set filter.prefix instead"))
+		}
+		if rule.Filter == nil {
+			continue
+		}
+		filterPath := rulePath.Child("filter")
+		allErrs = append(allErrs, validateLifecycleFilter(rule.Filter, filterPath)...)
+		if rule.AbortIncompleteMultipartUpload != nil && lifecycleFilterHasTags(rule.Filter) {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("abortIncompleteMultipartUpload"),
+				"abortIncompleteMultipartUpload may not be used in rules filtering on tags"))
+		}
+	}
+	return allErrs
+}
+
+// validateLifecycleExpiration checks that an expiration sets exactly one of
+// date, days and expiredObjectDeleteMarker.
+func validateLifecycleExpiration(expiration *svcapitypes.LifecycleExpiration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	set := 0
+	if expiration.Date != nil {
+		set++
+	}
+	if expiration.Days != nil {
+		set++
+		if *expiration.Days <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("days"), *expiration.Days,
+				"must be a positive number of days"))
+		}
+	}
+	if expiration.ExpiredObjectDeleteMarker != nil {
+		set++
+	}
+	if set != 1 {
+		allErrs = append(allErrs, field.Invalid(path, set,
+			"exactly one of date, days and expiredObjectDeleteMarker must be set"))
+	}
+	return allErrs
+}
+
+// validateLifecycleFilter checks that a lifecycle rule filter sets at most one
+// of its predicates, and that an and operator combines at least two of them.
+func validateLifecycleFilter(filter *svcapitypes.LifecycleRuleFilter, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	set := 0
+	for _, isSet := range []bool{
+		filter.Prefix != nil,
+		filter.Tag != nil,
+		filter.ObjectSizeGreaterThan != nil,
+		filter.ObjectSizeLessThan != nil,
+		filter.And != nil,
+	} {
+		if isSet {
+			set++
+		}
+	}
+	if set > 1 {
+		allErrs = append(allErrs, field.Invalid(path, set,
+			"at most one of prefix, tag, objectSizeGreaterThan, objectSizeLessThan and and "+
+				"may be set, use and to combine several predicates"))
+	}
+	if filter.And == nil {
+		return allErrs
+	}
+
+	and := filter.And
+	andPath := path.Child("and")
+	predicates := len(and.Tags)
+	if and.Prefix != nil {
+		predicates++
+	}
+	if and.ObjectSizeGreaterThan != nil {
+		predicates++
+	}
+	if and.ObjectSizeLessThan != nil {
+		predicates++
+	}
+	if predicates < 2 {
+		allErrs = append(allErrs, field.Invalid(andPath, predicates,
+			"and must combine at least two predicates"))
+	}
+	if and.ObjectSizeGreaterThan != nil && and.ObjectSizeLessThan != nil &&
+		*and.ObjectSizeGreaterThan >= *and.ObjectSizeLessThan {
+		allErrs = append(allErrs, field.Invalid(andPath.Child("objectSizeLessThan"), *and.ObjectSizeLessThan,
+			"must be greater than objectSizeGreaterThan"))
+	}
+	return allErrs
+}
+
+// lifecycleFilterHasTags returns whether the lifecycle rule filter selects
+// objects by tag.
+func lifecycleFilterHasTags(filter *svcapitypes.LifecycleRuleFilter) bool {
+	return filter.Tag != nil || (filter.And != nil && len(filter.And.Tags) > 0)
+}
+
+// validateReplication checks that a replication configuration has a role and
+// rules, that versioning is enabled on the bucket, and that every rule has a
+// destination and a filter S3 accepts.
+func validateReplication(
+	config *svcapitypes.ReplicationConfiguration,
+	versioning *svcapitypes.VersioningConfiguration,
+	specPath *field.Path,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+	path := specPath.Child("replication")
+	if config.Role == nil && config.RoleRef == nil {
+		allErrs = append(allErrs, field.Required(path.Child("role"),
+			"one of role and roleRef must be set"))
+	}
+	if versioning == nil || versioning.Status == nil || *versioning.Status != string(svcsdktypes.BucketVersioningStatusEnabled) {
+		allErrs = append(allErrs, field.Required(specPath.Child("versioning", "status"),
+			"versioning must be Enabled on buckets with a replication configuration"))
+	}
+
+	rulesPath := path.Child("rules")
+	if len(config.Rules) == 0 {
+		allErrs = append(allErrs, field.Required(rulesPath,
+			"replication configurations must have at least one rule"))
+	}
+	if len(config.Rules) > maxReplicationRules {
+		allErrs = append(allErrs, field.TooMany(rulesPath, len(config.Rules), maxReplicationRules))
+	}
+	ids := map[string]bool{}
+	priorities := map[int64]bool{}
+	for i, rule := range config.Rules {
+		if rule == nil {
+			continue
+		}
+		rulePath := rulesPath.Index(i)
+		allErrs = append(allErrs, validateRuleID(rule.ID, ids, rulePath.Child("id"))...)
+		allErrs = append(allErrs, validateRuleStatus(rule.Status, rulePath.Child("status"))...)
+
+		if rule.Destination == nil {
+			allErrs = append(allErrs, field.Required(rulePath.Child("destination"), ""))
+		} else if rule.Destination.Bucket == nil && rule.Destination.BucketRef == nil {
+			allErrs = append(allErrs, field.Required(rulePath.Child("destination", "bucket"),
+				"one of bucket and bucketRef must be set"))
+		}
+
+		if rule.Priority != nil {
+			if priorities[*rule.Priority] {
+				allErrs = append(allErrs, field.Duplicate(rulePath.Child("priority"), *rule.Priority))
+			}
+			priorities[*rule.Priority] = true
+		}
+
+		if rule.Prefix != nil && rule.Filter != nil {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("prefix"),
+				"prefix may not be set along with filter, set filter.prefix instead"))
+		}
+		if rule.Filter == nil {
+			continue
+		}
+		filterPath := rulePath.Child("filter")
+		set := 0
+		for _, isSet := range []bool{rule.Filter.Prefix != nil, rule.Filter.Tag != nil, rule.Filter.And != nil} {
+			if isSet {
+				set++
+			}
+		}
+		if set > 1 {
+			allErrs = append(allErrs, field.Invalid(filterPath, set,
+				"at most one of prefix, tag and and may be set, use and to combine several predicates"))
+		}
+		if rule.DeleteMarkerReplication == nil {
+			allErrs = append(allErrs, field.Required(rulePath.Child("deleteMarkerReplication"),
+				"deleteMarkerReplication must be set in rules with a filter"))
+		} else if replicationFilterHasTags(rule.Filter) &&
+			(rule.DeleteMarkerReplication.Status == nil || *rule.DeleteMarkerReplication.Status != "Disabled") {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("deleteMarkerReplication", "status"),
+				rule.DeleteMarkerReplication.Status,
+				"delete markers are not replicated for rules filtering on tags, status must be Disabled"))
+		}
+	}
+	return allErrs
+}
+
+// replicationFilterHasTags returns whether the replication rule filter selects
+// objects by tag.
+func replicationFilterHasTags(filter *svcapitypes.ReplicationRuleFilter) bool {
+	return filter.Tag != nil || (filter.And != nil && len(filter.And.Tags) > 0)
+}
+
+// validateCORS checks the number, IDs, methods, origins and headers of the
+// rules of a CORS configuration.
+func validateCORS(config *svcapitypes.CORSConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	rulesPath := path.Child("corsRules")
+	if len(config.CORSRules) > maxCORSRules {
+		allErrs = append(allErrs, field.TooMany(rulesPath, len(config.CORSRules), maxCORSRules))
+	}
+	ids := map[string]bool{}
+	for i, rule := range config.CORSRules {
+		if rule == nil {
+			continue
+		}
+		rulePath := rulesPath.Index(i)
+		allErrs = append(allErrs, validateRuleID(rule.ID, ids, rulePath.Child("id"))...)
+
+		if len(rule.AllowedMethods) == 0 {
+			allErrs = append(allErrs, field.Required(rulePath.Child("allowedMethods"), ""))
+		}
+		for j, method := range rule.AllowedMethods {
+			if method == nil || !slices.Contains(corsMethods, *method) {
+				allErrs = append(allErrs, field.NotSupported(rulePath.Child("allowedMethods").Index(j), method, corsMethods))
+			}
+		}
+		if len(rule.AllowedOrigins) == 0 {
+			allErrs = append(allErrs, field.Required(rulePath.Child("allowedOrigins"), ""))
+		}
+		allErrs = append(allErrs, validateCORSWildcards(rule.AllowedOrigins, rulePath.Child("allowedOrigins"))...)
+		allErrs = append(allErrs, validateCORSWildcards(rule.AllowedHeaders, rulePath.Child("allowedHeaders"))...)
+		if rule.MaxAgeSeconds != nil && *rule.MaxAgeSeconds < 0 {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("maxAgeSeconds"), *rule.MaxAgeSeconds,
+				"must not be negative"))
+		}
+	}
+	return allErrs
+}
+
+// validateCORSWildcards checks that CORS origins or headers contain at most
+// one "*" wildcard each.
+func validateCORSWildcards(values []*string, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, value := range values {
+		if value != nil && strings.Count(*value, "*") > 1 {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), *value,
+				"may contain at most one * wildcard"))
+		}
+	}
+	return allErrs
+}
+
+// validatePolicy checks that the bucket policy is set through at most one of
+// policy and policyDocument, and that policy is a valid policy document.
+func validatePolicy(ko *svcapitypes.Bucket, specPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ko.Spec.Policy != nil && ko.Spec.PolicyDocument != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("policyDocument"),
+			"only one of policy and policyDocument may be set"))
+	}
+	if ko.Spec.Policy != nil && *ko.Spec.Policy != "" {
+		if _, err := iampolicy.Parse(*ko.Spec.Policy); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("policy"), "",
+				fmt.Sprintf("not a valid policy document: %s", err)))
+		}
+	}
+	return allErrs
+}
+
+// validateRuleID checks that the ID of a rule is not too long and was not
+// seen in another rule of the same configuration.
+func validateRuleID(id *string, seen map[string]bool, path *field.Path) field.ErrorList {
+	if id == nil {
+		return nil
+	}
+	allErrs := field.ErrorList{}
+	if len(*id) > maxRuleIDLength {
+		allErrs = append(allErrs, field.TooLong(path, *id, maxRuleIDLength))
+	}
+	if seen[*id] {
+		allErrs = append(allErrs, field.Duplicate(path, *id))
+	}
+	seen[*id] = true
+	return allErrs
+}
+
+// validateRuleStatus checks that the status of a rule is set to Enabled or
+// Disabled.
+func validateRuleStatus(status *string, path *field.Path) field.ErrorList {
+	if status == nil {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	if !slices.Contains(ruleStatuses, *status) {
+		return field.ErrorList{field.NotSupported(path, *status, ruleStatuses)}
+	}
+	return nil
+}
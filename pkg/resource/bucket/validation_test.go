@@ -0,0 +1,263 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// errorFields returns the paths of the fields reported by the given errors.
+func errorFields(errs field.ErrorList) []string {
+	fields := []string{}
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	return fields
+}
+
+func Test_validateBucketName(t *testing.T) {
+	tests := []struct {
+		name    string
+		invalid bool
+	}{
+		{"my-bucket", false},
+		{"my.bucket.1", false},
+		{"ab", true},
+		{"My-Bucket", true},
+		{"-my-bucket", true},
+		{"my..bucket", true},
+		{"192.168.5.4", true},
+		{"xn--my-bucket", true},
+		{"my-bucket-s3alias", true},
+		{"my-bucket--usw2-az1--x-s3", false},
+		{"my-bucket--usw2-lax1-az1--x-s3", false},
+		{"my.bucket--usw2-az1--x-s3", true},
+		{"my-bucket--x-s3", true},
+		{"my-bucket-111122223333-us-west-2-an", false},
+		{"my-bucket-us-west-2-an", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateBucketName(tt.name, field.NewPath("spec", "name"))
+			assert.Equal(t, tt.invalid, len(errs) > 0, errs)
+		})
+	}
+}
+
+func Test_validateBucketSpec_DirectoryBucket(t *testing.T) {
+	ko := newBucketResource("my-bucket--usw2-az1--x-s3").ko
+	ko.Spec.Versioning = &svcapitypes.VersioningConfiguration{Status: aws.String("Enabled")}
+	ko.Spec.CORS = &svcapitypes.CORSConfiguration{}
+
+	assert.Equal(t, []string{"spec.cors", "spec.versioning"}, errorFields(validateBucketSpec(ko)))
+}
+
+func Test_validateBucketSpec_Lifecycle(t *testing.T) {
+	ko := newBucketResource("my-bucket").ko
+	ko.Spec.Lifecycle = &svcapitypes.BucketLifecycleConfiguration{
+		Rules: []*svcapitypes.LifecycleRule{
+			{
+				ID:         aws.String("expire"),
+				Status:     aws.String("Enabled"),
+				Expiration: &svcapitypes.LifecycleExpiration{Days: aws.Int64(30)},
+				Filter: &svcapitypes.LifecycleRuleFilter{
+					And: &svcapitypes.LifecycleRuleAndOperator{
+						Prefix:                aws.String("logs/"),
+						ObjectSizeGreaterThan: aws.Int64(1024),
+					},
+				},
+			},
+			{
+				// Duplicate ID, no action and two predicates outside of And
+				ID:     aws.String("expire"),
+				Status: aws.String("Enabled"),
+				Filter: &svcapitypes.LifecycleRuleFilter{
+					Prefix: aws.String("logs/"),
+					Tag:    &svcapitypes.Tag{Key: aws.String("k"), Value: aws.String("v")},
+				},
+			},
+			{
+				Status: aws.String("enabled"),
+				Expiration: &svcapitypes.LifecycleExpiration{
+					Days:                      aws.Int64(30),
+					ExpiredObjectDeleteMarker: aws.Bool(true),
+				},
+				AbortIncompleteMultipartUpload: &svcapitypes.AbortIncompleteMultipartUpload{DaysAfterInitiation: aws.Int64(7)},
+				Prefix:                         aws.String("tmp/"),
+				Filter: &svcapitypes.LifecycleRuleFilter{
+					And: &svcapitypes.LifecycleRuleAndOperator{
+						Tags: []*svcapitypes.Tag{{Key: aws.String("k"), Value: aws.String("v")}},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"spec.lifecycle.rules[1].id",
+		"spec.lifecycle.rules[1]",
+		"spec.lifecycle.rules[1].filter",
+		"spec.lifecycle.rules[2].status",
+		"spec.lifecycle.rules[2].expiration",
+		"spec.lifecycle.rules[2].prefix",
+		"spec.lifecycle.rules[2].filter.and",
+		"spec.lifecycle.rules[2].abortIncompleteMultipartUpload",
+	}, errorFields(validateBucketSpec(ko)))
+}
+
+func Test_validateBucketSpec_Replication(t *testing.T) {
+	ko := newBucketResource("my-bucket").ko
+	ko.Spec.Replication = &svcapitypes.ReplicationConfiguration{
+		Rules: []*svcapitypes.ReplicationRule{
+			{
+				Status:      aws.String("Enabled"),
+				Priority:    aws.Int64(1),
+				Destination: &svcapitypes.Destination{BucketRef: newReference("replica")},
+				Filter: &svcapitypes.ReplicationRuleFilter{
+					Tag: &svcapitypes.Tag{Key: aws.String("k"), Value: aws.String("v")},
+				},
+				DeleteMarkerReplication: &svcapitypes.DeleteMarkerReplication{Status: aws.String("Enabled")},
+			},
+			{
+				Status:      aws.String("Enabled"),
+				Priority:    aws.Int64(1),
+				Destination: &svcapitypes.Destination{},
+				Filter:      &svcapitypes.ReplicationRuleFilter{Prefix: aws.String("logs/")},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"spec.replication.role",
+		"spec.versioning.status",
+		"spec.replication.rules[0].deleteMarkerReplication.status",
+		"spec.replication.rules[1].destination.bucket",
+		"spec.replication.rules[1].priority",
+		"spec.replication.rules[1].deleteMarkerReplication",
+	}, errorFields(validateBucketSpec(ko)))
+
+	ko.Spec.Replication.RoleRef = newReference("replication")
+	ko.Spec.Versioning = &svcapitypes.VersioningConfiguration{Status: aws.String("Enabled")}
+	ko.Spec.Replication.Rules[0].DeleteMarkerReplication.Status = aws.String("Disabled")
+	ko.Spec.Replication.Rules[1] = ko.Spec.Replication.Rules[0].DeepCopy()
+	ko.Spec.Replication.Rules[1].Priority = aws.Int64(2)
+	assert.Empty(t, validateBucketSpec(ko))
+}
+
+func Test_validateBucketSpec_CORS(t *testing.T) {
+	ko := newBucketResource("my-bucket").ko
+	ko.Spec.CORS = &svcapitypes.CORSConfiguration{
+		CORSRules: []*svcapitypes.CORSRule{
+			{
+				AllowedMethods: []*string{aws.String("GET"), aws.String("PATCH")},
+				AllowedOrigins: []*string{aws.String("https://*.example.*")},
+				AllowedHeaders: []*string{aws.String("*")},
+				MaxAgeSeconds:  aws.Int64(-1),
+			},
+			{
+				AllowedOrigins: []*string{aws.String("*")},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"spec.cors.corsRules[0].allowedMethods[1]",
+		"spec.cors.corsRules[0].allowedOrigins[0]",
+		"spec.cors.corsRules[0].maxAgeSeconds",
+		"spec.cors.corsRules[1].allowedMethods",
+	}, errorFields(validateBucketSpec(ko)))
+}
+
+func Test_validateBucketSpec_Policy(t *testing.T) {
+	ko := newBucketResource("my-bucket").ko
+	ko.Spec.Policy = aws.String(`{"Version": "2012-10-17", "Statement": [`)
+	ko.Spec.PolicyDocument = &svcapitypes.BucketPolicyDocument{}
+
+	assert.Equal(t, []string{"spec.policyDocument", "spec.policy"}, errorFields(validateBucketSpec(ko)))
+
+	ko.Spec.PolicyDocument = nil
+	ko.Spec.Policy = aws.String(`{"Version": "2012-10-17", "Statement": []}`)
+	assert.Empty(t, validateBucketSpec(ko))
+}
+
+// Test_bucketValidator verifies that invalid Buckets are rejected on creation,
+// and that updates are only rejected for the fields they change.
+func Test_bucketValidator(t *testing.T) {
+	ctx := context.Background()
+	v := &bucketValidator{}
+
+	valid := newBucketResource("my-bucket").ko
+	_, err := v.ValidateCreate(ctx, valid)
+	require.NoError(t, err)
+
+	invalid := newBucketResource("My_Bucket").ko
+	_, err = v.ValidateCreate(ctx, invalid)
+	assert.True(t, apierrors.IsInvalid(err), err)
+
+	// The immutable name is not checked again, nor are fields the update
+	// leaves untouched
+	invalid.Spec.CORS = &svcapitypes.CORSConfiguration{CORSRules: []*svcapitypes.CORSRule{{}}}
+	updated := invalid.DeepCopy()
+	updated.Finalizers = []string{"finalizers.s3.services.k8s.aws/Bucket"}
+	_, err = v.ValidateUpdate(ctx, invalid, updated)
+	assert.NoError(t, err)
+
+	updated.Spec.Tagging = &svcapitypes.Tagging{}
+	_, err = v.ValidateUpdate(ctx, invalid, updated)
+	assert.NoError(t, err)
+
+	// Changed fields are
+	updated.Spec.CORS.CORSRules[0].AllowedOrigins = []*string{aws.String("*")}
+	_, err = v.ValidateUpdate(ctx, invalid, updated)
+	require.True(t, apierrors.IsInvalid(err), err)
+	assert.Equal(t,
+		[]string{"spec.cors.corsRules[0].allowedMethods"},
+		errorFields(validateBucketSpecUpdate(invalid, updated)),
+	)
+
+	// Updates of Buckets being deleted are admitted regardless
+	updated.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	_, err = v.ValidateUpdate(ctx, invalid, updated)
+	assert.NoError(t, err)
+}
+
+// Test_validateBucketSpecUpdate_Replication verifies that the replication
+// configuration is checked again when versioning changes.
+func Test_validateBucketSpecUpdate_Replication(t *testing.T) {
+	old := newBucketResource("my-bucket").ko
+	old.Spec.Replication = replication()
+	old.Spec.Replication.Rules[0].Destination = &svcapitypes.Destination{
+		Bucket: aws.String("arn:aws:s3:::replica"),
+	}
+	old.Spec.Versioning = versioning("Enabled")
+	require.Empty(t, validateBucketSpec(old))
+
+	ko := old.DeepCopy()
+	ko.Spec.Versioning = versioning("Suspended")
+	assert.Equal(t,
+		[]string{"spec.versioning.status"},
+		errorFields(validateBucketSpecUpdate(old, ko)),
+	)
+}
@@ -0,0 +1,104 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"fmt"
+
+	ackrtwebhook "github.com/aws-controllers-k8s/runtime/pkg/webhook"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// webhookTypeValidating is the type of the Bucket validating webhook. The
+// runtime only defines a constant for conversion webhooks.
+const webhookTypeValidating = "validating"
+
+func init() {
+	ackrtwebhook.RegisterWebhook(ackrtwebhook.New(
+		svcapitypes.GroupVersion.Version,
+		GroupKind.Kind,
+		webhookTypeValidating,
+		func(mgr ctrlrt.Manager) error {
+			return ctrlrt.NewWebhookManagedBy(mgr, &svcapitypes.Bucket{}).
+				WithValidator(&bucketValidator{}).
+				Complete()
+		},
+	))
+}
+
+// +kubebuilder:webhook:path=/validate-s3-services-k8s-aws-v1alpha1-bucket,mutating=false,failurePolicy=fail,sideEffects=None,groups=s3.services.k8s.aws,resources=buckets,verbs=create;update;delete,versions=v1alpha1,name=vbucket.s3.services.k8s.aws,admissionReviewVersions=v1
+
+// bucketValidator rejects Buckets whose spec S3 would refuse, see
+// validateBucketSpec, and the deletion of Buckets protected from deletion,
+// see isDeletionProtected.
+type bucketValidator struct{}
+
+var _ admission.Validator[*svcapitypes.Bucket] = &bucketValidator{}
+
+// ValidateCreate validates the spec of a new Bucket.
+func (v *bucketValidator) ValidateCreate(
+	ctx context.Context,
+	ko *svcapitypes.Bucket,
+) (admission.Warnings, error) {
+	return nil, invalidBucket(ko, validateBucketSpec(ko))
+}
+
+// ValidateUpdate validates the parts of the spec of an updated Bucket that
+// the update changed, see validateBucketSpecUpdate. Updates of Buckets being
+// deleted are always admitted, so that Buckets created before a validation
+// rule was introduced can still be deleted.
+func (v *bucketValidator) ValidateUpdate(
+	ctx context.Context,
+	old *svcapitypes.Bucket,
+	ko *svcapitypes.Bucket,
+) (admission.Warnings, error) {
+	if ko.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	return nil, invalidBucket(ko, validateBucketSpecUpdate(old, ko))
+}
+
+// ValidateDelete rejects the deletion of a Bucket protected from deletion.
+func (v *bucketValidator) ValidateDelete(
+	ctx context.Context,
+	ko *svcapitypes.Bucket,
+) (admission.Warnings, error) {
+	if !isDeletionProtected(&resource{ko}) {
+		return nil, nil
+	}
+	return nil, apierrors.NewForbidden(
+		svcapitypes.GroupVersion.WithResource("buckets").GroupResource(), ko.Name,
+		fmt.Errorf(
+			"the bucket is protected from deletion, set the %s annotation to \"false\" to delete it",
+			svcapitypes.AnnotationDeletionProtection,
+		),
+	)
+}
+
+// invalidBucket returns an Invalid error listing the given problems found in
+// the spec of the given Bucket, or nil if there are none.
+func invalidBucket(ko *svcapitypes.Bucket, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		svcapitypes.GroupVersion.WithKind(GroupKind.Kind).GroupKind(), ko.Name, allErrs,
+	)
+}
@@ -0,0 +1,389 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package multi_region_access_point
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3control"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3control/types"
+	smithy "github.com/aws/smithy-go"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/s3-controller/pkg/iampolicy"
+)
+
+// controlPlaneRegion is the Region every request about Multi-Region Access
+// Points must be sent to, whatever the Region of the controller.
+const controlPlaneRegion = "us-west-2"
+
+const (
+	operationStatusSucceeded = "SUCCEEDED"
+	operationStatusFailed    = "FAILED"
+)
+
+var requeueWaitWhileDeleting = ackrequeue.NeededAfter(
+	errors.New("Multi-Region Access Point is being deleted"),
+	30*time.Second,
+)
+
+// withControlPlaneRegion sends the requests of an S3 Control client to the
+// Multi-Region Access Point control plane.
+func withControlPlaneRegion(o *svcsdk.Options) {
+	o.Region = controlPlaneRegion
+}
+
+// clientToken returns the idempotency token of the creation of the
+// Multi-Region Access Point. It changes with the generation of the resource,
+// so that a creation which failed is retried once the spec is fixed.
+func clientToken(ko *svcapitypes.MultiRegionAccessPoint) string {
+	return fmt.Sprintf("%s-%d", ko.UID, ko.Generation)
+}
+
+// awaitOperation checks the asynchronous operation started on the
+// Multi-Region Access Point by the last create or update.
+//
+// While the operation runs, the Multi-Region Access Point may not exist yet
+// or not reflect the update, so a copy of the supplied resource is returned
+// as its latest state. Once the operation failed, a copy of the resource
+// without the request token is returned along with a terminal error. Once it
+// succeeded, nothing is returned and the Multi-Region Access Point can be
+// read.
+func (rm *resourceManager) awaitOperation(
+	ctx context.Context,
+	r *resource,
+) (*resource, error) {
+	resp, err := rm.sdkapi.DescribeMultiRegionAccessPointOperation(
+		ctx,
+		&svcsdk.DescribeMultiRegionAccessPointOperationInput{
+			AccountId:       aws.String(string(rm.awsAccountID)),
+			RequestTokenARN: r.ko.Status.RequestTokenARN,
+		},
+	)
+	rm.metrics.RecordAPICall("READ_ONE", "DescribeMultiRegionAccessPointOperation", err)
+	if err != nil {
+		return nil, err
+	}
+	op := resp.AsyncOperation
+	if op == nil {
+		return nil, nil
+	}
+
+	switch aws.ToString(op.RequestStatus) {
+	case operationStatusSucceeded:
+		return nil, nil
+	case operationStatusFailed:
+		ko := r.ko.DeepCopy()
+		ko.Status.RequestTokenARN = nil
+		return &resource{ko}, newOperationError(op)
+	default:
+		return &resource{r.ko.DeepCopy()}, nil
+	}
+}
+
+// newOperationError returns the terminal error an asynchronous operation
+// failed with.
+func newOperationError(op *svcsdktypes.AsyncOperation) error {
+	msg := fmt.Sprintf("%s operation failed", op.Operation)
+	if op.ResponseDetails != nil && op.ResponseDetails.ErrorDetails != nil {
+		details := op.ResponseDetails.ErrorDetails
+		msg = fmt.Sprintf(
+			"%s: %s: %s", msg,
+			aws.ToString(details.Code), aws.ToString(details.Message),
+		)
+	}
+	return ackerr.NewTerminalError(errors.New(msg))
+}
+
+// newResourceRegions returns the regions of the spec of a Multi-Region Access
+// Point from the regions S3 reports, in the order of the desired regions and
+// with their bucket references and traffic dial percentages, which are read
+// separately by addRoutesToResource.
+func (rm *resourceManager) newResourceRegions(
+	desired []*svcapitypes.MultiRegionAccessPointRegion,
+	reports []svcsdktypes.RegionReport,
+) []*svcapitypes.MultiRegionAccessPointRegion {
+	if len(reports) == 0 {
+		return nil
+	}
+	byBucket := map[string]svcsdktypes.RegionReport{}
+	for _, report := range reports {
+		byBucket[aws.ToString(report.Bucket)] = report
+	}
+
+	res := []*svcapitypes.MultiRegionAccessPointRegion{}
+	for _, region := range desired {
+		if region == nil {
+			continue
+		}
+		report, ok := byBucket[aws.ToString(region.Bucket)]
+		if !ok {
+			continue
+		}
+		delete(byBucket, aws.ToString(region.Bucket))
+		res = append(res, rm.newResourceRegion(report, region))
+	}
+	for _, report := range reports {
+		if _, ok := byBucket[aws.ToString(report.Bucket)]; ok {
+			res = append(res, rm.newResourceRegion(report, nil))
+		}
+	}
+	return res
+}
+
+// newResourceRegion returns the region of the spec of a Multi-Region Access
+// Point for a region S3 reports and the matching desired region, if any.
+func (rm *resourceManager) newResourceRegion(
+	report svcsdktypes.RegionReport,
+	desired *svcapitypes.MultiRegionAccessPointRegion,
+) *svcapitypes.MultiRegionAccessPointRegion {
+	res := &svcapitypes.MultiRegionAccessPointRegion{
+		Bucket: report.Bucket,
+	}
+	if desired != nil {
+		res.BucketRef = desired.BucketRef
+		res.TrafficDialPercentage = desired.TrafficDialPercentage
+	}
+	// S3 returns the account of the bucket even when it is the account of
+	// the Multi-Region Access Point, which need not be set in the spec.
+	if report.BucketAccountId != nil &&
+		((desired != nil && desired.BucketAccountID != nil) ||
+			string(rm.awsAccountID) != *report.BucketAccountId) {
+		res.BucketAccountID = report.BucketAccountId
+	}
+	return res
+}
+
+// isNotFound returns true if the error is an API error with the given code.
+func isNotFound(err error, code string) bool {
+	var awsErr smithy.APIError
+	return errors.As(err, &awsErr) && awsErr.ErrorCode() == code
+}
+
+// addPolicyToSpec reads the established policy of the Multi-Region Access
+// Point into its spec.
+func (rm *resourceManager) addPolicyToSpec(
+	ctx context.Context,
+	ko *svcapitypes.MultiRegionAccessPoint,
+) error {
+	resp, err := rm.sdkapi.GetMultiRegionAccessPointPolicy(ctx, &svcsdk.GetMultiRegionAccessPointPolicyInput{
+		AccountId: aws.String(string(rm.awsAccountID)),
+		Name:      ko.Spec.Name,
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetMultiRegionAccessPointPolicy", err)
+	switch {
+	case isNotFound(err, "NoSuchMultiRegionAccessPointPolicy"):
+		ko.Spec.Policy = nil
+	case err != nil:
+		return err
+	case resp.Policy == nil || resp.Policy.Established == nil ||
+		aws.ToString(resp.Policy.Established.Policy) == "":
+		ko.Spec.Policy = nil
+	default:
+		ko.Spec.Policy = resp.Policy.Established.Policy
+	}
+	return nil
+}
+
+// addRoutesToResource reads the routes of a ready Multi-Region Access Point
+// into its status, and the traffic dial percentages of its regions which are
+// set in the spec into the spec.
+func (rm *resourceManager) addRoutesToResource(
+	ctx context.Context,
+	ko *svcapitypes.MultiRegionAccessPoint,
+) error {
+	// The routes of a Multi-Region Access Point being created or deleted
+	// cannot be read; the desired traffic dial percentages are kept so that
+	// they are synced once it is ready.
+	if aws.ToString(ko.Status.State) != string(svcsdktypes.MultiRegionAccessPointStatusReady) ||
+		ko.Status.ACKResourceMetadata == nil || ko.Status.ACKResourceMetadata.ARN == nil {
+		return nil
+	}
+	resp, err := rm.sdkapi.GetMultiRegionAccessPointRoutes(ctx, &svcsdk.GetMultiRegionAccessPointRoutesInput{
+		AccountId: aws.String(string(rm.awsAccountID)),
+		Mrap:      (*string)(ko.Status.ACKResourceMetadata.ARN),
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetMultiRegionAccessPointRoutes", err)
+	if err != nil {
+		return err
+	}
+
+	ko.Status.Routes = nil
+	dials := map[string]*int64{}
+	for _, route := range resp.Routes {
+		var dial *int64
+		if route.TrafficDialPercentage != nil {
+			dial = aws.Int64(int64(*route.TrafficDialPercentage))
+		}
+		ko.Status.Routes = append(ko.Status.Routes, &svcapitypes.MultiRegionAccessPointRoute{
+			Bucket:                route.Bucket,
+			Region:                route.Region,
+			TrafficDialPercentage: dial,
+		})
+		dials[aws.ToString(route.Bucket)] = dial
+	}
+	for _, region := range ko.Spec.Regions {
+		if region.TrafficDialPercentage != nil {
+			region.TrafficDialPercentage = dials[aws.ToString(region.Bucket)]
+		}
+	}
+	return nil
+}
+
+// putPolicy starts putting the policy of the spec of the Multi-Region Access
+// Point, returning the request token of the operation.
+func (rm *resourceManager) putPolicy(
+	ctx context.Context,
+	ko *svcapitypes.MultiRegionAccessPoint,
+) (*string, error) {
+	resp, err := rm.sdkapi.PutMultiRegionAccessPointPolicy(ctx, &svcsdk.PutMultiRegionAccessPointPolicyInput{
+		AccountId: aws.String(string(rm.awsAccountID)),
+		Details: &svcsdktypes.PutMultiRegionAccessPointPolicyInput{
+			Name:   ko.Spec.Name,
+			Policy: ko.Spec.Policy,
+		},
+	})
+	rm.metrics.RecordAPICall("UPDATE", "PutMultiRegionAccessPointPolicy", err)
+	if err != nil {
+		return nil, err
+	}
+	return resp.RequestTokenARN, nil
+}
+
+// submitRoutes submits the traffic dial percentages set in the spec of the
+// desired Multi-Region Access Point, all at once so that failing over from a
+// bucket to another never leaves both of them active or passive.
+func (rm *resourceManager) submitRoutes(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) error {
+	var updates []svcsdktypes.MultiRegionAccessPointRoute
+	for _, region := range desired.ko.Spec.Regions {
+		if region.TrafficDialPercentage == nil {
+			continue
+		}
+		updates = append(updates, svcsdktypes.MultiRegionAccessPointRoute{
+			Bucket:                region.Bucket,
+			TrafficDialPercentage: aws.Int32(int32(*region.TrafficDialPercentage)),
+		})
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	if latest.ko.Status.ACKResourceMetadata == nil || latest.ko.Status.ACKResourceMetadata.ARN == nil {
+		return fmt.Errorf("the ARN of the Multi-Region Access Point is not known yet")
+	}
+	_, err := rm.sdkapi.SubmitMultiRegionAccessPointRoutes(ctx, &svcsdk.SubmitMultiRegionAccessPointRoutesInput{
+		AccountId:    aws.String(string(rm.awsAccountID)),
+		Mrap:         (*string)(latest.ko.Status.ACKResourceMetadata.ARN),
+		RouteUpdates: updates,
+	})
+	rm.metrics.RecordAPICall("UPDATE", "SubmitMultiRegionAccessPointRoutes", err)
+	return err
+}
+
+// validateRegionsUpdate returns a terminal error if the buckets of the
+// desired Multi-Region Access Point differ from the latest ones, as S3 does
+// not allow to change them once it is created.
+func validateRegionsUpdate(desired *resource, latest *resource) error {
+	buckets := func(regions []*svcapitypes.MultiRegionAccessPointRegion) map[string]string {
+		res := map[string]string{}
+		for _, region := range regions {
+			res[aws.ToString(region.Bucket)] = aws.ToString(region.BucketAccountID)
+		}
+		return res
+	}
+	desiredBuckets := buckets(desired.ko.Spec.Regions)
+	latestBuckets := buckets(latest.ko.Spec.Regions)
+	same := len(desiredBuckets) == len(latestBuckets)
+	for bucket, account := range desiredBuckets {
+		if latestAccount, ok := latestBuckets[bucket]; !ok || latestAccount != account {
+			same = false
+		}
+	}
+	if !same {
+		return ackerr.NewTerminalError(errors.New(
+			"the buckets of spec.regions cannot be changed once the Multi-Region Access Point is created, only their trafficDialPercentage",
+		))
+	}
+	return nil
+}
+
+// customUpdateMultiRegionAccessPoint updates the policy and routes of the
+// Multi-Region Access Point, the only properties S3 allows to change once it
+// is created.
+func (rm *resourceManager) customUpdateMultiRegionAccessPoint(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.customUpdateMultiRegionAccessPoint")
+	defer func() {
+		exit(err)
+	}()
+	if delta.DifferentAt("Spec.Regions") {
+		if err := validateRegionsUpdate(desired, latest); err != nil {
+			return nil, err
+		}
+	}
+
+	ko := desired.ko.DeepCopy()
+	latest.ko.Status.DeepCopyInto(&ko.Status)
+	rm.setStatusDefaults(ko)
+
+	if delta.DifferentAt("Spec.Regions") {
+		if err := rm.submitRoutes(ctx, desired, latest); err != nil {
+			return nil, err
+		}
+	}
+	if delta.DifferentAt("Spec.Policy") {
+		token, err := rm.putPolicy(ctx, desired.ko)
+		if err != nil {
+			return nil, err
+		}
+		ko.Status.RequestTokenARN = token
+	}
+	return &resource{ko}, nil
+}
+
+// customPreCompare ignores the differences between the desired and latest
+// Multi-Region Access Points that are only in the form S3 returns them in.
+func customPreCompare(
+	a *resource,
+	b *resource,
+) {
+	// S3 rewrites parts of the policy before returning it from
+	// GetMultiRegionAccessPointPolicy, so only diff the policy when the
+	// permissions it grants differ.
+	if a.ko.Spec.Policy != nil && b.ko.Spec.Policy != nil &&
+		iampolicy.Equal(*a.ko.Spec.Policy, *b.ko.Spec.Policy) {
+		b.ko.Spec.Policy = a.ko.Spec.Policy
+	}
+	// The policy of a Multi-Region Access Point cannot be deleted, so it is
+	// not managed when it is not set.
+	if aws.ToString(a.ko.Spec.Policy) == "" {
+		b.ko.Spec.Policy = a.ko.Spec.Policy
+	}
+}
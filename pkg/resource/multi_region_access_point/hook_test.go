@@ -0,0 +1,450 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package multi_region_access_point
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	smithy "github.com/aws/smithy-go"
+	smithymiddleware "github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3control"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3control/types"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+const (
+	testAlias = "abcdefghijklm.mrap"
+	testARN   = "arn:aws:s3::111122223333:accesspoint/abcdefghijklm.mrap"
+	testToken = "arn:aws:s3:us-west-2:111122223333:async-request/mrap/create/abcdef"
+)
+
+const readPolicy = `{
+	"Version": "2012-10-17",
+	"Statement": [{
+		"Effect": "Allow",
+		"Principal": {"AWS": "arn:aws:iam::111122223333:role/team-a"},
+		"Action": "s3:GetObject",
+		"Resource": "arn:aws:s3::111122223333:accesspoint/abcdefghijklm.mrap/object/*"
+	}]
+}`
+
+// opResult is a canned response for a single S3 Control operation. Exactly
+// one of output or err is used.
+type opResult struct {
+	output interface{}
+	err    error
+}
+
+// recorder records the operations called on a mocked client, with the
+// Region each was sent to and the input of the last call of each.
+type recorder struct {
+	calls   []string
+	regions []string
+	inputs  map[string]interface{}
+}
+
+// newMockedSDKClient builds a real *s3control.Client, configured like the one
+// of the resource manager, whose middleware stack is short-circuited at the
+// Finalize step, returning the canned result of each operation. Operations
+// without a result return an empty output.
+func newMockedSDKClient(
+	results map[string]opResult,
+	rec *recorder,
+) *svcsdk.Client {
+	defaults := map[string]opResult{
+		"GetMultiRegionAccessPointPolicy": {err: apiErr("NoSuchMultiRegionAccessPointPolicy")},
+		"GetMultiRegionAccessPointRoutes": {output: &svcsdk.GetMultiRegionAccessPointRoutesOutput{}},
+	}
+	if rec != nil && rec.inputs == nil {
+		rec.inputs = map[string]interface{}{}
+	}
+
+	mockInitialize := smithymiddleware.InitializeMiddlewareFunc(
+		"mockS3ControlInitialize",
+		func(
+			ctx context.Context,
+			in smithymiddleware.InitializeInput,
+			next smithymiddleware.InitializeHandler,
+		) (smithymiddleware.InitializeOutput, smithymiddleware.Metadata, error) {
+			if rec != nil {
+				rec.inputs[smithymiddleware.GetOperationName(ctx)] = in.Parameters
+			}
+			return next.HandleInitialize(ctx, in)
+		},
+	)
+	mockFinalize := smithymiddleware.FinalizeMiddlewareFunc(
+		"mockS3ControlFinalize",
+		func(
+			ctx context.Context,
+			in smithymiddleware.FinalizeInput,
+			_ smithymiddleware.FinalizeHandler,
+		) (smithymiddleware.FinalizeOutput, smithymiddleware.Metadata, error) {
+			opName := smithymiddleware.GetOperationName(ctx)
+			if rec != nil {
+				rec.calls = append(rec.calls, opName)
+				rec.regions = append(rec.regions, awsmiddleware.GetRegion(ctx))
+			}
+			res, ok := results[opName]
+			if !ok {
+				res = defaults[opName]
+			}
+			if res.output == nil && res.err == nil {
+				res.output = emptyOutputs[opName]
+			}
+			return smithymiddleware.FinalizeOutput{Result: res.output}, smithymiddleware.Metadata{}, res.err
+		},
+	)
+
+	return svcsdk.New(svcsdk.Options{
+		Region: "eu-west-1",
+		APIOptions: []func(*smithymiddleware.Stack) error{
+			func(stack *smithymiddleware.Stack) error {
+				if err := stack.Initialize.Add(mockInitialize, smithymiddleware.Before); err != nil {
+					return err
+				}
+				return stack.Finalize.Add(mockFinalize, smithymiddleware.Before)
+			},
+		},
+	}, withControlPlaneRegion)
+}
+
+// emptyOutputs are the outputs of the operations whose result is not
+// inspected by the tests.
+var emptyOutputs = map[string]interface{}{
+	"CreateMultiRegionAccessPoint":       &svcsdk.CreateMultiRegionAccessPointOutput{},
+	"DeleteMultiRegionAccessPoint":       &svcsdk.DeleteMultiRegionAccessPointOutput{},
+	"PutMultiRegionAccessPointPolicy":    &svcsdk.PutMultiRegionAccessPointPolicyOutput{},
+	"SubmitMultiRegionAccessPointRoutes": &svcsdk.SubmitMultiRegionAccessPointRoutesOutput{},
+}
+
+func apiErr(code string) error {
+	return &smithy.GenericAPIError{Code: code, Message: code}
+}
+
+func newTestResourceManager(sdkapi *svcsdk.Client) *resourceManager {
+	return &resourceManager{
+		sdkapi:       sdkapi,
+		metrics:      ackmetrics.NewMetrics("s3"),
+		awsAccountID: ackv1alpha1.AWSAccountID("111122223333"),
+		awsRegion:    ackv1alpha1.AWSRegion("eu-west-1"),
+		awsPartition: ackv1alpha1.AWSPartition("aws"),
+	}
+}
+
+// newMultiRegionAccessPointResource returns an active/passive Multi-Region
+// Access Point over a bucket in eu-west-1 and one in eu-central-1.
+func newMultiRegionAccessPointResource(activeDial, passiveDial int64) *resource {
+	return &resource{&svcapitypes.MultiRegionAccessPoint{
+		Spec: svcapitypes.MultiRegionAccessPointSpec{
+			Name: aws.String("my-mrap"),
+			Regions: []*svcapitypes.MultiRegionAccessPointRegion{
+				{Bucket: aws.String("bucket-eu-west-1"), TrafficDialPercentage: aws.Int64(activeDial)},
+				{Bucket: aws.String("bucket-eu-central-1"), TrafficDialPercentage: aws.Int64(passiveDial)},
+			},
+		},
+	}}
+}
+
+func newReadyResource(activeDial, passiveDial int64) *resource {
+	r := newMultiRegionAccessPointResource(activeDial, passiveDial)
+	arn := ackv1alpha1.AWSResourceName(testARN)
+	r.ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{ARN: &arn}
+	r.ko.Status.State = aws.String("READY")
+	return r
+}
+
+// Test_sdkFind_NotFound verifies that a missing Multi-Region Access Point is
+// reported as not found.
+func Test_sdkFind_NotFound(t *testing.T) {
+	rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+		"GetMultiRegionAccessPoint": {err: apiErr("NoSuchMultiRegionAccessPoint")},
+	}, nil))
+
+	_, err := rm.sdkFind(context.Background(), newMultiRegionAccessPointResource(100, 0))
+	assert.Equal(t, ackerr.NotFound, err)
+}
+
+// Test_sdkFind verifies that the Multi-Region Access Point, its policy and
+// its routes are read into the resource, from the control plane Region.
+func Test_sdkFind(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	rec := &recorder{}
+	rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+		"GetMultiRegionAccessPoint": {output: &svcsdk.GetMultiRegionAccessPointOutput{
+			AccessPoint: &svcsdktypes.MultiRegionAccessPointReport{
+				Alias:     aws.String(testAlias),
+				CreatedAt: &createdAt,
+				Name:      aws.String("my-mrap"),
+				PublicAccessBlock: &svcsdktypes.PublicAccessBlockConfiguration{
+					BlockPublicAcls:       aws.Bool(true),
+					BlockPublicPolicy:     aws.Bool(true),
+					IgnorePublicAcls:      aws.Bool(true),
+					RestrictPublicBuckets: aws.Bool(true),
+				},
+				// Not in the order of the spec
+				Regions: []svcsdktypes.RegionReport{
+					{Bucket: aws.String("bucket-eu-central-1"), BucketAccountId: aws.String("111122223333"), Region: aws.String("eu-central-1")},
+					{Bucket: aws.String("bucket-eu-west-1"), BucketAccountId: aws.String("111122223333"), Region: aws.String("eu-west-1")},
+				},
+				Status: svcsdktypes.MultiRegionAccessPointStatusReady,
+			},
+		}},
+		"GetMultiRegionAccessPointPolicy": {output: &svcsdk.GetMultiRegionAccessPointPolicyOutput{
+			Policy: &svcsdktypes.MultiRegionAccessPointPolicyDocument{
+				Established: &svcsdktypes.EstablishedMultiRegionAccessPointPolicy{Policy: aws.String(readPolicy)},
+			},
+		}},
+		"GetMultiRegionAccessPointRoutes": {output: &svcsdk.GetMultiRegionAccessPointRoutesOutput{
+			Routes: []svcsdktypes.MultiRegionAccessPointRoute{
+				{Bucket: aws.String("bucket-eu-west-1"), Region: aws.String("eu-west-1"), TrafficDialPercentage: aws.Int32(100)},
+				{Bucket: aws.String("bucket-eu-central-1"), Region: aws.String("eu-central-1"), TrafficDialPercentage: aws.Int32(0)},
+			},
+		}},
+	}, rec))
+
+	desired := newMultiRegionAccessPointResource(100, 0)
+	desired.ko.Spec.Policy = aws.String(readPolicy)
+	latest, err := rm.sdkFind(context.Background(), desired)
+	require.NoError(err)
+
+	ko := latest.ko
+	assert.Equal(testARN, string(*ko.Status.ACKResourceMetadata.ARN))
+	assert.Equal(testAlias, *ko.Status.Alias)
+	assert.Equal(createdAt, ko.Status.CreatedAt.Time)
+	assert.Equal("READY", *ko.Status.State)
+	assert.Len(ko.Status.Routes, 2)
+	assert.Equal("eu-central-1", *ko.Status.Routes[1].Region)
+	assert.Equal(desired.ko.Spec.Regions, ko.Spec.Regions)
+	assert.True(*ko.Spec.PublicAccessBlock.BlockPublicPolicy)
+	delta := newResourceDelta(desired, latest)
+	assert.False(delta.DifferentAt("Spec.Regions"))
+	assert.False(delta.DifferentAt("Spec.Policy"))
+
+	// Every call goes to the control plane, not to the Region of the
+	// controller
+	assert.Equal([]string{
+		"GetMultiRegionAccessPoint",
+		"GetMultiRegionAccessPointPolicy",
+		"GetMultiRegionAccessPointRoutes",
+	}, rec.calls)
+	for _, region := range rec.regions {
+		assert.Equal(controlPlaneRegion, region)
+	}
+}
+
+// Test_sdkFind_Operation verifies that the Multi-Region Access Point is only
+// read once the asynchronous operation started on it succeeded.
+func Test_sdkFind_Operation(t *testing.T) {
+	describe := func(status string) opResult {
+		return opResult{output: &svcsdk.DescribeMultiRegionAccessPointOperationOutput{
+			AsyncOperation: &svcsdktypes.AsyncOperation{
+				Operation:     svcsdktypes.AsyncOperationNameCreateMultiRegionAccessPoint,
+				RequestStatus: aws.String(status),
+				ResponseDetails: &svcsdktypes.AsyncResponseDetails{
+					ErrorDetails: &svcsdktypes.AsyncErrorDetails{
+						Code:    aws.String("BucketNotFound"),
+						Message: aws.String("bucket-eu-central-1 does not exist"),
+					},
+				},
+			},
+		}}
+	}
+
+	t.Run("running", func(t *testing.T) {
+		rec := &recorder{}
+		rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+			"DescribeMultiRegionAccessPointOperation": describe("RUNNING"),
+		}, rec))
+		desired := newMultiRegionAccessPointResource(100, 0)
+		desired.ko.Status.RequestTokenARN = aws.String(testToken)
+
+		latest, err := rm.sdkFind(context.Background(), desired)
+		require.NoError(t, err)
+		assert.Equal(t, desired.ko, latest.ko)
+		assert.Equal(t, []string{"DescribeMultiRegionAccessPointOperation"}, rec.calls)
+
+		synced, err := rm.IsSynced(context.Background(), latest)
+		require.NoError(t, err)
+		assert.False(t, synced)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+			"DescribeMultiRegionAccessPointOperation": describe("FAILED"),
+		}, nil))
+		desired := newMultiRegionAccessPointResource(100, 0)
+		desired.ko.Status.RequestTokenARN = aws.String(testToken)
+
+		latest, err := rm.sdkFind(context.Background(), desired)
+		var terminalErr *ackerr.TerminalError
+		require.ErrorAs(t, err, &terminalErr)
+		assert.Contains(t, err.Error(), "bucket-eu-central-1 does not exist")
+		assert.Nil(t, latest.ko.Status.RequestTokenARN)
+	})
+
+	t.Run("succeeded", func(t *testing.T) {
+		rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+			"DescribeMultiRegionAccessPointOperation": describe("SUCCEEDED"),
+			"GetMultiRegionAccessPoint": {output: &svcsdk.GetMultiRegionAccessPointOutput{
+				AccessPoint: &svcsdktypes.MultiRegionAccessPointReport{
+					Alias:  aws.String(testAlias),
+					Name:   aws.String("my-mrap"),
+					Status: svcsdktypes.MultiRegionAccessPointStatusReady,
+				},
+			}},
+		}, nil))
+		desired := newMultiRegionAccessPointResource(100, 0)
+		desired.ko.Status.RequestTokenARN = aws.String(testToken)
+
+		latest, err := rm.sdkFind(context.Background(), desired)
+		require.NoError(t, err)
+		assert.Nil(t, latest.ko.Status.RequestTokenARN)
+
+		synced, err := rm.IsSynced(context.Background(), latest)
+		require.NoError(t, err)
+		assert.True(t, synced)
+	})
+}
+
+// Test_sdkCreate verifies that the request token of the creation is tracked
+// and that the buckets are sent to S3.
+func Test_sdkCreate(t *testing.T) {
+	rec := &recorder{}
+	rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+		"CreateMultiRegionAccessPoint": {output: &svcsdk.CreateMultiRegionAccessPointOutput{
+			RequestTokenARN: aws.String(testToken),
+		}},
+	}, rec))
+	desired := newMultiRegionAccessPointResource(100, 0)
+	desired.ko.UID = "6c5c8e4e-8b1b-4d52-9c4f-0f3c0b2b7e1a"
+	desired.ko.Generation = 2
+
+	created, err := rm.sdkCreate(context.Background(), desired)
+	require.NoError(t, err)
+	assert.Equal(t, testToken, *created.ko.Status.RequestTokenARN)
+	assert.Equal(t, "CREATING", *created.ko.Status.State)
+
+	input := rec.inputs["CreateMultiRegionAccessPoint"].(*svcsdk.CreateMultiRegionAccessPointInput)
+	assert.Equal(t, "6c5c8e4e-8b1b-4d52-9c4f-0f3c0b2b7e1a-2", *input.ClientToken)
+	assert.Equal(t, []svcsdktypes.Region{
+		{Bucket: aws.String("bucket-eu-west-1")},
+		{Bucket: aws.String("bucket-eu-central-1")},
+	}, input.Details.Regions)
+}
+
+// Test_customUpdateMultiRegionAccessPoint verifies that failing over submits
+// the traffic dial percentages of all the buckets at once, that the policy is
+// put asynchronously, and that the buckets cannot be changed.
+func Test_customUpdateMultiRegionAccessPoint(t *testing.T) {
+	t.Run("failover", func(t *testing.T) {
+		rec := &recorder{}
+		rm := newTestResourceManager(newMockedSDKClient(nil, rec))
+		desired := newMultiRegionAccessPointResource(0, 100)
+		latest := newReadyResource(100, 0)
+
+		delta := newResourceDelta(desired, latest)
+		_, err := rm.customUpdateMultiRegionAccessPoint(context.Background(), desired, latest, delta)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"SubmitMultiRegionAccessPointRoutes"}, rec.calls)
+
+		input := rec.inputs["SubmitMultiRegionAccessPointRoutes"].(*svcsdk.SubmitMultiRegionAccessPointRoutesInput)
+		assert.Equal(t, testARN, *input.Mrap)
+		assert.Equal(t, []svcsdktypes.MultiRegionAccessPointRoute{
+			{Bucket: aws.String("bucket-eu-west-1"), TrafficDialPercentage: aws.Int32(0)},
+			{Bucket: aws.String("bucket-eu-central-1"), TrafficDialPercentage: aws.Int32(100)},
+		}, input.RouteUpdates)
+	})
+
+	t.Run("policy", func(t *testing.T) {
+		rec := &recorder{}
+		rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+			"PutMultiRegionAccessPointPolicy": {output: &svcsdk.PutMultiRegionAccessPointPolicyOutput{
+				RequestTokenARN: aws.String(testToken),
+			}},
+		}, rec))
+		desired := newMultiRegionAccessPointResource(100, 0)
+		desired.ko.Spec.Policy = aws.String(readPolicy)
+		latest := newReadyResource(100, 0)
+
+		delta := newResourceDelta(desired, latest)
+		updated, err := rm.customUpdateMultiRegionAccessPoint(context.Background(), desired, latest, delta)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"PutMultiRegionAccessPointPolicy"}, rec.calls)
+		assert.Equal(t, testToken, *updated.ko.Status.RequestTokenARN)
+		assert.Equal(t, "READY", *updated.ko.Status.State)
+	})
+
+	t.Run("buckets changed", func(t *testing.T) {
+		rec := &recorder{}
+		rm := newTestResourceManager(newMockedSDKClient(nil, rec))
+		desired := newMultiRegionAccessPointResource(100, 0)
+		desired.ko.Spec.Regions[1].Bucket = aws.String("bucket-us-east-1")
+		latest := newReadyResource(100, 0)
+
+		delta := newResourceDelta(desired, latest)
+		_, err := rm.customUpdateMultiRegionAccessPoint(context.Background(), desired, latest, delta)
+		var terminalErr *ackerr.TerminalError
+		require.ErrorAs(t, err, &terminalErr)
+		assert.Empty(t, rec.calls)
+	})
+}
+
+// Test_sdkDelete verifies that the deletion is started once and waited for.
+func Test_sdkDelete(t *testing.T) {
+	rec := &recorder{}
+	rm := newTestResourceManager(newMockedSDKClient(nil, rec))
+
+	r := newReadyResource(100, 0)
+	_, err := rm.sdkDelete(context.Background(), r)
+	var requeueErr *ackrequeue.RequeueNeededAfter
+	require.ErrorAs(t, err, &requeueErr)
+	assert.Equal(t, []string{"DeleteMultiRegionAccessPoint"}, rec.calls)
+
+	r.ko.Status.State = aws.String("DELETING")
+	_, err = rm.sdkDelete(context.Background(), r)
+	require.ErrorAs(t, err, &requeueErr)
+	assert.Len(t, rec.calls, 1)
+}
+
+// Test_newResourceDelta_Policy verifies that policies are compared by the
+// permissions they grant, and that an unset policy is not managed.
+func Test_newResourceDelta_Policy(t *testing.T) {
+	policyDifferent := func(desiredPolicy *string) bool {
+		desired := newMultiRegionAccessPointResource(100, 0)
+		desired.ko.Spec.Policy = desiredPolicy
+		latest := newMultiRegionAccessPointResource(100, 0)
+		latest.ko.Spec.Policy = aws.String(readPolicy)
+		return newResourceDelta(desired, latest).DifferentAt("Spec.Policy")
+	}
+
+	assert.False(t, policyDifferent(nil))
+	assert.False(t, policyDifferent(aws.String(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111122223333:role/team-a"},"Action":["s3:GetObject"],"Resource":["arn:aws:s3::111122223333:accesspoint/abcdefghijklm.mrap/object/*"]}]}`)))
+	assert.True(t, policyDifferent(aws.String(`{"Version":"2012-10-17","Statement":[]}`)))
+}
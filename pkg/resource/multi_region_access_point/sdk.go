@@ -0,0 +1,395 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package multi_region_access_point
+
+import (
+	"context"
+	"errors"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3control"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3control/types"
+	smithy "github.com/aws/smithy-go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// sdkFind returns SDK-specific information about a supplied resource
+func (rm *resourceManager) sdkFind(
+	ctx context.Context,
+	r *resource,
+) (latest *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkFind")
+	defer func() {
+		exit(err)
+	}()
+	// If any required fields in the input shape are missing, AWS resource is
+	// not created yet. Return NotFound here to indicate to callers that the
+	// resource isn't yet created.
+	if rm.requiredFieldsMissingFromReadOneInput(r) {
+		return nil, ackerr.NotFound
+	}
+
+	if r.ko.Status.RequestTokenARN != nil {
+		if pending, err := rm.awaitOperation(ctx, r); pending != nil || err != nil {
+			return pending, err
+		}
+	}
+
+	input, err := rm.newDescribeRequestPayload(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *svcsdk.GetMultiRegionAccessPointOutput
+	resp, err = rm.sdkapi.GetMultiRegionAccessPoint(ctx, input)
+	rm.metrics.RecordAPICall("READ_ONE", "GetMultiRegionAccessPoint", err)
+	if err != nil {
+		var awsErr smithy.APIError
+		if errors.As(err, &awsErr) && awsErr.ErrorCode() == "NoSuchMultiRegionAccessPoint" {
+			return nil, ackerr.NotFound
+		}
+		return nil, err
+	}
+	if resp.AccessPoint == nil {
+		return nil, ackerr.NotFound
+	}
+
+	// Merge in the information we read from the API call above to the copy of
+	// the original Kubernetes object we passed to the function
+	ko := r.ko.DeepCopy()
+	ko.Status.RequestTokenARN = nil
+
+	if resp.AccessPoint.Alias != nil {
+		if ko.Status.ACKResourceMetadata == nil {
+			ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+		}
+		arn := ackv1alpha1.AWSResourceName(rm.ARNFromName(*resp.AccessPoint.Alias))
+		ko.Status.ACKResourceMetadata.ARN = &arn
+	}
+	ko.Status.Alias = resp.AccessPoint.Alias
+	if resp.AccessPoint.CreatedAt != nil {
+		ko.Status.CreatedAt = &metav1.Time{Time: *resp.AccessPoint.CreatedAt}
+	} else {
+		ko.Status.CreatedAt = nil
+	}
+	if resp.AccessPoint.Name != nil {
+		ko.Spec.Name = resp.AccessPoint.Name
+	} else {
+		ko.Spec.Name = nil
+	}
+	if resp.AccessPoint.PublicAccessBlock != nil {
+		ko.Spec.PublicAccessBlock = &svcapitypes.PublicAccessBlockConfiguration{
+			BlockPublicACLs:       resp.AccessPoint.PublicAccessBlock.BlockPublicAcls,
+			BlockPublicPolicy:     resp.AccessPoint.PublicAccessBlock.BlockPublicPolicy,
+			IgnorePublicACLs:      resp.AccessPoint.PublicAccessBlock.IgnorePublicAcls,
+			RestrictPublicBuckets: resp.AccessPoint.PublicAccessBlock.RestrictPublicBuckets,
+		}
+	} else {
+		ko.Spec.PublicAccessBlock = nil
+	}
+	ko.Spec.Regions = rm.newResourceRegions(r.ko.Spec.Regions, resp.AccessPoint.Regions)
+	if resp.AccessPoint.Status != "" {
+		ko.Status.State = aws.String(string(resp.AccessPoint.Status))
+	} else {
+		ko.Status.State = nil
+	}
+
+	rm.setStatusDefaults(ko)
+	if err := rm.addPolicyToSpec(ctx, ko); err != nil {
+		return nil, err
+	}
+	if err := rm.addRoutesToResource(ctx, ko); err != nil {
+		return nil, err
+	}
+
+	return &resource{ko}, nil
+}
+
+// requiredFieldsMissingFromReadOneInput returns true if there are any fields
+// for the ReadOne Input shape that are required but not present in the
+// resource's Spec or Status
+func (rm *resourceManager) requiredFieldsMissingFromReadOneInput(
+	r *resource,
+) bool {
+	return r.ko.Spec.Name == nil
+}
+
+// newDescribeRequestPayload returns SDK-specific struct for the HTTP request
+// payload of the Describe API call for the resource
+func (rm *resourceManager) newDescribeRequestPayload(
+	r *resource,
+) (*svcsdk.GetMultiRegionAccessPointInput, error) {
+	res := &svcsdk.GetMultiRegionAccessPointInput{}
+
+	res.AccountId = aws.String(string(rm.awsAccountID))
+	if r.ko.Spec.Name != nil {
+		res.Name = r.ko.Spec.Name
+	}
+
+	return res, nil
+}
+
+// sdkCreate creates the supplied resource in the backend AWS service API and
+// returns a copy of the resource with resource fields (in both Spec and
+// Status) filled in with values from the CREATE API operation's Output shape.
+func (rm *resourceManager) sdkCreate(
+	ctx context.Context,
+	desired *resource,
+) (created *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkCreate")
+	defer func() {
+		exit(err)
+	}()
+	input, err := rm.newCreateRequestPayload(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *svcsdk.CreateMultiRegionAccessPointOutput
+	resp, err = rm.sdkapi.CreateMultiRegionAccessPoint(ctx, input)
+	rm.metrics.RecordAPICall("CREATE", "CreateMultiRegionAccessPoint", err)
+	if err != nil {
+		return nil, err
+	}
+	// Merge in the information we read from the API call above to the copy of
+	// the original Kubernetes object we passed to the function
+	ko := desired.ko.DeepCopy()
+
+	// The Multi-Region Access Point is created asynchronously. Its ARN, built
+	// from its alias, and its policy and routes, which cannot be set by
+	// CreateMultiRegionAccessPoint, are only read and synced once the
+	// operation completes.
+	ko.Status.RequestTokenARN = resp.RequestTokenARN
+	ko.Status.State = aws.String(string(svcsdktypes.MultiRegionAccessPointStatusCreating))
+
+	rm.setStatusDefaults(ko)
+	return &resource{ko}, nil
+}
+
+// newCreateRequestPayload returns an SDK-specific struct for the HTTP request
+// payload of the Create API call for the resource
+func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
+	r *resource,
+) (*svcsdk.CreateMultiRegionAccessPointInput, error) {
+	res := &svcsdk.CreateMultiRegionAccessPointInput{}
+
+	res.AccountId = aws.String(string(rm.awsAccountID))
+	res.ClientToken = aws.String(clientToken(r.ko))
+	details := &svcsdktypes.CreateMultiRegionAccessPointInput{}
+	if r.ko.Spec.Name != nil {
+		details.Name = r.ko.Spec.Name
+	}
+	if r.ko.Spec.PublicAccessBlock != nil {
+		details.PublicAccessBlock = &svcsdktypes.PublicAccessBlockConfiguration{
+			BlockPublicAcls:       r.ko.Spec.PublicAccessBlock.BlockPublicACLs,
+			BlockPublicPolicy:     r.ko.Spec.PublicAccessBlock.BlockPublicPolicy,
+			IgnorePublicAcls:      r.ko.Spec.PublicAccessBlock.IgnorePublicACLs,
+			RestrictPublicBuckets: r.ko.Spec.PublicAccessBlock.RestrictPublicBuckets,
+		}
+	}
+	for _, region := range r.ko.Spec.Regions {
+		if region == nil {
+			continue
+		}
+		details.Regions = append(details.Regions, svcsdktypes.Region{
+			Bucket:          region.Bucket,
+			BucketAccountId: region.BucketAccountID,
+		})
+	}
+	res.Details = details
+
+	return res, nil
+}
+
+// sdkUpdate patches the supplied resource in the backend AWS service API and
+// returns a new resource with updated fields.
+func (rm *resourceManager) sdkUpdate(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (*resource, error) {
+	return rm.customUpdateMultiRegionAccessPoint(ctx, desired, latest, delta)
+}
+
+// sdkDelete deletes the supplied resource in the backend AWS service API
+func (rm *resourceManager) sdkDelete(
+	ctx context.Context,
+	r *resource,
+) (latest *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkDelete")
+	defer func() {
+		exit(err)
+	}()
+	if aws.ToString(r.ko.Status.State) == string(svcsdktypes.MultiRegionAccessPointStatusDeleting) {
+		return r, requeueWaitWhileDeleting
+	}
+	input, err := rm.newDeleteRequestPayload(r)
+	if err != nil {
+		return nil, err
+	}
+	_, err = rm.sdkapi.DeleteMultiRegionAccessPoint(ctx, input)
+	rm.metrics.RecordAPICall("DELETE", "DeleteMultiRegionAccessPoint", err)
+	if err != nil {
+		return nil, err
+	}
+	// The Multi-Region Access Point is deleted asynchronously, so wait for it
+	// to be gone before removing the finalizer.
+	return r, requeueWaitWhileDeleting
+}
+
+// newDeleteRequestPayload returns an SDK-specific struct for the HTTP request
+// payload of the Delete API call for the resource
+func (rm *resourceManager) newDeleteRequestPayload(
+	r *resource,
+) (*svcsdk.DeleteMultiRegionAccessPointInput, error) {
+	res := &svcsdk.DeleteMultiRegionAccessPointInput{}
+
+	res.AccountId = aws.String(string(rm.awsAccountID))
+	res.Details = &svcsdktypes.DeleteMultiRegionAccessPointInput{
+		Name: r.ko.Spec.Name,
+	}
+
+	return res, nil
+}
+
+// setStatusDefaults sets default properties into supplied custom resource
+func (rm *resourceManager) setStatusDefaults(
+	ko *svcapitypes.MultiRegionAccessPoint,
+) {
+	if ko.Status.ACKResourceMetadata == nil {
+		ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+	}
+	if ko.Status.ACKResourceMetadata.Region == nil {
+		ko.Status.ACKResourceMetadata.Region = &rm.awsRegion
+	}
+	if ko.Status.ACKResourceMetadata.Partition == nil {
+		ko.Status.ACKResourceMetadata.Partition = &rm.awsPartition
+	}
+	if ko.Status.ACKResourceMetadata.OwnerAccountID == nil {
+		ko.Status.ACKResourceMetadata.OwnerAccountID = &rm.awsAccountID
+	}
+	if ko.Status.Conditions == nil {
+		ko.Status.Conditions = []*ackv1alpha1.Condition{}
+	}
+}
+
+// updateConditions returns updated resource, true; if conditions were updated
+// else it returns nil, false
+func (rm *resourceManager) updateConditions(
+	r *resource,
+	onSuccess bool,
+	err error,
+) (*resource, bool) {
+	ko := r.ko.DeepCopy()
+	rm.setStatusDefaults(ko)
+
+	// Terminal condition
+	var terminalCondition *ackv1alpha1.Condition = nil
+	var recoverableCondition *ackv1alpha1.Condition = nil
+	var syncCondition *ackv1alpha1.Condition = nil
+	for _, condition := range ko.Status.Conditions {
+		if condition.Type == ackv1alpha1.ConditionTypeTerminal {
+			terminalCondition = condition
+		}
+		if condition.Type == ackv1alpha1.ConditionTypeRecoverable {
+			recoverableCondition = condition
+		}
+		if condition.Type == ackv1alpha1.ConditionTypeResourceSynced {
+			syncCondition = condition
+		}
+	}
+	var termError *ackerr.TerminalError
+	if rm.terminalAWSError(err) || err == ackerr.SecretTypeNotSupported || err == ackerr.SecretNotFound || errors.As(err, &termError) {
+		if terminalCondition == nil {
+			terminalCondition = &ackv1alpha1.Condition{
+				Type: ackv1alpha1.ConditionTypeTerminal,
+			}
+			ko.Status.Conditions = append(ko.Status.Conditions, terminalCondition)
+		}
+		var errorMessage = ""
+		if err == ackerr.SecretTypeNotSupported || err == ackerr.SecretNotFound || errors.As(err, &termError) {
+			errorMessage = err.Error()
+		} else {
+			awsErr, _ := ackerr.AWSError(err)
+			errorMessage = awsErr.Error()
+		}
+		terminalCondition.Status = corev1.ConditionTrue
+		terminalCondition.Message = &errorMessage
+	} else {
+		// Clear the terminal condition if no longer present
+		if terminalCondition != nil {
+			terminalCondition.Status = corev1.ConditionFalse
+			terminalCondition.Message = nil
+		}
+		// Handling Recoverable Conditions
+		if err != nil {
+			if recoverableCondition == nil {
+				// Add a new Condition containing a non-terminal error
+				recoverableCondition = &ackv1alpha1.Condition{
+					Type: ackv1alpha1.ConditionTypeRecoverable,
+				}
+				ko.Status.Conditions = append(ko.Status.Conditions, recoverableCondition)
+			}
+			recoverableCondition.Status = corev1.ConditionTrue
+			awsErr, _ := ackerr.AWSError(err)
+			errorMessage := err.Error()
+			if awsErr != nil {
+				errorMessage = awsErr.Error()
+			}
+			recoverableCondition.Message = &errorMessage
+		} else if recoverableCondition != nil {
+			recoverableCondition.Status = corev1.ConditionFalse
+			recoverableCondition.Message = nil
+		}
+	}
+	// Required to avoid the "declared but not used" error in the default case
+	_ = syncCondition
+	if terminalCondition != nil || recoverableCondition != nil || syncCondition != nil {
+		return &resource{ko}, true // updated
+	}
+	return nil, false // not updated
+}
+
+// terminalAWSError returns awserr, true; if the supplied error is an aws Error type
+// and if the exception indicates that it is a Terminal exception
+func (rm *resourceManager) terminalAWSError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var terminalErr smithy.APIError
+	if !errors.As(err, &terminalErr) {
+		return false
+	}
+	switch terminalErr.ErrorCode() {
+	case "InvalidArgument",
+		"InvalidRequest",
+		"MalformedPolicy":
+		return true
+	default:
+		return false
+	}
+}
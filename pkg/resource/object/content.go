@@ -0,0 +1,175 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package object
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlrtconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// crossNamespaceRefKindConfigMap labels the cross-namespace ConfigMap
+// references of object contents.
+const crossNamespaceRefKindConfigMap ackrt.CrossNamespaceRefKind = "configmap reference"
+
+var (
+	errSecretContentNotExclusive = ackerr.NewTerminalError(errors.New(
+		"Content.SecretKeyRef cannot be set along with Content.Data or Content.ConfigMapKeyRef",
+	))
+	errConfigMapContentNotExclusive = ackerr.NewTerminalError(errors.New(
+		"Content.ConfigMapKeyRef cannot be set along with Content.Data",
+	))
+)
+
+// configMapReader returns the client the ConfigMaps holding object contents
+// are read with.
+var configMapReader = sync.OnceValues(func() (client.Reader, error) {
+	cfg, err := ctrlrtconfig.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+})
+
+// objectContent returns the content of the object, read from the referenced
+// Secret or ConfigMap if any. It is called when the object is read, to
+// compare its content, and when it is uploaded. The content read from a
+// reference is never set in the spec.
+func (rm *resourceManager) objectContent(
+	ctx context.Context,
+	r *resource,
+) ([]byte, error) {
+	content := r.ko.Spec.Content
+	if content == nil {
+		return nil, nil
+	}
+	if content.SecretKeyRef != nil {
+		if content.Data != nil || content.ConfigMapKeyRef != nil {
+			return nil, errSecretContentNotExclusive
+		}
+		data, err := rm.rr.SecretValueFromReference(ctx, content.SecretKeyRef)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(data), nil
+	}
+	if content.ConfigMapKeyRef != nil {
+		if content.Data != nil {
+			return nil, errConfigMapContentNotExclusive
+		}
+		return rm.configMapContent(ctx, r.ko)
+	}
+	return []byte(aws.ToString(content.Data)), nil
+}
+
+// configMapContent reads the key of the ConfigMap referenced from
+// Content.ConfigMapKeyRef.
+func (rm *resourceManager) configMapContent(
+	ctx context.Context,
+	ko *svcapitypes.Object,
+) ([]byte, error) {
+	ref := ko.Spec.Content.ConfigMapKeyRef
+	if ref.Name == "" || ref.Key == "" {
+		return nil, fmt.Errorf("provided ConfigMap reference is missing a name or key: Content.ConfigMapKeyRef")
+	}
+	namespace, err := ackrt.ResolveCrossNamespaceReferenceString(
+		ctx,
+		rm.cfg.EnableCrossNamespace,
+		&ko.Status.Conditions,
+		crossNamespaceRefKindConfigMap,
+		ko.ObjectMeta.GetNamespace(),
+		ref.Namespace,
+		ref.Name,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	apiReader, err := configMapReader()
+	if err != nil {
+		return nil, err
+	}
+	configMap := &corev1.ConfigMap{}
+	namespacedName := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+	if err := apiReader.Get(ctx, namespacedName, configMap); err != nil {
+		return nil, err
+	}
+	if data, ok := configMap.Data[ref.Key]; ok {
+		return []byte(data), nil
+	}
+	if data, ok := configMap.BinaryData[ref.Key]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf(
+		"key %q not found in ConfigMap %s/%s", ref.Key, namespace, ref.Name,
+	)
+}
+
+// contentSHA256 returns the base64 encoded SHA-256 checksum of content, in
+// the form S3 reports it.
+func contentSHA256(content []byte) string {
+	sum := sha256.Sum256(content)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// contentMatches returns true if the object described by resp holds content.
+// Objects are uploaded with a SHA-256 checksum, which is compared if S3
+// reports it; otherwise, for objects uploaded outside of the controller, the
+// ETag is compared, which is the MD5 digest of single part objects that are
+// not encrypted with KMS.
+func contentMatches(content []byte, resp *svcsdk.HeadObjectOutput) bool {
+	if resp.ContentLength != nil && *resp.ContentLength != int64(len(content)) {
+		return false
+	}
+	if resp.ChecksumSHA256 != nil && !strings.Contains(*resp.ChecksumSHA256, "-") {
+		return *resp.ChecksumSHA256 == contentSHA256(content)
+	}
+	if resp.ETag != nil {
+		sum := md5.Sum(content)
+		return strings.Trim(*resp.ETag, `"`) == hex.EncodeToString(sum[:])
+	}
+	return false
+}
+
+// differentContent returns a content that differs from desired, to report
+// an object whose content differs from the desired content.
+func differentContent(desired *svcapitypes.ObjectContent) *svcapitypes.ObjectContent {
+	if desired == nil || reflect.DeepEqual(desired, &svcapitypes.ObjectContent{}) {
+		return &svcapitypes.ObjectContent{Data: aws.String("")}
+	}
+	return &svcapitypes.ObjectContent{}
+}
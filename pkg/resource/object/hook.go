@@ -0,0 +1,382 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package object
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	smithy "github.com/aws/smithy-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// The fields that S3 only sets when an object is uploaded. Changing any of
+// them uploads the object again.
+var objectUploadFields = []string{
+	"Spec.BucketKeyEnabled",
+	"Spec.CacheControl",
+	"Spec.Content",
+	"Spec.ContentDisposition",
+	"Spec.ContentEncoding",
+	"Spec.ContentLanguage",
+	"Spec.ContentType",
+	"Spec.Metadata",
+	"Spec.SSEKMSKeyID",
+	"Spec.ServerSideEncryption",
+	"Spec.StorageClass",
+}
+
+// customFindObject reads the object from S3 with HeadObject. Its content is
+// compared with the desired content through the checksum of the object, and
+// is reported as the desired content if they match, or as different content
+// otherwise, since S3 does not return the content itself.
+func (rm *resourceManager) customFindObject(
+	ctx context.Context,
+	r *resource,
+) (latest *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.customFindObject")
+	defer func() {
+		exit(err)
+	}()
+	if r.ko.Spec.Bucket == nil || r.ko.Spec.Key == nil {
+		return nil, ackerr.NotFound
+	}
+
+	resp, err := rm.sdkapi.HeadObject(ctx, &svcsdk.HeadObjectInput{
+		Bucket:       r.ko.Spec.Bucket,
+		Key:          r.ko.Spec.Key,
+		ChecksumMode: svcsdktypes.ChecksumModeEnabled,
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "HeadObject", err)
+	if err != nil {
+		var awsErr smithy.APIError
+		if errors.As(err, &awsErr) && awsErr.ErrorCode() == "NotFound" {
+			return nil, ackerr.NotFound
+		}
+		return nil, err
+	}
+
+	ko := r.ko.DeepCopy()
+	setResourceFromHeadObjectOutput(ko, resp)
+
+	tagging, err := rm.sdkapi.GetObjectTagging(ctx, &svcsdk.GetObjectTaggingInput{
+		Bucket: r.ko.Spec.Bucket,
+		Key:    r.ko.Spec.Key,
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetObjectTagging", err)
+	if err != nil {
+		return nil, err
+	}
+	ko.Spec.Tags = nil
+	for _, tag := range tagging.TagSet {
+		ko.Spec.Tags = append(ko.Spec.Tags, &svcapitypes.Tag{
+			Key:   tag.Key,
+			Value: tag.Value,
+		})
+	}
+
+	content, err := rm.objectContent(ctx, &resource{ko})
+	if err != nil {
+		return nil, err
+	}
+	if contentMatches(content, resp) {
+		ko.Spec.Content = r.ko.Spec.Content.DeepCopy()
+	} else {
+		ko.Spec.Content = differentContent(r.ko.Spec.Content)
+	}
+
+	rm.setObjectARN(ko)
+	rm.setStatusDefaults(ko)
+	return &resource{ko}, nil
+}
+
+// setResourceFromHeadObjectOutput sets the fields of the HeadObject output in
+// the spec and status of ko.
+func setResourceFromHeadObjectOutput(
+	ko *svcapitypes.Object,
+	resp *svcsdk.HeadObjectOutput,
+) {
+	ko.Spec.BucketKeyEnabled = resp.BucketKeyEnabled
+	ko.Spec.CacheControl = resp.CacheControl
+	ko.Spec.ContentDisposition = resp.ContentDisposition
+	ko.Spec.ContentEncoding = resp.ContentEncoding
+	ko.Spec.ContentLanguage = resp.ContentLanguage
+	ko.Spec.ContentType = resp.ContentType
+	ko.Spec.Metadata = nil
+	if len(resp.Metadata) > 0 {
+		ko.Spec.Metadata = aws.StringMap(resp.Metadata)
+	}
+	ko.Spec.ObjectLockLegalHoldStatus = nil
+	if resp.ObjectLockLegalHoldStatus != "" {
+		ko.Spec.ObjectLockLegalHoldStatus = aws.String(string(resp.ObjectLockLegalHoldStatus))
+	}
+	ko.Spec.ObjectLockMode = nil
+	if resp.ObjectLockMode != "" {
+		ko.Spec.ObjectLockMode = aws.String(string(resp.ObjectLockMode))
+	}
+	ko.Spec.ObjectLockRetainUntilDate = nil
+	if resp.ObjectLockRetainUntilDate != nil {
+		ko.Spec.ObjectLockRetainUntilDate = &metav1.Time{Time: *resp.ObjectLockRetainUntilDate}
+	}
+	ko.Spec.SSEKMSKeyID = resp.SSEKMSKeyId
+	ko.Spec.ServerSideEncryption = nil
+	if resp.ServerSideEncryption != "" {
+		ko.Spec.ServerSideEncryption = aws.String(string(resp.ServerSideEncryption))
+	}
+	// HeadObject omits the storage class of STANDARD objects.
+	ko.Spec.StorageClass = aws.String(string(svcsdktypes.StorageClassStandard))
+	if resp.StorageClass != "" {
+		ko.Spec.StorageClass = aws.String(string(resp.StorageClass))
+	}
+
+	ko.Status.ChecksumSHA256 = resp.ChecksumSHA256
+	ko.Status.ETag = resp.ETag
+	ko.Status.VersionID = resp.VersionId
+}
+
+// customUpdateObject uploads the object again if any of the fields S3 only
+// sets on upload differs, and otherwise updates its tags, retention and legal
+// hold individually.
+func (rm *resourceManager) customUpdateObject(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.customUpdateObject")
+	defer func() {
+		exit(err)
+	}()
+
+	for _, field := range objectUploadFields {
+		if delta.DifferentAt(field) {
+			return rm.sdkCreate(ctx, desired)
+		}
+	}
+
+	ko := desired.ko.DeepCopy()
+	ko.Status = latest.ko.Status
+	rm.setStatusDefaults(ko)
+
+	if delta.DifferentAt("Spec.Tags") {
+		if err := rm.syncTags(ctx, desired); err != nil {
+			return &resource{ko}, err
+		}
+	}
+	if delta.DifferentAt("Spec.ObjectLockMode") || delta.DifferentAt("Spec.ObjectLockRetainUntilDate") {
+		if err := rm.syncRetention(ctx, desired); err != nil {
+			return &resource{ko}, err
+		}
+	}
+	if delta.DifferentAt("Spec.ObjectLockLegalHoldStatus") {
+		if err := rm.syncLegalHold(ctx, desired); err != nil {
+			return &resource{ko}, err
+		}
+	}
+	return &resource{ko}, nil
+}
+
+// setPutObjectContent sets the content, checksum and tags of the object in
+// the PutObject request.
+func (rm *resourceManager) setPutObjectContent(
+	ctx context.Context,
+	r *resource,
+	input *svcsdk.PutObjectInput,
+) error {
+	content, err := rm.objectContent(ctx, r)
+	if err != nil {
+		return err
+	}
+	input.Body = strings.NewReader(string(content))
+	input.ContentLength = aws.Int64(int64(len(content)))
+	input.ChecksumSHA256 = aws.String(contentSHA256(content))
+	if len(r.ko.Spec.Tags) > 0 {
+		input.Tagging = aws.String(encodeTagging(r.ko.Spec.Tags))
+	}
+	return nil
+}
+
+// encodeTagging returns the tags in the URL query parameter form expected by
+// PutObject.
+func encodeTagging(tags []*svcapitypes.Tag) string {
+	values := url.Values{}
+	for _, tag := range tags {
+		values.Set(aws.ToString(tag.Key), aws.ToString(tag.Value))
+	}
+	return values.Encode()
+}
+
+// syncTags replaces the tags of the object with the desired ones.
+func (rm *resourceManager) syncTags(
+	ctx context.Context,
+	r *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.syncTags")
+	defer func() {
+		exit(err)
+	}()
+
+	if len(r.ko.Spec.Tags) == 0 {
+		_, err = rm.sdkapi.DeleteObjectTagging(ctx, &svcsdk.DeleteObjectTaggingInput{
+			Bucket: r.ko.Spec.Bucket,
+			Key:    r.ko.Spec.Key,
+		})
+		rm.metrics.RecordAPICall("UPDATE", "DeleteObjectTagging", err)
+		return err
+	}
+
+	tagSet := make([]svcsdktypes.Tag, 0, len(r.ko.Spec.Tags))
+	for _, tag := range r.ko.Spec.Tags {
+		tagSet = append(tagSet, svcsdktypes.Tag{Key: tag.Key, Value: tag.Value})
+	}
+	_, err = rm.sdkapi.PutObjectTagging(ctx, &svcsdk.PutObjectTaggingInput{
+		Bucket:  r.ko.Spec.Bucket,
+		Key:     r.ko.Spec.Key,
+		Tagging: &svcsdktypes.Tagging{TagSet: tagSet},
+	})
+	rm.metrics.RecordAPICall("UPDATE", "PutObjectTagging", err)
+	return err
+}
+
+// syncRetention sets the Object Lock retention of the object. Leaving both
+// the mode and the date unset removes a GOVERNANCE retention; S3 refuses to
+// remove a COMPLIANCE retention.
+func (rm *resourceManager) syncRetention(
+	ctx context.Context,
+	r *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.syncRetention")
+	defer func() {
+		exit(err)
+	}()
+
+	input := &svcsdk.PutObjectRetentionInput{
+		Bucket:    r.ko.Spec.Bucket,
+		Key:       r.ko.Spec.Key,
+		Retention: &svcsdktypes.ObjectLockRetention{},
+	}
+	if r.ko.Spec.ObjectLockMode != nil {
+		input.Retention.Mode = svcsdktypes.ObjectLockRetentionMode(*r.ko.Spec.ObjectLockMode)
+	}
+	if r.ko.Spec.ObjectLockRetainUntilDate != nil {
+		input.Retention.RetainUntilDate = &r.ko.Spec.ObjectLockRetainUntilDate.Time
+	}
+	if input.Retention.Mode == "" && input.Retention.RetainUntilDate == nil {
+		input.BypassGovernanceRetention = aws.Bool(true)
+	}
+	_, err = rm.sdkapi.PutObjectRetention(ctx, input)
+	rm.metrics.RecordAPICall("UPDATE", "PutObjectRetention", err)
+	return err
+}
+
+// syncLegalHold sets the legal hold status of the object, OFF if unset.
+func (rm *resourceManager) syncLegalHold(
+	ctx context.Context,
+	r *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.syncLegalHold")
+	defer func() {
+		exit(err)
+	}()
+
+	status := svcsdktypes.ObjectLockLegalHoldStatusOff
+	if r.ko.Spec.ObjectLockLegalHoldStatus != nil {
+		status = svcsdktypes.ObjectLockLegalHoldStatus(*r.ko.Spec.ObjectLockLegalHoldStatus)
+	}
+	_, err = rm.sdkapi.PutObjectLegalHold(ctx, &svcsdk.PutObjectLegalHoldInput{
+		Bucket:    r.ko.Spec.Bucket,
+		Key:       r.ko.Spec.Key,
+		LegalHold: &svcsdktypes.ObjectLockLegalHold{Status: status},
+	})
+	rm.metrics.RecordAPICall("UPDATE", "PutObjectLegalHold", err)
+	return err
+}
+
+// partition returns the AWS partition of the objects managed by rm. It
+// prefers the partition the controller resolved from its own identity and
+// falls back to the partition that contains rm's region, so that ARNs are
+// correct in the aws-cn and aws-us-gov partitions even when the former is
+// unset.
+func (rm *resourceManager) partition() string {
+	if rm.awsPartition != "" {
+		return string(rm.awsPartition)
+	}
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), string(rm.awsRegion)); ok {
+		return p.ID()
+	}
+	return endpoints.AwsPartitionID
+}
+
+// setObjectARN sets the ARN of the object in the status of ko.
+func (rm *resourceManager) setObjectARN(ko *svcapitypes.Object) {
+	if ko.Spec.Bucket == nil || ko.Spec.Key == nil {
+		return
+	}
+	if ko.Status.ACKResourceMetadata == nil {
+		ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+	}
+	arn := ackv1alpha1.AWSResourceName(fmt.Sprintf(
+		"arn:%s:s3:::%s/%s", rm.partition(), *ko.Spec.Bucket, *ko.Spec.Key,
+	))
+	ko.Status.ACKResourceMetadata.ARN = &arn
+}
+
+// customPreCompare presents the object read from S3 in the form of the
+// desired object where S3 normalizes the values it is given.
+func customPreCompare(
+	a *resource,
+	b *resource,
+) {
+	// S3 lowercases the keys of user-defined metadata.
+	for key := range a.ko.Spec.Metadata {
+		lower := strings.ToLower(key)
+		if observed, ok := b.ko.Spec.Metadata[lower]; ok && lower != key {
+			if _, ok := b.ko.Spec.Metadata[key]; !ok {
+				delete(b.ko.Spec.Metadata, lower)
+				b.ko.Spec.Metadata[key] = observed
+			}
+		}
+	}
+
+	// S3 returns the ARN of the KMS key the object is encrypted with, which
+	// may have been given by ID.
+	if a.ko.Spec.SSEKMSKeyID != nil && b.ko.Spec.SSEKMSKeyID != nil &&
+		strings.HasSuffix(*b.ko.Spec.SSEKMSKeyID, ":key/"+*a.ko.Spec.SSEKMSKeyID) {
+		b.ko.Spec.SSEKMSKeyID = a.ko.Spec.SSEKMSKeyID
+	}
+
+	// S3 only returns BucketKeyEnabled when it is true.
+	if a.ko.Spec.BucketKeyEnabled != nil && !*a.ko.Spec.BucketKeyEnabled &&
+		b.ko.Spec.BucketKeyEnabled == nil {
+		b.ko.Spec.BucketKeyEnabled = aws.Bool(false)
+	}
+}
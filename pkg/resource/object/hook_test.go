@@ -0,0 +1,426 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	smithy "github.com/aws/smithy-go"
+	smithymiddleware "github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+
+	svcapitypes "github.com/aws-controllers-k8s/s3-controller/apis/v1alpha1"
+)
+
+// opResult is a canned response for a single S3 operation. Exactly one of
+// output or err is used.
+type opResult struct {
+	output interface{}
+	err    error
+}
+
+// newMockedSDKClient builds a real *s3.Client whose middleware stack is
+// short-circuited at the Finalize step, returning the canned result of each
+// operation and recording the name of the operations called in calls.
+// Operations without a result return an empty output.
+func newMockedSDKClient(
+	results map[string]opResult,
+	calls *[]string,
+) *svcsdk.Client {
+	defaults := map[string]opResult{
+		"GetObjectTagging":    {output: &svcsdk.GetObjectTaggingOutput{}},
+		"PutObject":           {output: &svcsdk.PutObjectOutput{}},
+		"PutObjectTagging":    {output: &svcsdk.PutObjectTaggingOutput{}},
+		"DeleteObjectTagging": {output: &svcsdk.DeleteObjectTaggingOutput{}},
+		"PutObjectRetention":  {output: &svcsdk.PutObjectRetentionOutput{}},
+		"PutObjectLegalHold":  {output: &svcsdk.PutObjectLegalHoldOutput{}},
+	}
+
+	mockFinalize := smithymiddleware.FinalizeMiddlewareFunc(
+		"mockS3Finalize",
+		func(
+			ctx context.Context,
+			in smithymiddleware.FinalizeInput,
+			_ smithymiddleware.FinalizeHandler,
+		) (smithymiddleware.FinalizeOutput, smithymiddleware.Metadata, error) {
+			opName := smithymiddleware.GetOperationName(ctx)
+			if calls != nil {
+				*calls = append(*calls, opName)
+			}
+			res, ok := results[opName]
+			if !ok {
+				res = defaults[opName]
+			}
+			return smithymiddleware.FinalizeOutput{Result: res.output}, smithymiddleware.Metadata{}, res.err
+		},
+	)
+
+	return svcsdk.New(svcsdk.Options{
+		Region: "us-west-2",
+		APIOptions: []func(*smithymiddleware.Stack) error{
+			func(stack *smithymiddleware.Stack) error {
+				return stack.Finalize.Add(mockFinalize, smithymiddleware.Before)
+			},
+		},
+	})
+}
+
+func newTestResourceManager(sdkapi *svcsdk.Client) *resourceManager {
+	return &resourceManager{
+		sdkapi:       sdkapi,
+		metrics:      ackmetrics.NewMetrics("s3"),
+		awsAccountID: ackv1alpha1.AWSAccountID("111122223333"),
+		awsRegion:    ackv1alpha1.AWSRegion("us-west-2"),
+		awsPartition: ackv1alpha1.AWSPartition("aws"),
+	}
+}
+
+func newObjectResource(data string) *resource {
+	return &resource{&svcapitypes.Object{
+		Spec: svcapitypes.ObjectSpec{
+			Bucket:  aws.String("my-bucket"),
+			Key:     aws.String("path/to/object.txt"),
+			Content: &svcapitypes.ObjectContent{Data: aws.String(data)},
+		},
+	}}
+}
+
+// Test_customFindObject_NotFound verifies that a missing object is reported
+// as not found.
+func Test_customFindObject_NotFound(t *testing.T) {
+	rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+		"HeadObject": {err: &smithy.GenericAPIError{Code: "NotFound"}},
+	}, nil))
+
+	_, err := rm.customFindObject(context.TODO(), newObjectResource("hello"))
+	assert.Equal(t, ackerr.NotFound, err)
+}
+
+// Test_customFindObject_Content verifies that the content of the object is
+// compared with the desired content through its checksum or ETag.
+func Test_customFindObject_Content(t *testing.T) {
+	const data = "hello"
+	for name, tt := range map[string]struct {
+		head      *svcsdk.HeadObjectOutput
+		different bool
+	}{
+		"matching checksum": {
+			head: &svcsdk.HeadObjectOutput{
+				ContentLength:  aws.Int64(int64(len(data))),
+				ChecksumSHA256: aws.String(contentSHA256([]byte(data))),
+			},
+		},
+		"different checksum": {
+			head: &svcsdk.HeadObjectOutput{
+				ContentLength:  aws.Int64(int64(len(data))),
+				ChecksumSHA256: aws.String(contentSHA256([]byte("world"))),
+			},
+			different: true,
+		},
+		"different length": {
+			head: &svcsdk.HeadObjectOutput{
+				ContentLength:  aws.Int64(42),
+				ChecksumSHA256: aws.String(contentSHA256([]byte(data))),
+			},
+			different: true,
+		},
+		"matching ETag": {
+			head: &svcsdk.HeadObjectOutput{
+				// The MD5 digest of "hello"
+				ETag: aws.String(`"5d41402abc4b2a76b9719d911017c592"`),
+			},
+		},
+		"different ETag": {
+			head: &svcsdk.HeadObjectOutput{
+				ETag: aws.String(`"7d793037a0760186574b0282f2f435e7"`),
+			},
+			different: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+				"HeadObject": {output: tt.head},
+			}, nil))
+			desired := newObjectResource(data)
+
+			latest, err := rm.customFindObject(context.TODO(), desired)
+			require.NoError(t, err)
+			delta := newResourceDelta(desired, latest)
+			assert.Equal(t, tt.different, delta.DifferentAt("Spec.Content"))
+		})
+	}
+}
+
+// Test_customFindObject_EmptyContent verifies that an object uploaded
+// without content is not reported as different.
+func Test_customFindObject_EmptyContent(t *testing.T) {
+	rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+		"HeadObject": {output: &svcsdk.HeadObjectOutput{
+			ContentLength:  aws.Int64(0),
+			ChecksumSHA256: aws.String(contentSHA256(nil)),
+		}},
+	}, nil))
+	desired := newObjectResource("")
+	desired.ko.Spec.Content = nil
+
+	latest, err := rm.customFindObject(context.TODO(), desired)
+	require.NoError(t, err)
+	assert.False(t, newResourceDelta(desired, latest).DifferentAt("Spec.Content"))
+
+	// Content was added outside of the controller
+	rm = newTestResourceManager(newMockedSDKClient(map[string]opResult{
+		"HeadObject": {output: &svcsdk.HeadObjectOutput{
+			ContentLength:  aws.Int64(5),
+			ChecksumSHA256: aws.String(contentSHA256([]byte("hello"))),
+		}},
+	}, nil))
+	latest, err = rm.customFindObject(context.TODO(), desired)
+	require.NoError(t, err)
+	assert.True(t, newResourceDelta(desired, latest).DifferentAt("Spec.Content"))
+}
+
+// Test_customFindObject_ConfigMapContent verifies that the content of an
+// object is read from the referenced ConfigMap without being set in the spec,
+// and that a change of the ConfigMap is reported as different content.
+func Test_customFindObject_ConfigMapContent(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "content", Namespace: "default"},
+		Data:       map[string]string{"index.html": "hello"},
+		BinaryData: map[string][]byte{"logo.png": []byte("world")},
+	}
+	reader := configMapReader
+	configMapReader = func() (client.Reader, error) {
+		return fake.NewClientBuilder().WithObjects(configMap).Build(), nil
+	}
+	t.Cleanup(func() { configMapReader = reader })
+
+	rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+		"HeadObject": {output: &svcsdk.HeadObjectOutput{
+			ContentLength:  aws.Int64(5),
+			ChecksumSHA256: aws.String(contentSHA256([]byte("hello"))),
+		}},
+	}, nil))
+	desired := newObjectResource("")
+	desired.ko.Namespace = "default"
+	desired.ko.Spec.Content = &svcapitypes.ObjectContent{
+		ConfigMapKeyRef: &svcapitypes.ConfigMapKeyReference{Name: "content", Key: "index.html"},
+	}
+
+	latest, err := rm.customFindObject(context.TODO(), desired)
+	require.NoError(err)
+	assert.False(newResourceDelta(desired, latest).DifferentAt("Spec.Content"))
+	assert.Nil(latest.ko.Spec.Content.Data)
+	assert.Nil(desired.ko.Spec.Content.Data)
+
+	desired.ko.Spec.Content.ConfigMapKeyRef.Key = "logo.png"
+	latest, err = rm.customFindObject(context.TODO(), desired)
+	require.NoError(err)
+	assert.True(newResourceDelta(desired, latest).DifferentAt("Spec.Content"))
+
+	desired.ko.Spec.Content.ConfigMapKeyRef.Key = "missing"
+	_, err = rm.customFindObject(context.TODO(), desired)
+	assert.ErrorContains(err, `key "missing" not found in ConfigMap default/content`)
+
+	desired.ko.Spec.Content.Data = aws.String("hello")
+	_, err = rm.customFindObject(context.TODO(), desired)
+	assert.ErrorIs(err, errConfigMapContentNotExclusive)
+}
+
+// Test_customFindObject_Fields verifies that the fields returned by
+// HeadObject and GetObjectTagging are set in the spec and status.
+func Test_customFindObject_Fields(t *testing.T) {
+	assert := assert.New(t)
+	rm := newTestResourceManager(newMockedSDKClient(map[string]opResult{
+		"HeadObject": {output: &svcsdk.HeadObjectOutput{
+			ContentType:          aws.String("text/plain"),
+			Metadata:             map[string]string{"owner": "ack"},
+			ServerSideEncryption: svcsdktypes.ServerSideEncryptionAes256,
+			ETag:                 aws.String(`"etag"`),
+			VersionId:            aws.String("v1"),
+		}},
+		"GetObjectTagging": {output: &svcsdk.GetObjectTaggingOutput{
+			TagSet: []svcsdktypes.Tag{{Key: aws.String("env"), Value: aws.String("test")}},
+		}},
+	}, nil))
+
+	latest, err := rm.customFindObject(context.TODO(), newObjectResource("hello"))
+	require.NoError(t, err)
+	ko := latest.ko
+	assert.Equal("text/plain", *ko.Spec.ContentType)
+	assert.Equal("ack", *ko.Spec.Metadata["owner"])
+	assert.Equal("AES256", *ko.Spec.ServerSideEncryption)
+	assert.Equal("STANDARD", *ko.Spec.StorageClass)
+	assert.Nil(ko.Spec.ObjectLockMode)
+	require.Len(t, ko.Spec.Tags, 1)
+	assert.Equal("env", *ko.Spec.Tags[0].Key)
+	assert.Equal(`"etag"`, *ko.Status.ETag)
+	assert.Equal("v1", *ko.Status.VersionID)
+	assert.Equal(
+		"arn:aws:s3:::my-bucket/path/to/object.txt",
+		string(*ko.Status.ACKResourceMetadata.ARN),
+	)
+}
+
+// Test_customUpdateObject verifies that the object is uploaded again only
+// when a field S3 sets on upload changes.
+func Test_customUpdateObject(t *testing.T) {
+	for name, tt := range map[string]struct {
+		update func(*svcapitypes.ObjectSpec)
+		calls  []string
+	}{
+		"content": {
+			update: func(spec *svcapitypes.ObjectSpec) { spec.Content.Data = aws.String("world") },
+			calls:  []string{"PutObject"},
+		},
+		"metadata": {
+			update: func(spec *svcapitypes.ObjectSpec) {
+				spec.Metadata = map[string]*string{"owner": aws.String("ack")}
+			},
+			calls: []string{"PutObject"},
+		},
+		"tags": {
+			update: func(spec *svcapitypes.ObjectSpec) {
+				spec.Tags = []*svcapitypes.Tag{{Key: aws.String("env"), Value: aws.String("test")}}
+			},
+			calls: []string{"PutObjectTagging"},
+		},
+		"retention": {
+			update: func(spec *svcapitypes.ObjectSpec) {
+				spec.ObjectLockMode = aws.String("GOVERNANCE")
+			},
+			calls: []string{"PutObjectRetention"},
+		},
+		"legal hold": {
+			update: func(spec *svcapitypes.ObjectSpec) {
+				spec.ObjectLockLegalHoldStatus = aws.String("ON")
+			},
+			calls: []string{"PutObjectLegalHold"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			rm := newTestResourceManager(newMockedSDKClient(nil, &calls))
+			latest := newObjectResource("hello")
+			desired := newObjectResource("hello")
+			tt.update(&desired.ko.Spec)
+
+			updated, err := rm.customUpdateObject(
+				context.TODO(), desired, latest, newResourceDelta(desired, latest),
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.calls, calls)
+			assert.Equal(t, desired.ko.Spec, updated.ko.Spec)
+		})
+	}
+}
+
+// Test_customUpdateObject_RemoveTags verifies that removing every tag of
+// the object deletes its tag set.
+func Test_customUpdateObject_RemoveTags(t *testing.T) {
+	var calls []string
+	rm := newTestResourceManager(newMockedSDKClient(nil, &calls))
+	latest := newObjectResource("hello")
+	latest.ko.Spec.Tags = []*svcapitypes.Tag{{Key: aws.String("env"), Value: aws.String("test")}}
+	desired := newObjectResource("hello")
+
+	_, err := rm.customUpdateObject(
+		context.TODO(), desired, latest, newResourceDelta(desired, latest),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DeleteObjectTagging"}, calls)
+}
+
+// Test_customPreCompare verifies that the values S3 normalizes are not
+// reported as differences.
+func Test_customPreCompare(t *testing.T) {
+	desired := newObjectResource("hello")
+	desired.ko.Spec.Metadata = map[string]*string{"Owner": aws.String("ack")}
+	desired.ko.Spec.SSEKMSKeyID = aws.String("1234abcd-12ab-34cd-56ef-1234567890ab")
+	desired.ko.Spec.BucketKeyEnabled = aws.Bool(false)
+
+	latest := newObjectResource("hello")
+	latest.ko.Spec.Metadata = map[string]*string{"owner": aws.String("ack")}
+	latest.ko.Spec.SSEKMSKeyID = aws.String(
+		"arn:aws:kms:us-west-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+	)
+
+	delta := newResourceDelta(desired, latest)
+	assert.False(t, delta.DifferentAt("Spec.Metadata"))
+	assert.False(t, delta.DifferentAt("Spec.SSEKMSKeyID"))
+	assert.False(t, delta.DifferentAt("Spec.BucketKeyEnabled"))
+
+	// A different value is still reported
+	latest.ko.Spec.Metadata = map[string]*string{"owner": aws.String("someone")}
+	assert.True(t, newResourceDelta(desired, latest).DifferentAt("Spec.Metadata"))
+}
+
+func Test_encodeTagging(t *testing.T) {
+	assert.Equal(t, "env=test&team=a+b%26c", encodeTagging([]*svcapitypes.Tag{
+		{Key: aws.String("team"), Value: aws.String("a b&c")},
+		{Key: aws.String("env"), Value: aws.String("test")},
+	}))
+}
+
+// Test_setObjectARN_Partition verifies that object ARNs are built in the
+// partition the controller runs in, falling back to the partition of the
+// region when the controller could not resolve one.
+func Test_setObjectARN_Partition(t *testing.T) {
+	tests := []struct {
+		name      string
+		partition ackv1alpha1.AWSPartition
+		region    ackv1alpha1.AWSRegion
+		want      string
+	}{
+		{
+			name:      "govcloud",
+			partition: "aws-us-gov",
+			region:    "us-gov-west-1",
+			want:      "arn:aws-us-gov:s3:::my-bucket/path/to/object.txt",
+		},
+		{
+			name:   "china, partition derived from region",
+			region: "cn-north-1",
+			want:   "arn:aws-cn:s3:::my-bucket/path/to/object.txt",
+		},
+		{
+			name: "unknown region defaults to aws",
+			want: "arn:aws:s3:::my-bucket/path/to/object.txt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := &resourceManager{awsPartition: tt.partition, awsRegion: tt.region}
+			ko := newObjectResource("").ko
+			rm.setObjectARN(ko)
+			assert.Equal(t, tt.want, string(*ko.Status.ACKResourceMetadata.ARN))
+		})
+	}
+}
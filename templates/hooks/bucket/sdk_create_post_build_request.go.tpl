@@ -3,6 +3,9 @@
 	if err := validateDirectoryBucketSpec(desired.ko); err != nil {
 		return nil, err
 	}
+	if err := validatePolicySpec(desired.ko); err != nil {
+		return nil, err
+	}
 
 	// Only set default LocationConstraint for general-purpose buckets
 	// Directory buckets use CreateBucketConfiguration.Location instead
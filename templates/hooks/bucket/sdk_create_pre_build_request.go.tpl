@@ -0,0 +1,3 @@
+	if err := applyBucketClass(ctx, desired.ko, true); err != nil {
+		return nil, err
+	}
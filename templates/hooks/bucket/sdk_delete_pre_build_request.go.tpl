@@ -0,0 +1,22 @@
+	// Use the region the bucket was found in by the preceding read
+	rm, err = rm.managerForResource(ctx, r)
+	if err != nil {
+		return r, err
+	}
+	if err := rm.ensureDeletable(ctx, r); err != nil {
+		return r, err
+	}
+	if isForceDeleteEnabled(r) {
+		emptied, err := rm.emptyBucket(ctx, r)
+		if err != nil {
+			return r, err
+		}
+		if !emptied {
+			return r, requeueWaitWhileEmptying
+		}
+	}
+	if isDestinationPolicyManaged(r) || hasDestinationGrants(r) {
+		if err := rm.removeDestinationGrants(ctx, r); err != nil {
+			return r, err
+		}
+	}
@@ -0,0 +1,3 @@
+	if err := rm.setPutObjectContent(ctx, desired, input); err != nil {
+		return nil, err
+	}
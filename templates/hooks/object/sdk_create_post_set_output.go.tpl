@@ -0,0 +1 @@
+	rm.setObjectARN(ko)
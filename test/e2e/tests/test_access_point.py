@@ -0,0 +1,130 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the S3 AccessPoint API.
+"""
+
+import json
+import pytest
+import time
+from typing import Generator
+
+from acktest.aws.identity import get_region, get_account_id
+from acktest.resources import random_suffix_name
+from acktest.k8s import resource as k8s
+from e2e import service_marker, CRD_GROUP, CRD_VERSION, load_s3_resource
+from e2e.replacement_values import REPLACEMENT_VALUES
+from e2e.tests.test_bucket import Bucket, create_bucket, delete_bucket
+
+RESOURCE_PLURAL = "accesspoints"
+
+CREATE_WAIT_AFTER_SECONDS = 10
+MODIFY_WAIT_AFTER_SECONDS = 10
+DELETE_WAIT_AFTER_SECONDS = 10
+
+
+def get_access_point(s3control_client, name: str):
+    try:
+        return s3control_client.get_access_point(AccountId=str(get_account_id()), Name=name)
+    except s3control_client.exceptions.ClientError as e:
+        if e.response["Error"]["Code"] == "NoSuchAccessPoint":
+            return None
+        raise
+
+
+def get_access_point_policy(s3control_client, name: str):
+    try:
+        resp = s3control_client.get_access_point_policy(AccountId=str(get_account_id()), Name=name)
+        return json.loads(resp["Policy"])
+    except s3control_client.exceptions.ClientError as e:
+        if e.response["Error"]["Code"] == "NoSuchAccessPointPolicy":
+            return None
+        raise
+
+
+@pytest.fixture(scope="function")
+def access_point_bucket() -> Generator[Bucket, None, None]:
+    bucket = create_bucket("bucket")
+    assert k8s.get_resource_exists(bucket.ref)
+    k8s.wait_on_condition(bucket.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+    yield bucket
+
+    delete_bucket(bucket)
+
+
+@pytest.fixture(scope="function")
+def basic_access_point(access_point_bucket):
+    resource_name = random_suffix_name("s3-access-point", 32)
+    replacements = REPLACEMENT_VALUES.copy()
+    replacements["ACCESS_POINT_NAME"] = resource_name
+    replacements["BUCKET_NAME"] = access_point_bucket.resource_name
+    replacements["ACCOUNT_ID"] = str(get_account_id())
+    replacements["REGION"] = get_region()
+    resource_data = load_s3_resource("access_point", additional_replacements=replacements)
+
+    ref = k8s.CustomResourceReference(
+        CRD_GROUP, CRD_VERSION, RESOURCE_PLURAL,
+        resource_name, namespace="default",
+    )
+    k8s.create_custom_resource(ref, resource_data)
+    k8s.wait_resource_consumed_by_controller(ref)
+    time.sleep(CREATE_WAIT_AFTER_SECONDS)
+
+    yield (ref, access_point_bucket)
+
+    if k8s.get_resource_exists(ref):
+        _, deleted = k8s.delete_custom_resource(ref, DELETE_WAIT_AFTER_SECONDS)
+        assert deleted
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+
+
+@service_marker
+class TestAccessPoint:
+    def test_crud(self, s3control_client, basic_access_point):
+        (ref, bucket) = basic_access_point
+        k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        cr = k8s.get_resource(ref)
+        name = cr["spec"]["name"]
+        access_point = get_access_point(s3control_client, name)
+        assert access_point is not None
+        assert access_point["Bucket"] == bucket.resource_name
+        assert cr["status"]["alias"] == access_point["Alias"]
+        assert cr["status"]["networkOrigin"] == "Internet"
+
+        policy = get_access_point_policy(s3control_client, name)
+        assert policy["Statement"][0]["Action"] == "s3:GetObject"
+
+        # Updating the policy puts it again
+        updated_policy = json.loads(cr["spec"]["policy"])
+        updated_policy["Statement"][0]["Action"] = ["s3:GetObject", "s3:PutObject"]
+        k8s.patch_custom_resource(ref, {"spec": {"policy": json.dumps(updated_policy)}})
+        time.sleep(MODIFY_WAIT_AFTER_SECONDS)
+        k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        policy = get_access_point_policy(s3control_client, name)
+        assert sorted(policy["Statement"][0]["Action"]) == ["s3:GetObject", "s3:PutObject"]
+
+        # Removing the policy deletes it
+        k8s.patch_custom_resource(ref, {"spec": {"policy": None}})
+        time.sleep(MODIFY_WAIT_AFTER_SECONDS)
+        k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        assert get_access_point_policy(s3control_client, name) is None
+
+        _, deleted = k8s.delete_custom_resource(ref, DELETE_WAIT_AFTER_SECONDS)
+        assert deleted
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+
+        assert get_access_point(s3control_client, name) is None
@@ -18,6 +18,7 @@ import pytest
 import time
 import logging
 import re
+import json
 import boto3
 from typing import  Generator
 from dataclasses import dataclass
@@ -376,6 +377,8 @@ class TestBucket:
         self._update_assert_ownership_controls(basic_bucket, s3_client)
         self._update_assert_policy(basic_bucket, s3_resource)
         self._update_assert_empty_policy(basic_bucket, s3_resource)
+        self._update_assert_policy_document(basic_bucket, s3_resource)
+        self._update_assert_empty_policy(basic_bucket, s3_resource)
         self._update_assert_public_access_block(basic_bucket, s3_client)
         self._update_assert_replication(basic_bucket, s3_client)
         self._update_assert_request_payment(basic_bucket, s3_resource)
@@ -509,6 +512,24 @@ class TestBucket:
         # if there is no error, fail test
         assert False
 
+    def _update_assert_policy_document(self, bucket: Bucket, s3_resource):
+        replace_bucket_spec(bucket, "bucket_policy_document")
+
+        latest = get_bucket(s3_resource, bucket.resource_name)
+        policy = json.loads(latest.Policy().policy)
+
+        desired = bucket.resource_data["spec"]["policyDocument"]
+        assert policy["Id"] == desired["id"]
+        assert len(policy["Statement"]) == 1
+        statement = policy["Statement"][0]
+        assert statement["Effect"] == "Deny"
+        assert statement["Action"] == "s3:PutObject"
+        # The bucket ARN placeholder is resolved by the controller
+        assert statement["Resource"] == f"arn:aws:s3:::{bucket.resource_name}/*"
+
+        # The observed policy matches the desired document
+        assert k8s.wait_on_condition(bucket.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
     def _update_assert_directory_policy(self, bucket: Bucket, s3_resource):
         arn = k8s.get_resource_arn(bucket.resource_data)
         additional_replacements = {
@@ -0,0 +1,86 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the BucketAccess resource.
+"""
+
+import json
+import time
+import pytest
+from typing import Generator
+
+from acktest.aws.identity import get_account_id
+from acktest.resources import random_suffix_name
+from acktest.k8s import resource as k8s
+from e2e import service_marker, CRD_GROUP, CRD_VERSION, load_s3_resource
+from e2e.tests.test_bucket import Bucket, create_bucket, delete_bucket
+
+BUCKET_ACCESS_RESOURCE_PLURAL = "bucketaccesses"
+
+DELETE_WAIT_AFTER_SECONDS = 10
+
+
+def get_bucket_access_sids(s3_client, bucket_name: str) -> list:
+    try:
+        policy = s3_client.get_bucket_policy(Bucket=bucket_name)["Policy"]
+    except s3_client.exceptions.ClientError as e:
+        if e.response["Error"]["Code"] == "NoSuchBucketPolicy":
+            return []
+        raise
+    return [
+        statement["Sid"] for statement in json.loads(policy)["Statement"]
+        if statement.get("Sid", "").startswith("ACKBucketAccess-")
+    ]
+
+
+@pytest.fixture(scope="function")
+def bucket() -> Generator[Bucket, None, None]:
+    bucket = create_bucket("bucket")
+    k8s.wait_on_condition(bucket.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+    yield bucket
+
+    delete_bucket(bucket)
+
+
+@service_marker
+class TestBucketAccess:
+    def test_bucket_policy_grant(self, s3_client, bucket):
+        access_name = random_suffix_name("s3-bucket-access", 24)
+        resource_data = load_s3_resource("bucket_access", {
+            "BUCKET_ACCESS_NAME": access_name,
+            "BUCKET_NAME": bucket.resource_name,
+            "PRINCIPAL_ARN": f"arn:aws:iam::{get_account_id()}:root",
+        })
+        ref = k8s.CustomResourceReference(
+            CRD_GROUP, CRD_VERSION, BUCKET_ACCESS_RESOURCE_PLURAL,
+            access_name, namespace="default",
+        )
+        k8s.create_custom_resource(ref, resource_data)
+        k8s.wait_resource_consumed_by_controller(ref)
+        assert k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        assert get_bucket_access_sids(s3_client, bucket.resource_name) == [
+            f"ACKBucketAccess-default.{access_name}.0",
+            f"ACKBucketAccess-default.{access_name}.1",
+        ]
+
+        # The grants are left out of the spec of the bucket
+        cr = k8s.get_resource(bucket.ref)
+        assert "policy" not in cr["spec"]
+
+        _, deleted = k8s.delete_custom_resource(ref)
+        assert deleted
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+
+        assert get_bucket_access_sids(s3_client, bucket.resource_name) == []
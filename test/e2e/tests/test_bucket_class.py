@@ -0,0 +1,84 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the BucketClass resource.
+"""
+
+import pytest
+from typing import Generator
+
+from acktest.resources import random_suffix_name
+from acktest.k8s import resource as k8s
+from e2e import service_marker, CRD_GROUP, CRD_VERSION, load_s3_resource
+from e2e.tests.test_bucket import Bucket, create_bucket, delete_bucket
+
+BUCKET_CLASS_RESOURCE_PLURAL = "bucketclasses"
+
+
+@pytest.fixture(scope="module")
+def bucket_class() -> Generator[str, None, None]:
+    class_name = random_suffix_name("s3-bucket-class", 24)
+    resource_data = load_s3_resource("bucket_class", {"BUCKET_CLASS_NAME": class_name})
+    ref = k8s.CustomResourceReference(
+        CRD_GROUP, CRD_VERSION, BUCKET_CLASS_RESOURCE_PLURAL,
+        class_name, namespace=None,
+    )
+    k8s.create_custom_resource(ref, resource_data)
+
+    yield class_name
+
+    k8s.delete_custom_resource(ref)
+
+
+@pytest.fixture(scope="function")
+def bucket_with_class(bucket_class) -> Generator[Bucket, None, None]:
+    bucket = create_bucket("bucket_with_class", additional_replacements={
+        "BUCKET_CLASS_NAME": bucket_class,
+    })
+    k8s.wait_on_condition(bucket.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+    yield bucket
+
+    delete_bucket(bucket)
+
+
+@service_marker
+class TestBucketClass:
+    def test_class_defaults_applied(self, s3_client, bucket_class, bucket_with_class):
+        bucket = bucket_with_class
+        name = bucket.resource_name
+
+        versioning = s3_client.get_bucket_versioning(Bucket=name)
+        assert versioning["Status"] == "Enabled"
+
+        public_access_block = s3_client.get_public_access_block(Bucket=name)
+        config = public_access_block["PublicAccessBlockConfiguration"]
+        assert config["BlockPublicAcls"] is False
+        assert config["BlockPublicPolicy"] is True
+
+        tagging = s3_client.get_bucket_tagging(Bucket=name)
+        tag_set = {tag["Key"]: tag["Value"] for tag in tagging["TagSet"]}
+        assert tag_set["team"] == "analytics"
+        assert tag_set["tier"] == "standard"
+
+        # The class is reported in the status and kept out of the spec
+        cr = k8s.get_resource(bucket.ref)
+        assert cr["status"]["bucketClass"]["name"] == bucket_class
+        assert sorted(cr["status"]["bucketClass"]["fields"]) == [
+            "publicAccessBlock.blockPublicPolicy",
+            "tagging.tagSet[tier]",
+            "versioning",
+        ]
+        assert "versioning" not in cr["spec"]
+        assert cr["spec"]["publicAccessBlock"] == {"blockPublicACLs": False}
+        assert [tag["key"] for tag in cr["spec"]["tagging"]["tagSet"]] == ["team"]
@@ -0,0 +1,66 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the connection details of the Bucket resource.
+"""
+
+import base64
+import time
+import pytest
+from typing import Generator, Tuple
+
+from kubernetes import client as k8s_client
+from acktest.aws.identity import get_region
+from acktest.resources import random_suffix_name
+from acktest.k8s import resource as k8s
+from e2e import service_marker
+from e2e.tests.test_bucket import Bucket, create_bucket, delete_bucket
+
+UPDATE_WAIT_AFTER_SECONDS = 10
+
+
+@pytest.fixture(scope="function")
+def bucket_with_connection_details() -> Generator[Tuple[Bucket, str], None, None]:
+    secret_name = random_suffix_name("s3-bucket-conn", 24)
+    bucket = create_bucket("bucket_connection_details", additional_replacements={
+        "SECRET_NAME": secret_name,
+    })
+    k8s.wait_on_condition(bucket.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+    yield bucket, secret_name
+
+    delete_bucket(bucket)
+
+
+@service_marker
+class TestBucketConnectionDetails:
+    def test_connection_details_secret(self, bucket_with_connection_details):
+        bucket, secret_name = bucket_with_connection_details
+        name = bucket.resource_name
+        region = get_region()
+        time.sleep(UPDATE_WAIT_AFTER_SECONDS)
+
+        api = k8s_client.CoreV1Api(k8s._get_k8s_api_client())
+        secret = api.read_namespaced_secret(secret_name, "default")
+        data = {k: base64.b64decode(v).decode() for k, v in secret.data.items()}
+
+        assert data["BUCKET_NAME"] == name
+        assert data["BUCKET_REGION"] == region
+        assert data["BUCKET_ARN"] == f"arn:aws:s3:::{name}"
+        assert data["BUCKET_ENDPOINT_URL"] == f"https://s3.{region}.amazonaws.com"
+        assert data["BUCKET_VIRTUAL_HOSTED_URL"] == f"https://{name}.s3.{region}.amazonaws.com"
+
+        owners = secret.metadata.owner_references
+        assert len(owners) == 1
+        assert owners[0].kind == "Bucket"
+        assert owners[0].name == name
@@ -0,0 +1,75 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the deletion-protection annotation on Bucket.
+"""
+
+import pytest
+import time
+from typing import Generator
+
+from acktest.k8s import resource as k8s
+from e2e import service_marker
+from e2e.tests.test_bucket import Bucket, bucket_exists, create_bucket, delete_bucket
+
+DELETION_PROTECTION_ANNOTATION = "s3.services.k8s.aws/deletion-protection"
+# The controller checks again whether a retained bucket can be deleted every
+# minute
+DELETION_RETRY_WAIT_PERIODS = 18
+DELETION_RETRY_PERIOD_LENGTH = 10
+
+
+def synced_condition_reason(bucket: Bucket) -> str:
+    cr = k8s.get_resource(bucket.ref)
+    for cond in cr.get("status", {}).get("conditions", []):
+        if cond["type"] == "ACK.ResourceSynced":
+            return cond.get("reason", "")
+    return ""
+
+
+@pytest.fixture(scope="function")
+def protected_bucket(s3_client) -> Generator[Bucket, None, None]:
+    bucket = create_bucket("bucket_deletion_protection")
+    k8s.wait_on_condition(bucket.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+    assert bucket_exists(s3_client, bucket)
+
+    yield bucket
+
+    # Clean up in case the test failed before lifting the protection
+    if k8s.get_resource_exists(bucket.ref):
+        k8s.patch_custom_resource(bucket.ref, {
+            "metadata": {"annotations": {DELETION_PROTECTION_ANNOTATION: "false"}},
+        })
+        delete_bucket(bucket)
+
+
+@service_marker
+class TestBucketDeletionProtection:
+    def test_protected_bucket_is_retained(self, s3_client, protected_bucket):
+        bucket = protected_bucket
+
+        _, deleted = k8s.delete_custom_resource(bucket.ref, 3, 10)
+        assert not deleted
+        assert synced_condition_reason(bucket) == "DeletionProtected"
+        assert bucket_exists(s3_client, bucket)
+
+        k8s.patch_custom_resource(bucket.ref, {
+            "metadata": {"annotations": {DELETION_PROTECTION_ANNOTATION: "false"}},
+        })
+        for _ in range(DELETION_RETRY_WAIT_PERIODS):
+            if not k8s.get_resource_exists(bucket.ref):
+                break
+            time.sleep(DELETION_RETRY_PERIOD_LENGTH)
+
+        assert not k8s.get_resource_exists(bucket.ref)
+        assert not bucket_exists(s3_client, bucket)
@@ -0,0 +1,70 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the manage-destination-policies annotation on Bucket.
+"""
+
+import json
+import pytest
+from typing import Generator, Tuple
+
+from acktest.k8s import resource as k8s
+from e2e import service_marker
+from e2e.tests.test_bucket import Bucket, create_bucket, delete_bucket
+
+GRANT_SID_PREFIX = "ACKDestinationGrant-"
+
+
+def get_policy_sids(s3_client, bucket_name: str) -> list:
+    try:
+        policy = s3_client.get_bucket_policy(Bucket=bucket_name)["Policy"]
+    except s3_client.exceptions.ClientError as e:
+        if e.response["Error"]["Code"] == "NoSuchBucketPolicy":
+            return []
+        raise
+    return [statement.get("Sid", "") for statement in json.loads(policy)["Statement"]]
+
+
+@pytest.fixture(scope="function")
+def source_and_destination() -> Generator[Tuple[Bucket, Bucket], None, None]:
+    destination = create_bucket("bucket")
+    k8s.wait_on_condition(destination.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+    source = create_bucket(
+        "bucket_destination_policies",
+        additional_replacements={"DESTINATION_BUCKET_NAME": destination.resource_name},
+    )
+    k8s.wait_on_condition(source.ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+    yield source, destination
+
+    delete_bucket(source)
+    delete_bucket(destination)
+
+
+@service_marker
+class TestBucketDestinationPolicies:
+    def test_logging_grant(self, s3_client, source_and_destination):
+        source, destination = source_and_destination
+
+        sids = get_policy_sids(s3_client, destination.resource_name)
+        assert f"{GRANT_SID_PREFIX}Logging-{source.resource_name}" in sids
+
+        # The grant is not part of the destination's own policy, so the
+        # destination stays in sync
+        assert k8s.wait_on_condition(destination.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        delete_bucket(source)
+
+        sids = get_policy_sids(s3_client, destination.resource_name)
+        assert not any(sid.startswith(GRANT_SID_PREFIX) for sid in sids)
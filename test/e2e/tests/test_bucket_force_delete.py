@@ -0,0 +1,63 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the force-delete annotation on Bucket.
+"""
+
+import pytest
+import logging
+from typing import Generator
+
+from acktest.k8s import resource as k8s
+from e2e import service_marker
+from e2e.tests.test_bucket import Bucket, bucket_exists, create_bucket, delete_bucket
+
+
+@pytest.fixture(scope="function")
+def force_delete_bucket(s3_client) -> Generator[Bucket, None, None]:
+    bucket = None
+    try:
+        bucket = create_bucket("bucket_force_delete")
+        assert k8s.get_resource_exists(bucket.ref)
+        k8s.wait_on_condition(bucket.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        exists = bucket_exists(s3_client, bucket)
+        assert exists
+    except:
+        if bucket is not None:
+            delete_bucket(bucket)
+        return pytest.fail("Bucket failed to create")
+
+    yield bucket
+
+    # Clean up in case the test failed before the bucket was emptied
+    delete_bucket(bucket)
+
+
+@service_marker
+class TestForceDeleteBucket:
+    def test_force_delete_non_empty_bucket(self, s3_client, force_delete_bucket):
+        bucket = force_delete_bucket
+
+        # Leave behind two versions, a delete marker and an incomplete
+        # multipart upload, none of which DeleteBucket tolerates.
+        s3_client.put_object(Bucket=bucket.resource_name, Key="a.txt", Body=b"one")
+        s3_client.put_object(Bucket=bucket.resource_name, Key="a.txt", Body=b"two")
+        s3_client.delete_object(Bucket=bucket.resource_name, Key="a.txt")
+        s3_client.create_multipart_upload(Bucket=bucket.resource_name, Key="big.bin")
+        logging.info(f"Populated bucket {bucket.resource_name}")
+
+        delete_bucket(bucket)
+
+        assert not k8s.get_resource_exists(bucket.ref)
+        assert not bucket_exists(s3_client, bucket)
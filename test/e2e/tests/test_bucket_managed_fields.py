@@ -0,0 +1,78 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for Buckets only managing the configurations declared in
+their spec.
+"""
+
+import pytest
+import time
+from typing import Generator
+
+from acktest.k8s import resource as k8s
+from e2e import service_marker
+from e2e.tests.test_bucket import (
+    Bucket,
+    MODIFY_WAIT_AFTER_SECONDS,
+    bucket_exists,
+    create_bucket,
+    delete_bucket,
+)
+
+EXTERNAL_LIFECYCLE_CONFIGURATION = {
+    "Rules": [{
+        "ID": "external",
+        "Status": "Enabled",
+        "Filter": {"Prefix": "logs/"},
+        "Expiration": {"Days": 30},
+    }],
+}
+
+
+@pytest.fixture(scope="function")
+def explicit_bucket(s3_client) -> Generator[Bucket, None, None]:
+    bucket = create_bucket("bucket_managed_fields")
+    k8s.wait_on_condition(bucket.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+    assert bucket_exists(s3_client, bucket)
+
+    yield bucket
+
+    delete_bucket(bucket)
+
+
+@service_marker
+class TestBucketManagedFields:
+    def test_undeclared_configuration_is_left_alone(self, s3_client, explicit_bucket):
+        bucket = explicit_bucket
+
+        # Another tool configures a property the spec leaves out
+        s3_client.put_bucket_lifecycle_configuration(
+            Bucket=bucket.resource_name,
+            LifecycleConfiguration=EXTERNAL_LIFECYCLE_CONFIGURATION,
+        )
+
+        # Trigger an update of a declared property
+        k8s.patch_custom_resource(bucket.ref, {
+            "spec": {"tagging": {"tagSet": [{"key": "team", "value": "platform"}]}},
+        })
+        time.sleep(MODIFY_WAIT_AFTER_SECONDS)
+        k8s.wait_on_condition(bucket.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        tags = s3_client.get_bucket_tagging(Bucket=bucket.resource_name)["TagSet"]
+        assert {"Key": "team", "Value": "platform"} in tags
+
+        lifecycle = s3_client.get_bucket_lifecycle_configuration(Bucket=bucket.resource_name)
+        assert [rule["ID"] for rule in lifecycle["Rules"]] == ["external"]
+
+        cr = k8s.get_resource(bucket.ref)
+        assert "lifecycle" not in cr["spec"]
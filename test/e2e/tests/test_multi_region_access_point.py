@@ -0,0 +1,152 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the S3 MultiRegionAccessPoint API.
+"""
+
+import boto3
+import json
+import pytest
+import time
+from typing import Generator
+
+from acktest.aws.identity import get_account_id
+from acktest.resources import random_suffix_name
+from acktest.k8s import resource as k8s
+from e2e import service_marker, CRD_GROUP, CRD_VERSION, load_s3_resource
+from e2e.replacement_values import REPLACEMENT_VALUES
+from e2e.tests.test_bucket import Bucket, create_bucket, delete_bucket
+
+RESOURCE_PLURAL = "multiregionaccesspoints"
+
+# Requests about Multi-Region Access Points are only served in us-west-2
+CONTROL_PLANE_REGION = "us-west-2"
+
+CREATE_WAIT_AFTER_SECONDS = 10
+MODIFY_WAIT_AFTER_SECONDS = 10
+DELETE_WAIT_AFTER_SECONDS = 10
+
+# Creating or deleting a Multi-Region Access Point takes several minutes
+SYNC_WAIT_PERIODS = 60
+SYNC_PERIOD_LENGTH = 15
+
+
+@pytest.fixture(scope="module")
+def mrap_client():
+    return boto3.client("s3control", region_name=CONTROL_PLANE_REGION)
+
+
+def get_multi_region_access_point(mrap_client, name: str):
+    try:
+        resp = mrap_client.get_multi_region_access_point(AccountId=str(get_account_id()), Name=name)
+        return resp["AccessPoint"]
+    except mrap_client.exceptions.ClientError as e:
+        if e.response["Error"]["Code"] == "NoSuchMultiRegionAccessPoint":
+            return None
+        raise
+
+
+def get_multi_region_access_point_policy(mrap_client, name: str):
+    resp = mrap_client.get_multi_region_access_point_policy(AccountId=str(get_account_id()), Name=name)
+    return json.loads(resp["Policy"]["Established"]["Policy"])
+
+
+def get_routes(mrap_client, arn: str):
+    resp = mrap_client.get_multi_region_access_point_routes(AccountId=str(get_account_id()), Mrap=arn)
+    return {route["Bucket"]: route["TrafficDialPercentage"] for route in resp["Routes"]}
+
+
+@pytest.fixture(scope="function")
+def mrap_bucket() -> Generator[Bucket, None, None]:
+    bucket = create_bucket("bucket")
+    assert k8s.get_resource_exists(bucket.ref)
+    k8s.wait_on_condition(bucket.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+    yield bucket
+
+    delete_bucket(bucket)
+
+
+@pytest.fixture(scope="function")
+def basic_multi_region_access_point(mrap_bucket):
+    resource_name = random_suffix_name("s3-mrap", 32)
+    replacements = REPLACEMENT_VALUES.copy()
+    replacements["MRAP_NAME"] = resource_name
+    replacements["BUCKET_NAME"] = mrap_bucket.resource_name
+    replacements["ACCOUNT_ID"] = str(get_account_id())
+    resource_data = load_s3_resource("multi_region_access_point", additional_replacements=replacements)
+
+    ref = k8s.CustomResourceReference(
+        CRD_GROUP, CRD_VERSION, RESOURCE_PLURAL,
+        resource_name, namespace="default",
+    )
+    k8s.create_custom_resource(ref, resource_data)
+    k8s.wait_resource_consumed_by_controller(ref)
+    time.sleep(CREATE_WAIT_AFTER_SECONDS)
+
+    yield (ref, mrap_bucket)
+
+    if k8s.get_resource_exists(ref):
+        _, deleted = k8s.delete_custom_resource(
+            ref, period_length=SYNC_PERIOD_LENGTH, wait_periods=SYNC_WAIT_PERIODS,
+        )
+        assert deleted
+
+
+@service_marker
+class TestMultiRegionAccessPoint:
+    def test_crud(self, mrap_client, basic_multi_region_access_point):
+        (ref, bucket) = basic_multi_region_access_point
+        k8s.wait_on_condition(
+            ref, "ACK.ResourceSynced", "True",
+            wait_periods=SYNC_WAIT_PERIODS, period_length=SYNC_PERIOD_LENGTH,
+        )
+
+        cr = k8s.get_resource(ref)
+        name = cr["spec"]["name"]
+        mrap = get_multi_region_access_point(mrap_client, name)
+        assert mrap is not None
+        assert mrap["Status"] == "READY"
+        assert mrap["Regions"][0]["Bucket"] == bucket.resource_name
+        assert cr["status"]["alias"] == mrap["Alias"]
+        assert "requestTokenARN" not in cr["status"]
+
+        # The policy is put once the Multi-Region Access Point is created
+        policy = get_multi_region_access_point_policy(mrap_client, name)
+        assert policy["Statement"][0]["Action"] == "s3:GetObject"
+
+        arn = cr["status"]["ackResourceMetadata"]["arn"]
+        assert get_routes(mrap_client, arn) == {bucket.resource_name: 100}
+
+        # Setting the traffic dial percentage submits the route
+        regions = cr["spec"]["regions"]
+        regions[0]["trafficDialPercentage"] = 0
+        k8s.patch_custom_resource(ref, {"spec": {"regions": regions}})
+        time.sleep(MODIFY_WAIT_AFTER_SECONDS)
+        k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        assert get_routes(mrap_client, arn) == {bucket.resource_name: 0}
+
+        # Changing the buckets is rejected
+        regions[0]["bucket"] = "another-bucket"
+        regions[0].pop("bucketRef")
+        k8s.patch_custom_resource(ref, {"spec": {"regions": regions}})
+        time.sleep(MODIFY_WAIT_AFTER_SECONDS)
+        k8s.wait_on_condition(ref, "ACK.Terminal", "True", wait_periods=5)
+
+        _, deleted = k8s.delete_custom_resource(
+            ref, period_length=SYNC_PERIOD_LENGTH, wait_periods=SYNC_WAIT_PERIODS,
+        )
+        assert deleted
+
+        assert get_multi_region_access_point(mrap_client, name) is None
@@ -0,0 +1,143 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the S3 Object API.
+"""
+
+import pytest
+import time
+import logging
+from typing import Generator
+
+from acktest.resources import random_suffix_name
+from acktest.k8s import resource as k8s
+from e2e import service_marker, CRD_GROUP, CRD_VERSION, load_s3_resource
+from e2e.replacement_values import REPLACEMENT_VALUES
+from e2e.tests.test_bucket import Bucket, create_bucket, delete_bucket
+
+RESOURCE_PLURAL = "objects"
+
+CREATE_WAIT_AFTER_SECONDS = 10
+MODIFY_WAIT_AFTER_SECONDS = 10
+DELETE_WAIT_AFTER_SECONDS = 10
+
+OBJECT_KEY = "path/to/object.txt"
+OBJECT_DATA = "hello from ACK"
+
+
+def get_object(s3_client, bucket_name: str, key: str):
+    try:
+        return s3_client.get_object(Bucket=bucket_name, Key=key)
+    except s3_client.exceptions.NoSuchKey:
+        return None
+
+
+def get_object_tags(s3_client, bucket_name: str, key: str) -> dict:
+    resp = s3_client.get_object_tagging(Bucket=bucket_name, Key=key)
+    return {tag["Key"]: tag["Value"] for tag in resp["TagSet"]}
+
+
+@pytest.fixture(scope="function")
+def object_bucket() -> Generator[Bucket, None, None]:
+    bucket = create_bucket("bucket")
+    assert k8s.get_resource_exists(bucket.ref)
+    k8s.wait_on_condition(bucket.ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+    yield bucket
+
+    delete_bucket(bucket)
+
+
+@pytest.fixture(scope="function")
+def basic_object(object_bucket):
+    resource_name = random_suffix_name("s3-object", 24)
+    replacements = REPLACEMENT_VALUES.copy()
+    replacements["OBJECT_NAME"] = resource_name
+    replacements["BUCKET_NAME"] = object_bucket.resource_name
+    replacements["OBJECT_KEY"] = OBJECT_KEY
+    replacements["OBJECT_DATA"] = OBJECT_DATA
+    resource_data = load_s3_resource("object", additional_replacements=replacements)
+
+    ref = k8s.CustomResourceReference(
+        CRD_GROUP, CRD_VERSION, RESOURCE_PLURAL,
+        resource_name, namespace="default",
+    )
+    k8s.create_custom_resource(ref, resource_data)
+    k8s.wait_resource_consumed_by_controller(ref)
+    time.sleep(CREATE_WAIT_AFTER_SECONDS)
+
+    yield (ref, object_bucket)
+
+    if k8s.get_resource_exists(ref):
+        _, deleted = k8s.delete_custom_resource(ref, DELETE_WAIT_AFTER_SECONDS)
+        assert deleted
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+
+
+@service_marker
+class TestObject:
+    def test_crud(self, s3_client, basic_object):
+        (ref, bucket) = basic_object
+        k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        obj = get_object(s3_client, bucket.resource_name, OBJECT_KEY)
+        assert obj is not None
+        assert obj["Body"].read().decode() == OBJECT_DATA
+        assert obj["ContentType"] == "text/plain"
+        assert obj["Metadata"] == {"owner": "ack"}
+        assert get_object_tags(s3_client, bucket.resource_name, OBJECT_KEY) == {"env": "test"}
+
+        cr = k8s.get_resource(ref)
+        assert cr["status"]["eTag"] == obj["ETag"]
+        assert cr["status"]["ackResourceMetadata"]["arn"] == \
+            f"arn:aws:s3:::{bucket.resource_name}/{OBJECT_KEY}"
+
+        # Updating the content uploads the object again
+        updated_data = "updated by ACK"
+        k8s.patch_custom_resource(ref, {"spec": {"content": {"data": updated_data}}})
+        time.sleep(MODIFY_WAIT_AFTER_SECONDS)
+        k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        obj = get_object(s3_client, bucket.resource_name, OBJECT_KEY)
+        assert obj["Body"].read().decode() == updated_data
+
+        # Updating the tags leaves the content untouched
+        k8s.patch_custom_resource(ref, {"spec": {"tags": [{"key": "env", "value": "prod"}]}})
+        time.sleep(MODIFY_WAIT_AFTER_SECONDS)
+        k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        assert get_object_tags(s3_client, bucket.resource_name, OBJECT_KEY) == {"env": "prod"}
+        assert get_object(s3_client, bucket.resource_name, OBJECT_KEY)["ETag"] == obj["ETag"]
+
+        _, deleted = k8s.delete_custom_resource(ref, DELETE_WAIT_AFTER_SECONDS)
+        assert deleted
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+
+        assert get_object(s3_client, bucket.resource_name, OBJECT_KEY) is None
+
+    def test_content_drift(self, s3_client, basic_object):
+        (ref, bucket) = basic_object
+        k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        # Overwrite the object outside of the controller
+        s3_client.put_object(Bucket=bucket.resource_name, Key=OBJECT_KEY, Body=b"drifted")
+        logging.info(f"Overwrote object {OBJECT_KEY} in {bucket.resource_name}")
+
+        # Any spec change triggers a reconciliation, which detects the drift
+        # and restores the desired content
+        k8s.patch_custom_resource(ref, {"spec": {"tags": [{"key": "env", "value": "drift"}]}})
+        time.sleep(MODIFY_WAIT_AFTER_SECONDS)
+        k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        obj = get_object(s3_client, bucket.resource_name, OBJECT_KEY)
+        assert obj["Body"].read().decode() == OBJECT_DATA